@@ -44,9 +44,18 @@ func main() {
 	channeld.InitMetrics()
 	channeld.InitConnections(channeld.GlobalSettings.ServerFSM, channeld.GlobalSettings.ClientFSM)
 	channeld.InitChannels()
+	if err := channeld.InitAuthProvider(); err != nil {
+		fmt.Printf("error initializing auth provider: %v\n", err)
+		return
+	}
+	if err := channeld.InitBanList(); err != nil {
+		fmt.Printf("error initializing ban list: %v\n", err)
+		return
+	}
 
 	// Setup Prometheus
 	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/bans", channeld.BanListHandler())
 	go http.ListenAndServe(":8080", nil)
 
 	go channeld.StartListening(channeldpb.ConnectionType_SERVER, channeld.GlobalSettings.ServerNetwork, channeld.GlobalSettings.ServerAddress)
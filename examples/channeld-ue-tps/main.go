@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/metaworking/channeld/pkg/channeld"
 	"github.com/metaworking/channeld/pkg/channeldpb"
@@ -21,7 +24,22 @@ func main() {
 	channeld.InitConnections(channeld.GlobalSettings.ServerFSM, channeld.GlobalSettings.ClientFSM)
 	channeld.InitChannels()
 
-	channeld.InitSpatialController()
+	// The custom spatial controllers should be registered with channeld.RegisterSpatialControllerType() before the initialization.
+	if err := channeld.InitSpatialController(); err != nil {
+		fmt.Printf("error initializing spatial controller: %v\n", err)
+		return
+	}
+
+	// Reload the spatial damping profiles when the process receives SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := channeld.ReloadSpatialDampingProfiles(); err != nil {
+				fmt.Printf("error reloading spatial damping profiles: %v\n", err)
+			}
+		}
+	}()
 
 	unreal.InitMessageHandlers()
 	channeld.RegisterChannelDataType(channeldpb.ChannelType_SPATIAL, &unrealpb.SpatialChannelData{})
@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/metaworking/channeld/examples/unity-mirror-tanks/tankspb"
 	"github.com/metaworking/channeld/pkg/channeld"
@@ -22,7 +25,21 @@ func main() {
 
 	channeld.RegisterChannelDataType(channeldpb.ChannelType_SUBWORLD, &tankspb.TankGameChannelData{})
 
-	channeld.InitSpatialController()
+	if err := channeld.InitSpatialController(); err != nil {
+		fmt.Printf("error initializing spatial controller: %v\n", err)
+		return
+	}
+
+	// Reload the spatial damping profiles when the process receives SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := channeld.ReloadSpatialDampingProfiles(); err != nil {
+				fmt.Printf("error reloading spatial damping profiles: %v\n", err)
+			}
+		}
+	}()
 
 	// Setup Prometheus
 	http.Handle("/metrics", promhttp.Handler())
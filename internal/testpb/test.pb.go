@@ -76,6 +76,61 @@ func (x *TestChannelDataMessage) GetNum() uint32 {
 	return 0
 }
 
+type TestCounterMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count *int64 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	Total int64  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *TestCounterMessage) Reset() {
+	*x = TestCounterMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCounterMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCounterMessage) ProtoMessage() {}
+
+func (x *TestCounterMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCounterMessage.ProtoReflect.Descriptor instead.
+func (*TestCounterMessage) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TestCounterMessage) GetCount() int64 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *TestCounterMessage) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type TestAnyMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -88,7 +143,7 @@ type TestAnyMessage struct {
 func (x *TestAnyMessage) Reset() {
 	*x = TestAnyMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[1]
+		mi := &file_test_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +156,7 @@ func (x *TestAnyMessage) String() string {
 func (*TestAnyMessage) ProtoMessage() {}
 
 func (x *TestAnyMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[1]
+	mi := &file_test_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +169,7 @@ func (x *TestAnyMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestAnyMessage.ProtoReflect.Descriptor instead.
 func (*TestAnyMessage) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{1}
+	return file_test_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *TestAnyMessage) GetMsg() *anypb.Any {
@@ -143,7 +198,7 @@ type TestMergeMessage struct {
 func (x *TestMergeMessage) Reset() {
 	*x = TestMergeMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[2]
+		mi := &file_test_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -156,7 +211,7 @@ func (x *TestMergeMessage) String() string {
 func (*TestMergeMessage) ProtoMessage() {}
 
 func (x *TestMergeMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[2]
+	mi := &file_test_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +224,7 @@ func (x *TestMergeMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestMergeMessage.ProtoReflect.Descriptor instead.
 func (*TestMergeMessage) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{2}
+	return file_test_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TestMergeMessage) GetList() []string {
@@ -199,7 +254,7 @@ type TestMapMessage struct {
 func (x *TestMapMessage) Reset() {
 	*x = TestMapMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[3]
+		mi := &file_test_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -212,7 +267,7 @@ func (x *TestMapMessage) String() string {
 func (*TestMapMessage) ProtoMessage() {}
 
 func (x *TestMapMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[3]
+	mi := &file_test_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,7 +280,7 @@ func (x *TestMapMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestMapMessage.ProtoReflect.Descriptor instead.
 func (*TestMapMessage) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{3}
+	return file_test_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TestMapMessage) GetKv() map[uint32]string {
@@ -264,7 +319,7 @@ type TestFieldMaskMessage struct {
 func (x *TestFieldMaskMessage) Reset() {
 	*x = TestFieldMaskMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[4]
+		mi := &file_test_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -277,7 +332,7 @@ func (x *TestFieldMaskMessage) String() string {
 func (*TestFieldMaskMessage) ProtoMessage() {}
 
 func (x *TestFieldMaskMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[4]
+	mi := &file_test_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +345,7 @@ func (x *TestFieldMaskMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestFieldMaskMessage.ProtoReflect.Descriptor instead.
 func (*TestFieldMaskMessage) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{4}
+	return file_test_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TestFieldMaskMessage) GetName() string {
@@ -339,7 +394,7 @@ type TestAnyMessage_Type1 struct {
 func (x *TestAnyMessage_Type1) Reset() {
 	*x = TestAnyMessage_Type1{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[5]
+		mi := &file_test_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -352,7 +407,7 @@ func (x *TestAnyMessage_Type1) String() string {
 func (*TestAnyMessage_Type1) ProtoMessage() {}
 
 func (x *TestAnyMessage_Type1) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[5]
+	mi := &file_test_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +420,7 @@ func (x *TestAnyMessage_Type1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestAnyMessage_Type1.ProtoReflect.Descriptor instead.
 func (*TestAnyMessage_Type1) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{1, 0}
+	return file_test_proto_rawDescGZIP(), []int{2, 0}
 }
 
 func (x *TestAnyMessage_Type1) GetValue() string {
@@ -386,7 +441,7 @@ type TestAnyMessage_Type2 struct {
 func (x *TestAnyMessage_Type2) Reset() {
 	*x = TestAnyMessage_Type2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[6]
+		mi := &file_test_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -399,7 +454,7 @@ func (x *TestAnyMessage_Type2) String() string {
 func (*TestAnyMessage_Type2) ProtoMessage() {}
 
 func (x *TestAnyMessage_Type2) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[6]
+	mi := &file_test_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +467,7 @@ func (x *TestAnyMessage_Type2) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestAnyMessage_Type2.ProtoReflect.Descriptor instead.
 func (*TestAnyMessage_Type2) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{1, 1}
+	return file_test_proto_rawDescGZIP(), []int{2, 1}
 }
 
 func (x *TestAnyMessage_Type2) GetValue() int64 {
@@ -434,7 +489,7 @@ type TestMergeMessage_StringWrapper struct {
 func (x *TestMergeMessage_StringWrapper) Reset() {
 	*x = TestMergeMessage_StringWrapper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[7]
+		mi := &file_test_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -447,7 +502,7 @@ func (x *TestMergeMessage_StringWrapper) String() string {
 func (*TestMergeMessage_StringWrapper) ProtoMessage() {}
 
 func (x *TestMergeMessage_StringWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[7]
+	mi := &file_test_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -460,7 +515,7 @@ func (x *TestMergeMessage_StringWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestMergeMessage_StringWrapper.ProtoReflect.Descriptor instead.
 func (*TestMergeMessage_StringWrapper) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{2, 0}
+	return file_test_proto_rawDescGZIP(), []int{3, 0}
 }
 
 func (x *TestMergeMessage_StringWrapper) GetRemoved() bool {
@@ -489,7 +544,7 @@ type TestMapMessage_StringWrapper struct {
 func (x *TestMapMessage_StringWrapper) Reset() {
 	*x = TestMapMessage_StringWrapper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[10]
+		mi := &file_test_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -502,7 +557,7 @@ func (x *TestMapMessage_StringWrapper) String() string {
 func (*TestMapMessage_StringWrapper) ProtoMessage() {}
 
 func (x *TestMapMessage_StringWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[10]
+	mi := &file_test_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +570,7 @@ func (x *TestMapMessage_StringWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestMapMessage_StringWrapper.ProtoReflect.Descriptor instead.
 func (*TestMapMessage_StringWrapper) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{3, 1}
+	return file_test_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *TestMapMessage_StringWrapper) GetContent() string {
@@ -544,7 +599,7 @@ type TestMapMessage_NullableWrapper struct {
 func (x *TestMapMessage_NullableWrapper) Reset() {
 	*x = TestMapMessage_NullableWrapper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[12]
+		mi := &file_test_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +612,7 @@ func (x *TestMapMessage_NullableWrapper) String() string {
 func (*TestMapMessage_NullableWrapper) ProtoMessage() {}
 
 func (x *TestMapMessage_NullableWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[12]
+	mi := &file_test_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +625,7 @@ func (x *TestMapMessage_NullableWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestMapMessage_NullableWrapper.ProtoReflect.Descriptor instead.
 func (*TestMapMessage_NullableWrapper) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{3, 3}
+	return file_test_proto_rawDescGZIP(), []int{4, 3}
 }
 
 func (x *TestMapMessage_NullableWrapper) GetContent() string {
@@ -599,7 +654,7 @@ type TestFieldMaskMessage_NestedMessage struct {
 func (x *TestFieldMaskMessage_NestedMessage) Reset() {
 	*x = TestFieldMaskMessage_NestedMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_proto_msgTypes[14]
+		mi := &file_test_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -612,7 +667,7 @@ func (x *TestFieldMaskMessage_NestedMessage) String() string {
 func (*TestFieldMaskMessage_NestedMessage) ProtoMessage() {}
 
 func (x *TestFieldMaskMessage_NestedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[14]
+	mi := &file_test_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +680,7 @@ func (x *TestFieldMaskMessage_NestedMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use TestFieldMaskMessage_NestedMessage.ProtoReflect.Descriptor instead.
 func (*TestFieldMaskMessage_NestedMessage) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{4, 0}
+	return file_test_proto_rawDescGZIP(), []int{5, 0}
 }
 
 func (x *TestFieldMaskMessage_NestedMessage) GetP1() int64 {
@@ -652,102 +707,107 @@ var file_test_proto_rawDesc = []byte{
 	0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x10, 0x0a,
 	0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22,
-	0xa0, 0x01, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x41, 0x6e, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x26, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x28, 0x0a, 0x04, 0x6c, 0x69,
-	0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04,
-	0x6c, 0x69, 0x73, 0x74, 0x1a, 0x1d, 0x0a, 0x05, 0x54, 0x79, 0x70, 0x65, 0x31, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x1a, 0x1d, 0x0a, 0x05, 0x54, 0x79, 0x70, 0x65, 0x32, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x22, 0xfc, 0x01, 0x0a, 0x10, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x02, 0x6b,
-	0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62,
-	0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x2e, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6b, 0x76, 0x1a, 0x43, 0x0a,
-	0x0d, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x1a, 0x5d, 0x0a, 0x07, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72, 0x67,
-	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57,
-	0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0xb5, 0x04, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x02, 0x6b, 0x76, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61,
-	0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x02, 0x6b, 0x76, 0x12, 0x31, 0x0a, 0x03, 0x6b, 0x76, 0x32, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d,
-	0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x32, 0x12, 0x31, 0x0a, 0x03, 0x6b, 0x76, 0x33, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x33,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x33, 0x1a, 0x35, 0x0a, 0x07, 0x4b, 0x76,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70,
-	0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x1a, 0x5c,
-	0x0a, 0x08, 0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74, 0x65,
-	0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65,
-	0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x0f,
-	0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12,
-	0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x15,
-	0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x03, 0x6e,
-	0x75, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6e, 0x75, 0x6d, 0x1a, 0x5e, 0x0a, 0x08, 0x4b, 0x76, 0x33,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e,
-	0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e,
-	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe7, 0x03, 0x0a, 0x14, 0x54, 0x65,
+	0x4f, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0xa0, 0x01, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x41, 0x6e, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x28, 0x0a, 0x04, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x04, 0x6c, 0x69, 0x73, 0x74, 0x1a, 0x1d, 0x0a, 0x05, 0x54, 0x79, 0x70, 0x65, 0x31, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x1a, 0x1d, 0x0a, 0x05, 0x54, 0x79, 0x70, 0x65, 0x32, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xfc, 0x01, 0x0a, 0x10, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x02,
+	0x6b, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70,
+	0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6b, 0x76, 0x1a, 0x43,
+	0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x1a, 0x5d, 0x0a, 0x07, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xb5, 0x04, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x02, 0x6b, 0x76, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d,
+	0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x02, 0x6b, 0x76, 0x12, 0x31, 0x0a, 0x03, 0x6b, 0x76, 0x32, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x32, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x32, 0x12, 0x31, 0x0a, 0x03, 0x6b, 0x76, 0x33, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76,
+	0x33, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x33, 0x1a, 0x35, 0x0a, 0x07, 0x4b,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x1a,
+	0x5c, 0x0a, 0x08, 0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a,
+	0x0f, 0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x12, 0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x15, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x03,
+	0x6e, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6e, 0x75, 0x6d, 0x1a, 0x5e, 0x0a, 0x08, 0x4b, 0x76,
+	0x33, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x4e, 0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe7, 0x03, 0x0a, 0x14, 0x54,
+	0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65,
 	0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73,
+	0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x3e, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73,
 	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
-	0x03, 0x6d, 0x73, 0x67, 0x12, 0x3e, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x03, 0x6b, 0x76, 0x31, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74,
 	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x04,
-	0x6c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x03, 0x6b, 0x76, 0x31, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x25, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
-	0x4b, 0x76, 0x31, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x31, 0x12, 0x37, 0x0a,
-	0x03, 0x6b, 0x76, 0x32, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x65, 0x73,
-	0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73,
-	0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x03, 0x6b, 0x76, 0x32, 0x1a, 0x2f, 0x0a, 0x0d, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x31, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x70, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x32, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x02, 0x70, 0x32, 0x1a, 0x62, 0x0a, 0x08, 0x4b, 0x76, 0x31, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x4b,
-	0x76, 0x32, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x4b, 0x76, 0x31, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x31, 0x12, 0x37,
+	0x0a, 0x03, 0x6b, 0x76, 0x32, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61,
+	0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x03, 0x6b, 0x76, 0x32, 0x1a, 0x2f, 0x0a, 0x0d, 0x4e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x31, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x70, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x32, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x70, 0x32, 0x1a, 0x62, 0x0a, 0x08, 0x4b, 0x76, 0x31, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08,
+	0x4b, 0x76, 0x32, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -762,42 +822,43 @@ func file_test_proto_rawDescGZIP() []byte {
 	return file_test_proto_rawDescData
 }
 
-var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_test_proto_goTypes = []interface{}{
 	(*TestChannelDataMessage)(nil),         // 0: testpb.TestChannelDataMessage
-	(*TestAnyMessage)(nil),                 // 1: testpb.TestAnyMessage
-	(*TestMergeMessage)(nil),               // 2: testpb.TestMergeMessage
-	(*TestMapMessage)(nil),                 // 3: testpb.TestMapMessage
-	(*TestFieldMaskMessage)(nil),           // 4: testpb.TestFieldMaskMessage
-	(*TestAnyMessage_Type1)(nil),           // 5: testpb.TestAnyMessage.Type1
-	(*TestAnyMessage_Type2)(nil),           // 6: testpb.TestAnyMessage.Type2
-	(*TestMergeMessage_StringWrapper)(nil), // 7: testpb.TestMergeMessage.StringWrapper
-	nil,                                    // 8: testpb.TestMergeMessage.KvEntry
-	nil,                                    // 9: testpb.TestMapMessage.KvEntry
-	(*TestMapMessage_StringWrapper)(nil),   // 10: testpb.TestMapMessage.StringWrapper
-	nil,                                    // 11: testpb.TestMapMessage.Kv2Entry
-	(*TestMapMessage_NullableWrapper)(nil), // 12: testpb.TestMapMessage.NullableWrapper
-	nil,                                    // 13: testpb.TestMapMessage.Kv3Entry
-	(*TestFieldMaskMessage_NestedMessage)(nil), // 14: testpb.TestFieldMaskMessage.NestedMessage
-	nil,               // 15: testpb.TestFieldMaskMessage.Kv1Entry
-	nil,               // 16: testpb.TestFieldMaskMessage.Kv2Entry
-	(*anypb.Any)(nil), // 17: google.protobuf.Any
+	(*TestCounterMessage)(nil),             // 1: testpb.TestCounterMessage
+	(*TestAnyMessage)(nil),                 // 2: testpb.TestAnyMessage
+	(*TestMergeMessage)(nil),               // 3: testpb.TestMergeMessage
+	(*TestMapMessage)(nil),                 // 4: testpb.TestMapMessage
+	(*TestFieldMaskMessage)(nil),           // 5: testpb.TestFieldMaskMessage
+	(*TestAnyMessage_Type1)(nil),           // 6: testpb.TestAnyMessage.Type1
+	(*TestAnyMessage_Type2)(nil),           // 7: testpb.TestAnyMessage.Type2
+	(*TestMergeMessage_StringWrapper)(nil), // 8: testpb.TestMergeMessage.StringWrapper
+	nil,                                    // 9: testpb.TestMergeMessage.KvEntry
+	nil,                                    // 10: testpb.TestMapMessage.KvEntry
+	(*TestMapMessage_StringWrapper)(nil),   // 11: testpb.TestMapMessage.StringWrapper
+	nil,                                    // 12: testpb.TestMapMessage.Kv2Entry
+	(*TestMapMessage_NullableWrapper)(nil), // 13: testpb.TestMapMessage.NullableWrapper
+	nil,                                    // 14: testpb.TestMapMessage.Kv3Entry
+	(*TestFieldMaskMessage_NestedMessage)(nil), // 15: testpb.TestFieldMaskMessage.NestedMessage
+	nil,               // 16: testpb.TestFieldMaskMessage.Kv1Entry
+	nil,               // 17: testpb.TestFieldMaskMessage.Kv2Entry
+	(*anypb.Any)(nil), // 18: google.protobuf.Any
 }
 var file_test_proto_depIdxs = []int32{
-	17, // 0: testpb.TestAnyMessage.msg:type_name -> google.protobuf.Any
-	17, // 1: testpb.TestAnyMessage.list:type_name -> google.protobuf.Any
-	8,  // 2: testpb.TestMergeMessage.kv:type_name -> testpb.TestMergeMessage.KvEntry
-	9,  // 3: testpb.TestMapMessage.kv:type_name -> testpb.TestMapMessage.KvEntry
-	11, // 4: testpb.TestMapMessage.kv2:type_name -> testpb.TestMapMessage.Kv2Entry
-	13, // 5: testpb.TestMapMessage.kv3:type_name -> testpb.TestMapMessage.Kv3Entry
-	14, // 6: testpb.TestFieldMaskMessage.msg:type_name -> testpb.TestFieldMaskMessage.NestedMessage
-	14, // 7: testpb.TestFieldMaskMessage.list:type_name -> testpb.TestFieldMaskMessage.NestedMessage
-	15, // 8: testpb.TestFieldMaskMessage.kv1:type_name -> testpb.TestFieldMaskMessage.Kv1Entry
-	16, // 9: testpb.TestFieldMaskMessage.kv2:type_name -> testpb.TestFieldMaskMessage.Kv2Entry
-	7,  // 10: testpb.TestMergeMessage.KvEntry.value:type_name -> testpb.TestMergeMessage.StringWrapper
-	10, // 11: testpb.TestMapMessage.Kv2Entry.value:type_name -> testpb.TestMapMessage.StringWrapper
-	12, // 12: testpb.TestMapMessage.Kv3Entry.value:type_name -> testpb.TestMapMessage.NullableWrapper
-	14, // 13: testpb.TestFieldMaskMessage.Kv1Entry.value:type_name -> testpb.TestFieldMaskMessage.NestedMessage
+	18, // 0: testpb.TestAnyMessage.msg:type_name -> google.protobuf.Any
+	18, // 1: testpb.TestAnyMessage.list:type_name -> google.protobuf.Any
+	9,  // 2: testpb.TestMergeMessage.kv:type_name -> testpb.TestMergeMessage.KvEntry
+	10, // 3: testpb.TestMapMessage.kv:type_name -> testpb.TestMapMessage.KvEntry
+	12, // 4: testpb.TestMapMessage.kv2:type_name -> testpb.TestMapMessage.Kv2Entry
+	14, // 5: testpb.TestMapMessage.kv3:type_name -> testpb.TestMapMessage.Kv3Entry
+	15, // 6: testpb.TestFieldMaskMessage.msg:type_name -> testpb.TestFieldMaskMessage.NestedMessage
+	15, // 7: testpb.TestFieldMaskMessage.list:type_name -> testpb.TestFieldMaskMessage.NestedMessage
+	16, // 8: testpb.TestFieldMaskMessage.kv1:type_name -> testpb.TestFieldMaskMessage.Kv1Entry
+	17, // 9: testpb.TestFieldMaskMessage.kv2:type_name -> testpb.TestFieldMaskMessage.Kv2Entry
+	8,  // 10: testpb.TestMergeMessage.KvEntry.value:type_name -> testpb.TestMergeMessage.StringWrapper
+	11, // 11: testpb.TestMapMessage.Kv2Entry.value:type_name -> testpb.TestMapMessage.StringWrapper
+	13, // 12: testpb.TestMapMessage.Kv3Entry.value:type_name -> testpb.TestMapMessage.NullableWrapper
+	15, // 13: testpb.TestFieldMaskMessage.Kv1Entry.value:type_name -> testpb.TestFieldMaskMessage.NestedMessage
 	14, // [14:14] is the sub-list for method output_type
 	14, // [14:14] is the sub-list for method input_type
 	14, // [14:14] is the sub-list for extension type_name
@@ -824,7 +885,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestAnyMessage); i {
+			switch v := v.(*TestCounterMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -836,7 +897,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestMergeMessage); i {
+			switch v := v.(*TestAnyMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -848,7 +909,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestMapMessage); i {
+			switch v := v.(*TestMergeMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -860,7 +921,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestFieldMaskMessage); i {
+			switch v := v.(*TestMapMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -872,7 +933,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestAnyMessage_Type1); i {
+			switch v := v.(*TestFieldMaskMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -884,7 +945,7 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TestAnyMessage_Type2); i {
+			switch v := v.(*TestAnyMessage_Type1); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -896,6 +957,18 @@ func file_test_proto_init() {
 			}
 		}
 		file_test_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestAnyMessage_Type2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestMergeMessage_StringWrapper); i {
 			case 0:
 				return &v.state
@@ -907,7 +980,7 @@ func file_test_proto_init() {
 				return nil
 			}
 		}
-		file_test_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_test_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestMapMessage_StringWrapper); i {
 			case 0:
 				return &v.state
@@ -919,7 +992,7 @@ func file_test_proto_init() {
 				return nil
 			}
 		}
-		file_test_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_test_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestMapMessage_NullableWrapper); i {
 			case 0:
 				return &v.state
@@ -931,7 +1004,7 @@ func file_test_proto_init() {
 				return nil
 			}
 		}
-		file_test_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_test_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestFieldMaskMessage_NestedMessage); i {
 			case 0:
 				return &v.state
@@ -944,14 +1017,15 @@ func file_test_proto_init() {
 			}
 		}
 	}
-	file_test_proto_msgTypes[12].OneofWrappers = []interface{}{}
+	file_test_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_test_proto_msgTypes[13].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
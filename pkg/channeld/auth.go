@@ -1,6 +1,12 @@
 package channeld
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/metaworking/channeld/pkg/channeldpb"
 	"go.uber.org/zap"
 )
@@ -9,6 +15,13 @@ type AuthProvider interface {
 	DoAuth(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, error)
 }
 
+// Optional interface of the AuthProvider. The auth tags (e.g. "team", "role") of the connection are resolved along with the auth result,
+// and are used to match the subscription templates of the well-known entities.
+type AuthTagsProvider interface {
+	// Same as DoAuth, but also returns the auth tags if the auth is successful.
+	DoAuthWithTags(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, map[string]string, error)
+}
+
 // Do nothing but logging
 type LoggingAuthProvider struct {
 	Logger *zap.Logger
@@ -53,3 +66,90 @@ var authProvider AuthProvider
 func SetAuthProvider(value AuthProvider) {
 	authProvider = value
 }
+
+// The auth tags should not be modified after being set. The roles of the connection are parsed from the RolesAuthTag.
+func (c *Connection) SetAuthTags(tags map[string]string) {
+	c.authTags.Store(tags)
+	c.roles.Store(splitCommaList(tags[RolesAuthTag]))
+}
+
+// Returns the auth tags of the connection, or nil if not set.
+func (c *Connection) GetAuthTags() map[string]string {
+	tags, _ := c.authTags.Load().(map[string]string)
+	return tags
+}
+
+// Returns the roles assigned at the auth time. The server connections always have the ServerRole.
+func (c *Connection) GetRoles() []string {
+	roles, _ := c.roles.Load().([]string)
+	if c.connectionType == channeldpb.ConnectionType_SERVER {
+		return append([]string{ServerRole}, roles...)
+	}
+	return roles
+}
+
+func splitCommaList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Sets the built-in AuthProvider selected by GlobalSettings.AuthProviderType. Does nothing if the type is empty.
+func InitAuthProvider() error {
+	if GlobalSettings.AuthProviderType == "" {
+		return nil
+	}
+
+	if GlobalSettings.AuthProviderType == WebhookAuthProviderType {
+		if GlobalSettings.AuthWebhookUrl == "" {
+			return errors.New("the URL of the auth webhook is not set")
+		}
+		provider := NewWebhookAuthProvider(GlobalSettings.AuthWebhookUrl)
+		if GlobalSettings.AuthWebhookTimeoutMs > 0 {
+			provider.Timeout = time.Duration(GlobalSettings.AuthWebhookTimeoutMs) * time.Millisecond
+		}
+		SetAuthProvider(provider)
+		rootLogger.Info("initialized auth provider", zap.String("type", GlobalSettings.AuthProviderType), zap.String("url", provider.URL))
+		return nil
+	}
+
+	keys := NewTokenKeySet()
+	for _, path := range splitCommaList(GlobalSettings.AuthKeyFiles) {
+		if err := keys.LoadKeyFile(path); err != nil {
+			return fmt.Errorf("failed to load the auth key file: %w", err)
+		}
+	}
+	if GlobalSettings.AuthJwksFile != "" {
+		if err := keys.LoadJwksFile(GlobalSettings.AuthJwksFile); err != nil {
+			return fmt.Errorf("failed to load the JWKS file: %w", err)
+		}
+	}
+	if keys.Len() == 0 {
+		return errors.New("no auth key is loaded")
+	}
+
+	options := TokenValidationOptions{
+		Audience:  GlobalSettings.AuthAudience,
+		TagClaims: splitCommaList(GlobalSettings.AuthTagClaims),
+	}
+	switch GlobalSettings.AuthProviderType {
+	case JwtAuthProviderType:
+		SetAuthProvider(&JwtAuthProvider{TokenValidationOptions: options, Keys: keys})
+	case HmacAuthProviderType:
+		SetAuthProvider(&HmacTokenAuthProvider{TokenValidationOptions: options, Keys: keys})
+	default:
+		return fmt.Errorf("unknown auth provider type: %s", GlobalSettings.AuthProviderType)
+	}
+
+	kids := make([]string, 0, keys.Len())
+	for kid := range keys.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	rootLogger.Info("initialized auth provider", zap.String("type", GlobalSettings.AuthProviderType), zap.Strings("kids", kids))
+	return nil
+}
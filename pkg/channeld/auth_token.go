@@ -0,0 +1,499 @@
+package channeld
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"go.uber.org/zap"
+)
+
+/* Signed login token authentication
+ * The login token (LT) in the AuthMessage is a token signed by the login service, and the PIT should be the subject of the token.
+ * - JwtAuthProvider validates the JWT signed with RS256, ES256 or HS256.
+ * - HmacTokenAuthProvider validates the simple token: base64url(claims JSON) + "." + base64url(HMAC-SHA256(secret, the first part)),
+ *   which can be created by SignHmacToken.
+ * Both of them check the expiry ("exp" is required), "nbf", the audience and the subject. The custom claims (e.g. "roles", "team")
+ * become the auth tags of the connection. An array claim is joined by commas, e.g. "roles": ["admin", "gm"] becomes "admin,gm".
+ * The providers can be selected by the CLI flags, see InitAuthProvider().
+ */
+
+const (
+	JwtAuthProviderType  = "jwt"
+	HmacAuthProviderType = "hmac"
+)
+
+// The registered claims that don't become the auth tags
+var registeredTokenClaims = map[string]struct{}{
+	"iss": {}, "sub": {}, "aud": {}, "exp": {}, "nbf": {}, "iat": {}, "jti": {},
+}
+
+// Replaced in the tests
+var tokenTimeNow = time.Now
+
+// The keys to verify the signed login tokens, by the key ID. A key is either *rsa.PublicKey, *ecdsa.PublicKey, or []byte (the HMAC secret).
+type TokenKeySet struct {
+	keys map[string]interface{}
+}
+
+func NewTokenKeySet() *TokenKeySet {
+	return &TokenKeySet{keys: make(map[string]interface{})}
+}
+
+func (ks *TokenKeySet) AddKey(kid string, key interface{}) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return fmt.Errorf("key %s: only the P-256 curve is supported", kid)
+		}
+	case []byte:
+		if len(k) == 0 {
+			return fmt.Errorf("key %s: empty HMAC secret", kid)
+		}
+	default:
+		return fmt.Errorf("key %s: unsupported key type %T", kid, key)
+	}
+	if _, exists := ks.keys[kid]; exists {
+		return fmt.Errorf("duplicated key ID: %s", kid)
+	}
+	ks.keys[kid] = key
+	return nil
+}
+
+func (ks *TokenKeySet) Len() int {
+	return len(ks.keys)
+}
+
+// Loads a PEM-encoded public key or certificate, or an HMAC secret if the file is not PEM-encoded.
+// The key ID is the file name without the extension.
+func (ks *TokenKeySet) LoadKeyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return ks.AddKey(kid, []byte(strings.TrimSpace(string(data))))
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return fmt.Errorf("%s: unsupported PEM block type %s", path, block.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return ks.AddKey(kid, key)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// oct (HMAC)
+	K string `json:"k"`
+}
+
+// Loads the RSA, EC (P-256) and oct keys in the JSON Web Key Set file.
+func (ks *TokenKeySet) LoadJwksFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			return fmt.Errorf("%s: key %s: %w", path, jwk.Kid, err)
+		}
+		if err := ks.AddKey(jwk.Kid, key); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (jwk *jsonWebKey) publicKey() (interface{}, error) {
+	decodeInt := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := decodeInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("the point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(jwk.K)
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
+
+// Returns the key of the ID, or all the keys if the ID is empty.
+func (ks *TokenKeySet) findKeys(kid string) []interface{} {
+	if kid != "" {
+		if key, exists := ks.keys[kid]; exists {
+			return []interface{}{key}
+		}
+		return nil
+	}
+	keys := make([]interface{}, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func verifyTokenSignature(alg string, key interface{}, signingInput string, sig []byte) error {
+	hash := sha256.Sum256([]byte(signingInput))
+	switch alg {
+	case "HS256":
+		secret, ok := key.([]byte)
+		if !ok {
+			return errors.New("not an HMAC secret")
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return errors.New("signature mismatch")
+		}
+	case "RS256":
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("not an RSA public key")
+		}
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], sig)
+	case "ES256":
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("not an ECDSA public key")
+		}
+		if len(sig) != 64 {
+			return errors.New("invalid ES256 signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(publicKey, hash[:], r, s) {
+			return errors.New("signature mismatch")
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %s", alg)
+	}
+	return nil
+}
+
+// Tries the keys until one of them verifies the signature.
+func (ks *TokenKeySet) verify(alg string, kid string, signingInput string, sig []byte) error {
+	keys := ks.findKeys(kid)
+	if len(keys) == 0 {
+		return fmt.Errorf("no key found for kid '%s'", kid)
+	}
+	var err error
+	for _, key := range keys {
+		if err = verifyTokenSignature(alg, key, signingInput, sig); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// The common options of JwtAuthProvider and HmacTokenAuthProvider.
+type TokenValidationOptions struct {
+	// Optional. The "aud" claim should be or contain it. Empty = not checked.
+	Audience string
+	// Optional. The claims that become the auth tags. Empty = all the custom claims.
+	TagClaims []string
+	// The allowed clock skew when checking "exp" and "nbf".
+	Leeway time.Duration
+}
+
+type TokenClaims map[string]interface{}
+
+func (claims TokenClaims) getTime(name string) (time.Time, bool, error) {
+	value, exists := claims[name]
+	if !exists {
+		return time.Time{}, false, nil
+	}
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, true, fmt.Errorf("claim '%s' is not a number", name)
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true, nil
+}
+
+func (claims TokenClaims) hasAudience(audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns INVALID_PIT if the subject is not the PIT, or INVALID_LT if any other claim is invalid.
+func (opts *TokenValidationOptions) validateClaims(claims TokenClaims, pit string) (channeldpb.AuthResultMessage_AuthResult, error) {
+	now := tokenTimeNow()
+	exp, exists, err := claims.getTime("exp")
+	if err != nil {
+		return channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+	if !exists {
+		return channeldpb.AuthResultMessage_INVALID_LT, errors.New("claim 'exp' is missing")
+	}
+	if now.After(exp.Add(opts.Leeway)) {
+		return channeldpb.AuthResultMessage_INVALID_LT, errors.New("token is expired")
+	}
+
+	nbf, exists, err := claims.getTime("nbf")
+	if err != nil {
+		return channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+	if exists && now.Before(nbf.Add(-opts.Leeway)) {
+		return channeldpb.AuthResultMessage_INVALID_LT, errors.New("token is not valid yet")
+	}
+
+	if opts.Audience != "" && !claims.hasAudience(opts.Audience) {
+		return channeldpb.AuthResultMessage_INVALID_LT, errors.New("audience mismatch")
+	}
+
+	if sub, _ := claims["sub"].(string); sub != pit {
+		return channeldpb.AuthResultMessage_INVALID_PIT, errors.New("subject is not the PIT")
+	}
+
+	return channeldpb.AuthResultMessage_SUCCESSFUL, nil
+}
+
+func tokenClaimToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		elems := make([]string, 0, len(v))
+		for _, elem := range v {
+			elems = append(elems, tokenClaimToString(elem))
+		}
+		return strings.Join(elems, ",")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func (opts *TokenValidationOptions) getAuthTags(claims TokenClaims) map[string]string {
+	tags := make(map[string]string)
+	if len(opts.TagClaims) > 0 {
+		for _, name := range opts.TagClaims {
+			if value, exists := claims[name]; exists {
+				tags[name] = tokenClaimToString(value)
+			}
+		}
+	} else {
+		for name, value := range claims {
+			if _, registered := registeredTokenClaims[name]; !registered {
+				tags[name] = tokenClaimToString(value)
+			}
+		}
+	}
+	return tags
+}
+
+func decodeTokenClaims(part string) (TokenClaims, error) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the claims: %w", err)
+	}
+	claims := make(TokenClaims)
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the claims: %w", err)
+	}
+	return claims, nil
+}
+
+// JwtAuthProvider validates the login token as a JWT signed with RS256, ES256 or HS256.
+// If the JWT header has "kid", only the key of the ID is used to verify the signature. Otherwise all the keys are tried.
+type JwtAuthProvider struct {
+	TokenValidationOptions
+	Keys *TokenKeySet
+}
+
+func (provider *JwtAuthProvider) parse(pit string, lt string) (TokenClaims, channeldpb.AuthResultMessage_AuthResult, error) {
+	parts := strings.Split(lt, ".")
+	if len(parts) != 3 {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, errors.New("malformed JWT")
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, fmt.Errorf("failed to decode the header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, fmt.Errorf("failed to unmarshal the header: %w", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, fmt.Errorf("failed to decode the signature: %w", err)
+	}
+	if err := provider.Keys.verify(header.Alg, header.Kid, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+
+	claims, err := decodeTokenClaims(parts[1])
+	if err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+	result, err := provider.validateClaims(claims, pit)
+	return claims, result, err
+}
+
+func (provider *JwtAuthProvider) DoAuth(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, error) {
+	result, _, err := provider.DoAuthWithTags(connId, pit, lt)
+	return result, err
+}
+
+func (provider *JwtAuthProvider) DoAuthWithTags(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, map[string]string, error) {
+	claims, result, err := provider.parse(pit, lt)
+	if err != nil {
+		securityLogger.Info("invalid JWT", zap.Uint32("connId", uint32(connId)), zap.String("pit", pit), zap.Error(err))
+		return result, nil, nil
+	}
+	return result, provider.getAuthTags(claims), nil
+}
+
+// HmacTokenAuthProvider validates the login token signed by SignHmacToken. All the HMAC secrets in the keys are tried.
+type HmacTokenAuthProvider struct {
+	TokenValidationOptions
+	Keys *TokenKeySet
+}
+
+// Signs the claims with HMAC-SHA256. The token can be validated by HmacTokenAuthProvider.
+func SignHmacToken(secret []byte, claims map[string]interface{}) (string, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (provider *HmacTokenAuthProvider) parse(pit string, lt string) (TokenClaims, channeldpb.AuthResultMessage_AuthResult, error) {
+	parts := strings.Split(lt, ".")
+	if len(parts) != 2 {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, errors.New("malformed HMAC token")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, fmt.Errorf("failed to decode the signature: %w", err)
+	}
+	if err := provider.Keys.verify("HS256", "", parts[0], sig); err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+
+	claims, err := decodeTokenClaims(parts[0])
+	if err != nil {
+		return nil, channeldpb.AuthResultMessage_INVALID_LT, err
+	}
+	result, err := provider.validateClaims(claims, pit)
+	return claims, result, err
+}
+
+func (provider *HmacTokenAuthProvider) DoAuth(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, error) {
+	result, _, err := provider.DoAuthWithTags(connId, pit, lt)
+	return result, err
+}
+
+func (provider *HmacTokenAuthProvider) DoAuthWithTags(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, map[string]string, error) {
+	claims, result, err := provider.parse(pit, lt)
+	if err != nil {
+		securityLogger.Info("invalid HMAC token", zap.Uint32("connId", uint32(connId)), zap.String("pit", pit), zap.Error(err))
+		return result, nil, nil
+	}
+	return result, provider.getAuthTags(claims), nil
+}
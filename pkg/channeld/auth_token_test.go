@@ -0,0 +1,231 @@
+package channeld
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestJwt(t *testing.T, alg string, kid string, key interface{}, claims map[string]interface{}) string {
+	header := map[string]interface{}{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	headerData, _ := json.Marshal(header)
+	claimsData, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(headerData) + "." + base64.RawURLEncoding.EncodeToString(claimsData)
+	hash := sha256.Sum256([]byte(signingInput))
+
+	var sig []byte
+	var err error
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signingInput))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hash[:])
+	case *ecdsa.PrivateKey:
+		r, s, signErr := ecdsa.Sign(rand.Reader, k, hash[:])
+		err = signErr
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	assert.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func writePublicKeyPem(t *testing.T, path string, key interface{}) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+}
+
+func TestJwtAuthProvider(t *testing.T) {
+	InitLogs()
+	now := time.Unix(1700000000, 0)
+	tokenTimeNow = func() time.Time { return now }
+	defer func() {
+		tokenTimeNow = time.Now
+	}()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	secret := []byte("top-secret")
+
+	dir := t.TempDir()
+	writePublicKeyPem(t, filepath.Join(dir, "rsa1.pem"), &rsaKey.PublicKey)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "hs1.key"), append(secret, '\n'), 0644))
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"kid": "ec1",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "jwks.json"), jwks, 0644))
+
+	keys := NewTokenKeySet()
+	assert.NoError(t, keys.LoadKeyFile(filepath.Join(dir, "rsa1.pem")))
+	assert.NoError(t, keys.LoadKeyFile(filepath.Join(dir, "hs1.key")))
+	assert.NoError(t, keys.LoadJwksFile(filepath.Join(dir, "jwks.json")))
+	assert.Equal(t, 3, keys.Len())
+	// Duplicated key ID
+	assert.Error(t, keys.LoadKeyFile(filepath.Join(dir, "rsa1.pem")))
+
+	provider := &JwtAuthProvider{
+		TokenValidationOptions: TokenValidationOptions{Audience: "game"},
+		Keys:                   keys,
+	}
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":   "player1",
+			"aud":   []string{"web", "game"},
+			"exp":   now.Add(time.Minute).Unix(),
+			"team":  "red",
+			"roles": []string{"admin", "gm"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	doAuth := func(lt string) channeldpb.AuthResultMessage_AuthResult {
+		result, err := provider.DoAuth(1, "player1", lt)
+		assert.NoError(t, err)
+		return result
+	}
+
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, doAuth(signTestJwt(t, "RS256", "rsa1", rsaKey, claims(nil))))
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, doAuth(signTestJwt(t, "ES256", "ec1", ecKey, claims(nil))))
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(nil))))
+	// Without kid, all the keys are tried
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, doAuth(signTestJwt(t, "ES256", "", ecKey, claims(nil))))
+
+	// Wrong key
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "HS256", "hs1", []byte("guess"), claims(nil))))
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "ES256", "rsa1", ecKey, claims(nil))))
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "RS256", "unknown", rsaKey, claims(nil))))
+	// Unsupported algorithm
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "none", "", secret, claims(nil))))
+	// Expired
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(map[string]interface{}{"exp": now.Add(-time.Second).Unix()}))))
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(map[string]interface{}{"exp": nil}))))
+	// Not valid yet
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(map[string]interface{}{"nbf": now.Add(time.Minute).Unix()}))))
+	// Audience mismatch
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(map[string]interface{}{"aud": "web"}))))
+	// Subject mismatch
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_PIT, doAuth(signTestJwt(t, "HS256", "hs1", secret, claims(map[string]interface{}{"sub": "player2"}))))
+	// Malformed
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, doAuth("not-a-jwt"))
+
+	// The custom claims become the auth tags
+	result, tags, err := provider.DoAuthWithTags(1, "player1", signTestJwt(t, "RS256", "rsa1", rsaKey, claims(nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	assert.Equal(t, map[string]string{"team": "red", "roles": "admin,gm"}, tags)
+
+	provider.TagClaims = []string{"team", "level"}
+	_, tags, err = provider.DoAuthWithTags(1, "player1", signTestJwt(t, "RS256", "rsa1", rsaKey, claims(map[string]interface{}{"level": 10})))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "red", "level": "10"}, tags)
+
+	// No tags if the auth fails
+	result, tags, _ = provider.DoAuthWithTags(1, "player2", signTestJwt(t, "RS256", "rsa1", rsaKey, claims(nil)))
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_PIT, result)
+	assert.Nil(t, tags)
+}
+
+func TestHmacTokenAuthProvider(t *testing.T) {
+	InitLogs()
+	keys := NewTokenKeySet()
+	assert.NoError(t, keys.AddKey("old", []byte("secret1")))
+	assert.NoError(t, keys.AddKey("new", []byte("secret2")))
+	assert.Error(t, keys.AddKey("empty", []byte{}))
+	provider := &HmacTokenAuthProvider{Keys: keys}
+
+	exp := time.Now().Add(time.Minute).Unix()
+	lt, err := SignHmacToken([]byte("secret1"), map[string]interface{}{"sub": "player1", "exp": exp, "team": "blue"})
+	assert.NoError(t, err)
+	result, _ := provider.DoAuth(1, "player1", lt)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	result, _ = provider.DoAuth(1, "player2", lt)
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_PIT, result)
+	result, tags, err := provider.DoAuthWithTags(1, "player1", lt)
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	assert.Equal(t, map[string]string{"team": "blue"}, tags)
+
+	lt, _ = SignHmacToken([]byte("secret3"), map[string]interface{}{"sub": "player1", "exp": exp})
+	result, _ = provider.DoAuth(1, "player1", lt)
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, result)
+
+	lt, _ = SignHmacToken([]byte("secret2"), map[string]interface{}{"sub": "player1", "exp": time.Now().Add(-time.Minute).Unix()})
+	result, _ = provider.DoAuth(1, "player1", lt)
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, result)
+}
+
+func TestInitAuthProvider(t *testing.T) {
+	InitLogs()
+	oldSettings := GlobalSettings
+	oldProvider := authProvider
+	defer func() {
+		GlobalSettings = oldSettings
+		authProvider = oldProvider
+	}()
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "secret.key")
+	assert.NoError(t, os.WriteFile(keyPath, []byte("secret"), 0644))
+
+	authProvider = nil
+	assert.NoError(t, InitAuthProvider())
+	assert.Nil(t, authProvider)
+
+	GlobalSettings.AuthProviderType = HmacAuthProviderType
+	assert.Error(t, InitAuthProvider())
+	GlobalSettings.AuthKeyFiles = keyPath
+	GlobalSettings.AuthAudience = "game"
+	GlobalSettings.AuthTagClaims = "team, roles"
+	assert.NoError(t, InitAuthProvider())
+	hmacProvider, ok := authProvider.(*HmacTokenAuthProvider)
+	assert.True(t, ok)
+	assert.Equal(t, "game", hmacProvider.Audience)
+	assert.Equal(t, []string{"team", "roles"}, hmacProvider.TagClaims)
+
+	GlobalSettings.AuthProviderType = JwtAuthProviderType
+	assert.NoError(t, InitAuthProvider())
+	_, ok = authProvider.(AuthTagsProvider)
+	assert.True(t, ok)
+
+	GlobalSettings.AuthProviderType = "unknown"
+	assert.Error(t, InitAuthProvider())
+	GlobalSettings.AuthProviderType = JwtAuthProviderType
+	GlobalSettings.AuthKeyFiles = filepath.Join(dir, "missing.pem")
+	assert.Error(t, InitAuthProvider())
+}
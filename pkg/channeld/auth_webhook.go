@@ -0,0 +1,338 @@
+package channeld
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"go.uber.org/zap"
+)
+
+/* HTTP webhook authentication
+ * WebhookAuthProvider POSTs {"connId": 1, "pit": "...", "lt": "..."} to the URL of the account service, and maps the response:
+ * - 2xx: SUCCESSFUL, unless the JSON body has "result" (the name of AuthResultMessage.AuthResult, e.g. "INVALID_LT").
+ *   The body can also have "tags" (an object of strings), which become the auth tags of the connection.
+ * - 401 and 403: INVALID_LT
+ * - 404: INVALID_PIT
+ * - Any other status, a timeout or a network error fails the auth with an error, which closes the connection.
+ * The results are cached for CacheTTL (positive) or NegativeCacheTTL (negative), so reconnecting clients don't hit the service again.
+ * After CircuitBreakerThreshold consecutive errors, the requests fail fast for CircuitBreakerCooldown, then one trial request is let through.
+ * Timeout should be shorter than ConnectionAuthTimeoutMs, so the slow service doesn't get the IP of the connection blacklisted.
+ */
+
+const WebhookAuthProviderType = "webhook"
+
+const (
+	defaultWebhookAuthTimeout          = 3 * time.Second
+	defaultWebhookAuthMaxConcurrency   = 32
+	defaultWebhookAuthCacheTTL         = 30 * time.Second
+	defaultWebhookAuthNegativeCacheTTL = 5 * time.Second
+	defaultWebhookAuthMaxCacheSize     = 10000
+	defaultWebhookCircuitThreshold     = 5
+	defaultWebhookCircuitCooldown      = 10 * time.Second
+	webhookAuthRetryBackoff            = 100 * time.Millisecond
+)
+
+var errWebhookCircuitOpen = errors.New("the circuit breaker of the auth webhook is open")
+
+type webhookAuthRequest struct {
+	ConnId uint32 `json:"connId"`
+	Pit    string `json:"pit"`
+	Lt     string `json:"lt"`
+}
+
+type webhookAuthResponse struct {
+	Result string            `json:"result"`
+	Tags   map[string]string `json:"tags"`
+}
+
+type webhookAuthCacheEntry struct {
+	result     channeldpb.AuthResultMessage_AuthResult
+	tags       map[string]string
+	expiryTime time.Time
+}
+
+type webhookCircuitBreaker struct {
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+	// Is the trial request after the cooldown in flight?
+	trialInFlight bool
+}
+
+func (cb *webhookCircuitBreaker) allow(now time.Time, threshold int) bool {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if threshold <= 0 || cb.failures < threshold {
+		return true
+	}
+	if now.Before(cb.openUntil) || cb.trialInFlight {
+		return false
+	}
+	cb.trialInFlight = true
+	return true
+}
+
+func (cb *webhookCircuitBreaker) onSuccess() {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.failures = 0
+	cb.trialInFlight = false
+}
+
+func (cb *webhookCircuitBreaker) onFailure(now time.Time, threshold int, cooldown time.Duration) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.failures++
+	cb.trialInFlight = false
+	if threshold > 0 && cb.failures >= threshold {
+		cb.openUntil = now.Add(cooldown)
+	}
+}
+
+// WebhookAuthProvider authenticates the connections by an HTTP/JSON webhook. Use NewWebhookAuthProvider() to create it with the default options.
+// The options should not be changed after the provider is in use.
+type WebhookAuthProvider struct {
+	URL string
+	// The timeout of each request, including waiting for the concurrency slot.
+	Timeout time.Duration
+	// The max number of the requests in flight. 0 = no limit.
+	MaxConcurrency int
+	// How many times a request is retried after a timeout, a network error or a 5xx status.
+	MaxRetries int
+	// How long the successful results are cached. 0 = no cache.
+	CacheTTL time.Duration
+	// How long the failed results (INVALID_PIT and INVALID_LT) are cached. 0 = no cache.
+	NegativeCacheTTL time.Duration
+	MaxCacheSize     int
+	// How many consecutive errors open the circuit breaker. 0 = no circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+	// Optional. Extra headers of the requests, e.g. the API key of the account service.
+	Headers map[string]string
+	Client  *http.Client
+
+	slots      chan struct{}
+	slotsOnce  sync.Once
+	cacheLock  sync.Mutex
+	cache      map[string]*webhookAuthCacheEntry
+	breaker    webhookCircuitBreaker
+	timeNowFun func() time.Time
+}
+
+func NewWebhookAuthProvider(url string) *WebhookAuthProvider {
+	return &WebhookAuthProvider{
+		URL:                     url,
+		Timeout:                 defaultWebhookAuthTimeout,
+		MaxConcurrency:          defaultWebhookAuthMaxConcurrency,
+		MaxRetries:              1,
+		CacheTTL:                defaultWebhookAuthCacheTTL,
+		NegativeCacheTTL:        defaultWebhookAuthNegativeCacheTTL,
+		MaxCacheSize:            defaultWebhookAuthMaxCacheSize,
+		CircuitBreakerThreshold: defaultWebhookCircuitThreshold,
+		CircuitBreakerCooldown:  defaultWebhookCircuitCooldown,
+		Client:                  &http.Client{},
+	}
+}
+
+func (provider *WebhookAuthProvider) now() time.Time {
+	if provider.timeNowFun != nil {
+		return provider.timeNowFun()
+	}
+	return time.Now()
+}
+
+func webhookAuthCacheKey(pit string, lt string) string {
+	hash := sha256.Sum256([]byte(pit + "\x00" + lt))
+	return hex.EncodeToString(hash[:])
+}
+
+func (provider *WebhookAuthProvider) getCached(key string) (*webhookAuthCacheEntry, bool) {
+	provider.cacheLock.Lock()
+	defer provider.cacheLock.Unlock()
+	entry, exists := provider.cache[key]
+	if !exists {
+		return nil, false
+	}
+	if provider.now().After(entry.expiryTime) {
+		delete(provider.cache, key)
+		return nil, false
+	}
+	return entry, true
+}
+
+func (provider *WebhookAuthProvider) putCached(key string, result channeldpb.AuthResultMessage_AuthResult, tags map[string]string) {
+	ttl := provider.CacheTTL
+	if result != channeldpb.AuthResultMessage_SUCCESSFUL {
+		ttl = provider.NegativeCacheTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	provider.cacheLock.Lock()
+	defer provider.cacheLock.Unlock()
+	now := provider.now()
+	if provider.cache == nil {
+		provider.cache = make(map[string]*webhookAuthCacheEntry)
+	}
+	if provider.MaxCacheSize > 0 && len(provider.cache) >= provider.MaxCacheSize {
+		for k, entry := range provider.cache {
+			if now.After(entry.expiryTime) {
+				delete(provider.cache, k)
+			}
+		}
+		if len(provider.cache) >= provider.MaxCacheSize {
+			return
+		}
+	}
+	provider.cache[key] = &webhookAuthCacheEntry{result: result, tags: tags, expiryTime: now.Add(ttl)}
+}
+
+func (provider *WebhookAuthProvider) acquireSlot(ctx context.Context) error {
+	if provider.MaxConcurrency <= 0 {
+		return nil
+	}
+	provider.slotsOnce.Do(func() {
+		provider.slots = make(chan struct{}, provider.MaxConcurrency)
+	})
+	select {
+	case provider.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("too many concurrent auth requests: %w", ctx.Err())
+	}
+}
+
+func (provider *WebhookAuthProvider) releaseSlot() {
+	if provider.MaxConcurrency > 0 {
+		<-provider.slots
+	}
+}
+
+// Sends the request once. The returned bool indicates whether the error is retryable.
+func (provider *WebhookAuthProvider) post(connId ConnectionId, pit string, lt string) (*webhookAuthCacheEntry, bool, error) {
+	ctx := context.Background()
+	if provider.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, provider.Timeout)
+		defer cancel()
+	}
+
+	if err := provider.acquireSlot(ctx); err != nil {
+		return nil, false, err
+	}
+	defer provider.releaseSlot()
+
+	body, err := json.Marshal(webhookAuthRequest{ConnId: uint32(connId), Pit: pit, Lt: lt})
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range provider.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := provider.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, true, err
+		}
+		entry := &webhookAuthCacheEntry{result: channeldpb.AuthResultMessage_SUCCESSFUL}
+		if len(bytes.TrimSpace(respBody)) == 0 {
+			return entry, false, nil
+		}
+		var authResp webhookAuthResponse
+		if err := json.Unmarshal(respBody, &authResp); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal the response: %w", err)
+		}
+		if authResp.Result != "" {
+			result, exists := channeldpb.AuthResultMessage_AuthResult_value[authResp.Result]
+			if !exists {
+				return nil, false, fmt.Errorf("unknown auth result: %s", authResp.Result)
+			}
+			entry.result = channeldpb.AuthResultMessage_AuthResult(result)
+		}
+		if entry.result == channeldpb.AuthResultMessage_SUCCESSFUL {
+			entry.tags = authResp.Tags
+		}
+		return entry, false, nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return &webhookAuthCacheEntry{result: channeldpb.AuthResultMessage_INVALID_LT}, false, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return &webhookAuthCacheEntry{result: channeldpb.AuthResultMessage_INVALID_PIT}, false, nil
+	default:
+		return nil, resp.StatusCode >= 500, fmt.Errorf("unexpected status of the auth webhook: %s", resp.Status)
+	}
+}
+
+func (provider *WebhookAuthProvider) authenticate(connId ConnectionId, pit string, lt string) (*webhookAuthCacheEntry, error) {
+	key := webhookAuthCacheKey(pit, lt)
+	if entry, exists := provider.getCached(key); exists {
+		return entry, nil
+	}
+
+	if !provider.breaker.allow(provider.now(), provider.CircuitBreakerThreshold) {
+		return nil, errWebhookCircuitOpen
+	}
+
+	var entry *webhookAuthCacheEntry
+	var err error
+	for attempt := 0; attempt <= provider.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(webhookAuthRetryBackoff)
+		}
+		var retryable bool
+		entry, retryable, err = provider.post(connId, pit, lt)
+		if err == nil || !retryable {
+			break
+		}
+		securityLogger.Debug("retrying the auth webhook", zap.Uint32("connId", uint32(connId)), zap.Int("attempt", attempt), zap.Error(err))
+	}
+
+	if err != nil {
+		provider.breaker.onFailure(provider.now(), provider.CircuitBreakerThreshold, provider.CircuitBreakerCooldown)
+		return nil, err
+	}
+	provider.breaker.onSuccess()
+	provider.putCached(key, entry.result, entry.tags)
+	return entry, nil
+}
+
+func (provider *WebhookAuthProvider) DoAuth(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, error) {
+	result, _, err := provider.DoAuthWithTags(connId, pit, lt)
+	return result, err
+}
+
+// The tags are in the response of the successful auth.
+func (provider *WebhookAuthProvider) DoAuthWithTags(connId ConnectionId, pit string, lt string) (channeldpb.AuthResultMessage_AuthResult, map[string]string, error) {
+	entry, err := provider.authenticate(connId, pit, lt)
+	if err != nil {
+		return channeldpb.AuthResultMessage_INVALID_LT, nil, err
+	}
+	return entry.result, entry.tags, nil
+}
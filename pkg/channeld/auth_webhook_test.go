@@ -0,0 +1,202 @@
+package channeld
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookAuthProvider(t *testing.T) {
+	InitLogs()
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		var req webhookAuthRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Pit {
+		case "player1":
+			if req.Lt == "good" {
+				w.Write([]byte(`{"tags": {"team": "red"}}`))
+			} else {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		case "banned":
+			w.Write([]byte(`{"result": "INVALID_PIT"}`))
+		case "empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewWebhookAuthProvider(server.URL)
+	provider.Headers = map[string]string{"X-Api-Key": "secret"}
+	provider.CircuitBreakerThreshold = 0
+	now := time.Now()
+	provider.timeNowFun = func() time.Time { return now }
+
+	result, tags, err := provider.DoAuthWithTags(1, "player1", "good")
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	assert.Equal(t, map[string]string{"team": "red"}, tags)
+	// The result is cached
+	result, err = provider.DoAuth(1, "player1", "good")
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+
+	result, err = provider.DoAuth(1, "player1", "bad")
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, result)
+	result, _ = provider.DoAuth(1, "player1", "bad")
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_LT, result)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&hits))
+
+	result, _ = provider.DoAuth(1, "unknown", "good")
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_PIT, result)
+	result, _ = provider.DoAuth(1, "banned", "good")
+	assert.Equal(t, channeldpb.AuthResultMessage_INVALID_PIT, result)
+	result, _ = provider.DoAuth(1, "empty", "good")
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+
+	// The negative result expires earlier than the positive one
+	now = now.Add(provider.NegativeCacheTTL + time.Second)
+	provider.DoAuth(1, "player1", "bad")
+	provider.DoAuth(1, "player1", "good")
+	assert.EqualValues(t, 6, atomic.LoadInt32(&hits))
+
+	// The 5xx error is retried and not cached
+	_, err = provider.DoAuth(1, "broken", "good")
+	assert.Error(t, err)
+	assert.EqualValues(t, 8, atomic.LoadInt32(&hits))
+	_, err = provider.DoAuth(1, "broken", "good")
+	assert.Error(t, err)
+	assert.EqualValues(t, 10, atomic.LoadInt32(&hits))
+}
+
+func TestWebhookAuthTimeoutAndConcurrency(t *testing.T) {
+	InitLogs()
+
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer slowServer.Close()
+
+	// The slow request times out
+	provider := NewWebhookAuthProvider(slowServer.URL)
+	provider.Timeout = 50 * time.Millisecond
+	provider.MaxRetries = 0
+	startTime := time.Now()
+	_, err := provider.DoAuth(1, "player1", "good")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(startTime), time.Second)
+
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		if n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// Only one request is in flight
+	provider = NewWebhookAuthProvider(server.URL)
+	provider.MaxConcurrency = 1
+	provider.CacheTTL = 0
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := provider.DoAuth(1, "player1", "good")
+			assert.NoError(t, err)
+			assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, atomic.LoadInt32(&maxInFlight))
+}
+
+func TestWebhookAuthCircuitBreaker(t *testing.T) {
+	InitLogs()
+
+	var hits int32
+	var healthy int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewWebhookAuthProvider(server.URL)
+	provider.MaxRetries = 0
+	provider.CircuitBreakerThreshold = 2
+	now := time.Now()
+	provider.timeNowFun = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := provider.DoAuth(1, "player1", "good")
+		assert.Error(t, err)
+	}
+	// The circuit is open
+	_, err := provider.DoAuth(1, "player1", "good")
+	assert.ErrorIs(t, err, errWebhookCircuitOpen)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&hits))
+
+	// The trial request after the cooldown fails, so the circuit is open again
+	now = now.Add(provider.CircuitBreakerCooldown)
+	_, err = provider.DoAuth(1, "player1", "good")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errWebhookCircuitOpen)
+	_, err = provider.DoAuth(1, "player1", "good")
+	assert.ErrorIs(t, err, errWebhookCircuitOpen)
+
+	// The successful trial request closes the circuit
+	atomic.StoreInt32(&healthy, 1)
+	now = now.Add(provider.CircuitBreakerCooldown)
+	result, err := provider.DoAuth(1, "player1", "good")
+	assert.NoError(t, err)
+	assert.Equal(t, channeldpb.AuthResultMessage_SUCCESSFUL, result)
+	_, err = provider.DoAuth(1, "player2", "good")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, atomic.LoadInt32(&hits))
+}
+
+func TestInitWebhookAuthProvider(t *testing.T) {
+	InitLogs()
+	oldSettings := GlobalSettings
+	oldProvider := authProvider
+	defer func() {
+		GlobalSettings = oldSettings
+		authProvider = oldProvider
+	}()
+
+	GlobalSettings.AuthProviderType = WebhookAuthProviderType
+	assert.Error(t, InitAuthProvider())
+	GlobalSettings.AuthWebhookUrl = "http://localhost/auth"
+	GlobalSettings.AuthWebhookTimeoutMs = 500
+	assert.NoError(t, InitAuthProvider())
+	provider, ok := authProvider.(*WebhookAuthProvider)
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, provider.Timeout)
+}
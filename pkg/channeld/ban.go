@@ -0,0 +1,419 @@
+package channeld
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+/* Ban list
+ * The banned IP addresses (or CIDR ranges) are refused when connecting, and the banned PITs are refused when authenticating.
+ * A ban expires after its duration. The ban of the same IP or PIT within BanOffenseMemory after the last ban expires is a repeated offense,
+ * and the duration doubles each time, from GlobalSettings.BanBaseDurationSec up to BanMaxDurationSec.
+ * The bans are persisted to GlobalSettings.BanListFile every BanListFlushInterval, and can be listed, added and removed at runtime via BanListHandler.
+ */
+
+type BanType string
+
+const (
+	BanType_IP  BanType = "ip"
+	BanType_PIT BanType = "pit"
+)
+
+const (
+	DefaultBanBaseDuration = 10 * time.Minute
+	DefaultBanMaxDuration  = 7 * 24 * time.Hour
+	// How long the offenses are remembered after the ban expires.
+	BanOffenseMemory = 24 * time.Hour
+	// Pass as the duration to ban forever.
+	BanForever time.Duration = -1
+	// How often the changed bans are written to the file.
+	BanListFlushInterval = time.Second
+)
+
+type BanEntry struct {
+	Type BanType `json:"type"`
+	// The IP address, the CIDR range (e.g. "10.0.0.0/24") or the PIT.
+	Value     string    `json:"value"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Zero = never expires
+	ExpiresAt time.Time `json:"expiresAt"`
+	// How many times the IP or PIT has been banned.
+	Offenses int `json:"offenses"`
+}
+
+func (e *BanEntry) isActive(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)
+}
+
+// Accessed from the listening, auth and event goroutines, so it's protected by the mutex.
+type BanList struct {
+	lock    sync.RWMutex
+	entries map[string]*BanEntry
+	// The parsed CIDR ranges, keyed by the entry key
+	ipNets map[string]*net.IPNet
+	// Empty = no persistence
+	filePath     string
+	baseDuration time.Duration
+	maxDuration  time.Duration
+	timeNowFun   func() time.Time
+	// Set when the entries are changed but not written to the file yet.
+	dirty bool
+	// Serializes the writes to the file, which happen outside the lock.
+	fileLock sync.Mutex
+}
+
+var bans = NewBanList("")
+
+func NewBanList(filePath string) *BanList {
+	return &BanList{
+		entries:      make(map[string]*BanEntry),
+		ipNets:       make(map[string]*net.IPNet),
+		filePath:     filePath,
+		baseDuration: DefaultBanBaseDuration,
+		maxDuration:  DefaultBanMaxDuration,
+		timeNowFun:   time.Now,
+	}
+}
+
+func banKey(t BanType, value string) string {
+	return string(t) + ":" + value
+}
+
+// Returns the normalized value of the IP address, the CIDR range or the PIT.
+func normalizeBanValue(t BanType, value string) (string, *net.IPNet, error) {
+	switch t {
+	case BanType_IP:
+		if strings.Contains(value, "/") {
+			_, ipNet, err := net.ParseCIDR(value)
+			if err != nil {
+				return "", nil, err
+			}
+			return ipNet.String(), ipNet, nil
+		}
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", nil, fmt.Errorf("invalid IP address: %s", value)
+		}
+		return ip.String(), nil, nil
+	case BanType_PIT:
+		if value == "" {
+			return "", nil, errors.New("empty PIT")
+		}
+		return value, nil, nil
+	}
+	return "", nil, fmt.Errorf("unknown ban type: %s", t)
+}
+
+// Bans the IP address, the CIDR range or the PIT. Duration 0 = the escalating duration by the offenses.
+func (b *BanList) Ban(t BanType, value string, duration time.Duration, reason string) (BanEntry, error) {
+	value, ipNet, err := normalizeBanValue(t, value)
+	if err != nil {
+		return BanEntry{}, err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.timeNowFun()
+	b.purgeExpired(now)
+
+	key := banKey(t, value)
+	entry, exists := b.entries[key]
+	if !exists {
+		entry = &BanEntry{Type: t, Value: value}
+		b.entries[key] = entry
+		if ipNet != nil {
+			b.ipNets[key] = ipNet
+		}
+	}
+	entry.Offenses++
+	entry.Reason = reason
+	entry.CreatedAt = now
+	if duration == 0 {
+		duration = b.baseDuration
+		for i := 1; i < entry.Offenses && duration < b.maxDuration; i++ {
+			duration *= 2
+		}
+		if duration > b.maxDuration {
+			duration = b.maxDuration
+		}
+	}
+	if duration == BanForever {
+		entry.ExpiresAt = time.Time{}
+	} else {
+		entry.ExpiresAt = now.Add(duration)
+	}
+
+	b.dirty = true
+	return *entry, nil
+}
+
+// Returns false if the IP address, the CIDR range or the PIT is not banned.
+func (b *BanList) Unban(t BanType, value string) bool {
+	value, _, err := normalizeBanValue(t, value)
+	if err != nil {
+		return false
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	key := banKey(t, value)
+	entry, exists := b.entries[key]
+	if !exists || !entry.isActive(b.timeNowFun()) {
+		return false
+	}
+	// Forget the offenses as well
+	delete(b.entries, key)
+	delete(b.ipNets, key)
+	b.dirty = true
+	return true
+}
+
+// Returns the active bans, sorted by the creation time.
+func (b *BanList) List() []BanEntry {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	now := b.timeNowFun()
+	list := make([]BanEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		if entry.isActive(now) {
+			list = append(list, *entry)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+		return banKey(list[i].Type, list[i].Value) < banKey(list[j].Type, list[j].Value)
+	})
+	return list
+}
+
+// Returns true if the IP address is banned, or in any banned CIDR range.
+func (b *BanList) IsIPBanned(ipStr string) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	now := b.timeNowFun()
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return false
+	}
+	if entry, exists := b.entries[banKey(BanType_IP, ip.String())]; exists && entry.isActive(now) {
+		return true
+	}
+	for key, ipNet := range b.ipNets {
+		if ipNet.Contains(ip) && b.entries[key].isActive(now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BanList) IsPITBanned(pit string) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	entry, exists := b.entries[banKey(BanType_PIT, pit)]
+	return exists && entry.isActive(b.timeNowFun())
+}
+
+// Removes the expired entries whose offenses are no longer remembered. Should be called with the lock held.
+func (b *BanList) purgeExpired(now time.Time) {
+	for key, entry := range b.entries {
+		if !entry.ExpiresAt.IsZero() && now.Sub(entry.ExpiresAt) > BanOffenseMemory {
+			delete(b.entries, key)
+			delete(b.ipNets, key)
+		}
+	}
+}
+
+// Loads the bans from the file. A missing file is not an error.
+func (b *BanList) Load() error {
+	if b.filePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(b.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var list []*BanEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("failed to unmarshal the ban list file: %w", err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, entry := range list {
+		value, ipNet, err := normalizeBanValue(entry.Type, entry.Value)
+		if err != nil {
+			return fmt.Errorf("invalid ban entry %s: %w", entry.Value, err)
+		}
+		entry.Value = value
+		key := banKey(entry.Type, value)
+		b.entries[key] = entry
+		if ipNet != nil {
+			b.ipNets[key] = ipNet
+		}
+	}
+	b.purgeExpired(b.timeNowFun())
+	return nil
+}
+
+// Writes all the entries, including the expired ones whose offenses are remembered, to the file if they are changed since the last flush.
+// The file is written outside the lock, so the bans and the ban checks are not blocked by the disk I/O.
+func (b *BanList) Flush() {
+	if b.filePath == "" {
+		return
+	}
+	b.fileLock.Lock()
+	defer b.fileLock.Unlock()
+
+	b.lock.Lock()
+	if !b.dirty {
+		b.lock.Unlock()
+		return
+	}
+	b.dirty = false
+	// Copy the entries, as they can be changed by the bans during the write.
+	list := make([]BanEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		list = append(list, *entry)
+	}
+	b.lock.Unlock()
+
+	if !b.save(list) {
+		// Retry in the next flush
+		b.lock.Lock()
+		b.dirty = true
+		b.lock.Unlock()
+	}
+}
+
+// Flushes the ban list every BanListFlushInterval. Runs in its own goroutine.
+func (b *BanList) flushPeriodically() {
+	for {
+		time.Sleep(BanListFlushInterval)
+		b.Flush()
+	}
+}
+
+// Returns false if the entries failed to be written to the file.
+func (b *BanList) save(list []BanEntry) bool {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		securityLogger.Error("failed to marshal the ban list", zap.Error(err))
+		return false
+	}
+	// Write to a temporary file first, so a crash doesn't corrupt the ban list file.
+	tmpPath := b.filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		securityLogger.Error("failed to write the ban list file", zap.String("path", tmpPath), zap.Error(err))
+		return false
+	}
+	if err := os.Rename(tmpPath, b.filePath); err != nil {
+		securityLogger.Error("failed to replace the ban list file", zap.String("path", b.filePath), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// Sets up the ban list by the GlobalSettings and loads the persisted bans.
+func InitBanList() error {
+	b := NewBanList(GlobalSettings.BanListFile)
+	if GlobalSettings.BanBaseDurationSec > 0 {
+		b.baseDuration = time.Duration(GlobalSettings.BanBaseDurationSec) * time.Second
+	}
+	if GlobalSettings.BanMaxDurationSec > 0 {
+		b.maxDuration = time.Duration(GlobalSettings.BanMaxDurationSec) * time.Second
+	}
+	if b.filePath != "" {
+		if err := os.MkdirAll(filepath.Dir(b.filePath), 0755); err != nil {
+			return err
+		}
+	}
+	if err := b.Load(); err != nil {
+		return err
+	}
+	if b.filePath != "" {
+		go b.flushPeriodically()
+	}
+	bans = b
+	securityLogger.Info("initialized the ban list", zap.String("path", b.filePath), zap.Int("bans", len(b.List())))
+	return nil
+}
+
+type banRequest struct {
+	Type  BanType `json:"type"`
+	Value string  `json:"value"`
+	// 0 = the escalating duration by the offenses, -1 = forever
+	DurationSec int64  `json:"durationSec"`
+	Reason      string `json:"reason"`
+}
+
+/*
+The admin API of the ban list. Requires the "Authorization: Bearer <GlobalSettings.BanAdminToken>" header.
+GET: lists the active bans.
+POST: adds the ban in the JSON body, e.g. {"type": "ip", "value": "10.0.0.0/24", "durationSec": 3600, "reason": "cheating"}.
+DELETE: removes the ban by the query parameters, e.g. ?type=pit&value=player1.
+*/
+func BanListHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := GlobalSettings.BanAdminToken
+		// Compare in constant time, so the token can't be guessed by the response time.
+		if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(bans.List())
+
+		case http.MethodPost:
+			var req banRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			duration := time.Duration(req.DurationSec) * time.Second
+			if req.DurationSec < 0 {
+				duration = BanForever
+			}
+			entry, err := bans.Ban(req.Type, req.Value, duration, req.Reason)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			securityLogger.Info("banned by admin", zap.String("type", string(entry.Type)), zap.String("value", entry.Value), zap.Time("expiresAt", entry.ExpiresAt))
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(entry)
+
+		case http.MethodDelete:
+			t, value := BanType(r.URL.Query().Get("type")), r.URL.Query().Get("value")
+			if !bans.Unban(t, value) {
+				http.Error(w, "not banned", http.StatusNotFound)
+				return
+			}
+			securityLogger.Info("unbanned by admin", zap.String("type", string(t)), zap.String("value", value))
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
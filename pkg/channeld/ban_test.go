@@ -0,0 +1,125 @@
+package channeld
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBanList(t *testing.T) {
+	InitLogs()
+	path := filepath.Join(t.TempDir(), "bans.json")
+	b := NewBanList(path)
+	now := time.Now()
+	b.timeNowFun = func() time.Time { return now }
+
+	entry, err := b.Ban(BanType_IP, "10.0.0.1", 0, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(DefaultBanBaseDuration), entry.ExpiresAt)
+	assert.True(t, b.IsIPBanned("10.0.0.1"))
+	assert.False(t, b.IsIPBanned("10.0.0.2"))
+	// The file is written by the flush
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	// The ban expires
+	now = now.Add(DefaultBanBaseDuration)
+	assert.False(t, b.IsIPBanned("10.0.0.1"))
+
+	// The repeated ban doubles the duration
+	entry, _ = b.Ban(BanType_IP, "10.0.0.1", 0, "test")
+	assert.Equal(t, 2, entry.Offenses)
+	assert.Equal(t, now.Add(2*DefaultBanBaseDuration), entry.ExpiresAt)
+	for i := 0; i < 20; i++ {
+		entry, _ = b.Ban(BanType_IP, "10.0.0.1", 0, "test")
+	}
+	assert.Equal(t, now.Add(DefaultBanMaxDuration), entry.ExpiresAt)
+
+	// The offenses are forgotten some time after the ban expires
+	now = now.Add(DefaultBanMaxDuration + BanOffenseMemory + time.Second)
+	entry, _ = b.Ban(BanType_IP, "10.0.0.1", 0, "test")
+	assert.Equal(t, 1, entry.Offenses)
+
+	// CIDR range
+	_, err = b.Ban(BanType_IP, "192.168.1.7/24", BanForever, "")
+	assert.NoError(t, err)
+	assert.True(t, b.IsIPBanned("192.168.1.100"))
+	assert.False(t, b.IsIPBanned("192.168.2.1"))
+	_, err = b.Ban(BanType_IP, "not-an-ip", 0, "")
+	assert.Error(t, err)
+
+	_, err = b.Ban(BanType_PIT, "player1", time.Minute, "cheating")
+	assert.NoError(t, err)
+	assert.True(t, b.IsPITBanned("player1"))
+	assert.Len(t, b.List(), 3)
+
+	// The bans are persisted
+	b.Flush()
+	loaded := NewBanList(path)
+	loaded.timeNowFun = b.timeNowFun
+	assert.NoError(t, loaded.Load())
+	assert.Len(t, loaded.List(), 3)
+	assert.True(t, loaded.IsIPBanned("10.0.0.1"))
+	assert.True(t, loaded.IsIPBanned("192.168.1.1"))
+	assert.True(t, loaded.IsPITBanned("player1"))
+	entry, _ = loaded.Ban(BanType_IP, "10.0.0.1", 0, "test")
+	assert.Equal(t, 2, entry.Offenses)
+
+	assert.True(t, b.Unban(BanType_IP, "192.168.1.0/24"))
+	assert.False(t, b.IsIPBanned("192.168.1.100"))
+	assert.False(t, b.Unban(BanType_PIT, "player2"))
+	now = now.Add(time.Minute)
+	assert.False(t, b.IsPITBanned("player1"))
+	assert.False(t, b.Unban(BanType_PIT, "player1"))
+
+	// Missing file
+	assert.NoError(t, NewBanList(filepath.Join(t.TempDir(), "missing.json")).Load())
+}
+
+func TestBanListHandler(t *testing.T) {
+	InitLogs()
+	oldBans := bans
+	oldToken := GlobalSettings.BanAdminToken
+	defer func() {
+		bans = oldBans
+		GlobalSettings.BanAdminToken = oldToken
+	}()
+	bans = NewBanList("")
+
+	handler := BanListHandler()
+	serve := func(method string, url string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Disabled without the token
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/bans", "").Code)
+	GlobalSettings.BanAdminToken = "admin-token"
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/bans", `{"type": "pit", "value": "player1", "durationSec": -1}`).Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/bans", `{"type": "ip", "value": "10.0.0.0/8", "durationSec": 60}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/bans", `{"type": "user", "value": "player1"}`).Code)
+	assert.True(t, bans.IsPITBanned("player1"))
+	assert.True(t, bans.IsIPBanned("10.1.2.3"))
+
+	w := serve(http.MethodGet, "/bans", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list []BanEntry
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list, 2)
+	assert.True(t, list[0].ExpiresAt.IsZero())
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/bans?type=pit&value=player1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/bans?type=pit&value=player1", "").Code)
+	assert.False(t, bans.IsPITBanned("player1"))
+}
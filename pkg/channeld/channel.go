@@ -86,6 +86,22 @@ type Channel struct {
 	enableClientBroadcast bool
 	logger                *Logger
 	removing              int32
+	// The number of entities in the spatial channel data. Updated in the spatial channel's tick, so it can be read from other goroutines.
+	entityCount int32
+	// The total number of the channel data updates. Read/write with atomic operations.
+	dataUpdateCount uint64
+	// The duration of the latest tick, in nanoseconds. Read/write with atomic operations.
+	lastTickDuration int64
+	// The position of the entity (*common.SpatialInfo), updated in the entity channel's Tick(). See GetSpatialInfo().
+	spatialInfo atomic.Value
+	// The spatial world that the entity channel belongs to. Read-only after the channel is created.
+	spatialWorldId string
+	// Is the entity channel dormant? Read/write with atomic operations. See entity_dormancy.go.
+	dormant int32
+	// The last time the channel data was updated or the channel was woken up. Only accessed in the channel's goroutine.
+	lastActiveTime time.Time
+	// Overrides the role-based ACL rules in the settings. Set when the channel is created. See channel_acl.go.
+	roleACL *RoleACLSettingsType
 }
 
 const (
@@ -108,6 +124,7 @@ func InitChannels() {
 	}
 
 	allChannels = xsync.NewTypedMapOf[common.ChannelId, *Channel](UintIdHasher[common.ChannelId]())
+	spatialEntities = newSpatialEntityIndex()
 
 	nextChannelId = 0
 	nextSpatialChannelId = GlobalSettings.SpatialChannelIdStart
@@ -118,6 +135,15 @@ func InitChannels() {
 	}
 
 	for chType, settings := range GlobalSettings.ChannelSettings {
+		if chType == channeldpb.ChannelType_ENTITY {
+			if _, err := CreateEntityGroupController(settings.EntityGroupControllerType); err != nil {
+				rootLogger.Error("failed to create the entity group controller, FlatEntityGroupController will be used",
+					zap.String("entityGroupControllerType", settings.EntityGroupControllerType),
+					zap.Error(err),
+				)
+			}
+		}
+
 		if settings.DataMsgFullName == "" {
 			continue
 		}
@@ -150,6 +176,11 @@ var ErrSpatialChannelFull = errors.New("spatial channels are full")
 var ErrEntityChannelFull = errors.New("entity channels are full")
 
 func createChannelWithId(channelId common.ChannelId, t channeldpb.ChannelType, owner ConnectionInChannel) *Channel {
+	return createChannelInWorld(channelId, t, owner, DefaultSpatialWorldId)
+}
+
+// Same as createChannelWithId, but the entity channel belongs to the spatial world.
+func createChannelInWorld(channelId common.ChannelId, t channeldpb.ChannelType, owner ConnectionInChannel, worldId string) *Channel {
 	ch := &Channel{
 		id:                    channelId,
 		channelType:           t,
@@ -173,8 +204,14 @@ func createChannelWithId(channelId common.ChannelId, t channeldpb.ChannelType, o
 	}
 
 	if ch.channelType == channeldpb.ChannelType_ENTITY {
-		ch.spatialNotifier = GetSpatialController()
-		ch.entityController = &FlatEntityGroupController{}
+		ch.spatialWorldId = worldId
+		ch.spatialNotifier = newEntitySpatialNotifier(EntityId(channelId), GetSpatialControllerOfWorld(worldId))
+		entityController, err := CreateEntityGroupController(GlobalSettings.GetChannelSettings(t).EntityGroupControllerType)
+		if err != nil {
+			// The error has been logged in InitChannels()
+			entityController = &FlatEntityGroupController{}
+		}
+		ch.entityController = entityController
 		ch.entityController.Initialize(ch)
 	}
 
@@ -212,19 +249,14 @@ func CreateChannel(t channeldpb.ChannelType, owner ConnectionInChannel) (*Channe
 			spatialChannelFull = true
 			return nil, ErrSpatialChannelFull
 		}
-		/* Entity channels use fixed channelId (= netId)
-		} else if t == channeldpb.ChannelType_ENTITY {
-			if entityChannelFull {
-				return nil, ErrEntityChannelFull
-			}
-			channelId, ok = GetNextIdTyped[common.ChannelId, *Channel](allChannels, nextEntityChannelId, GlobalSettings.EntityChannelIdStart, math.MaxUint32)
-			if ok {
-				nextEntityChannelId = channelId
-			} else {
-				entityChannelFull = true
-				return nil, ErrEntityChannelFull
-			}
-		*/
+	} else if t == channeldpb.ChannelType_ENTITY {
+		// Entity channels usually use fixed channelId (= netId), see handleCreateEntityChannel().
+		// Otherwise, channeld assigns an ID that is not leased to any connection.
+		entityId, err := entityIds.allocateId()
+		if err != nil {
+			return nil, err
+		}
+		channelId = common.ChannelId(entityId)
 	} else {
 		if nonSpatialChannelFull {
 			return nil, ErrNonSpatialChannelFull
@@ -246,7 +278,14 @@ func RemoveChannel(ch *Channel) {
 
 	if ch.channelType == channeldpb.ChannelType_ENTITY {
 		ch.entityController.Uninitialize(ch)
+		pendingHandovers.remove(EntityId(ch.id))
+		spatialEntities.remove(EntityId(ch.id))
 		Event_AuthComplete.UnlistenFor(ch)
+		if atomic.SwapInt32(&ch.dormant, 0) > 0 {
+			dormantChannelNum.Dec()
+		}
+	} else if ch.channelType == channeldpb.ChannelType_SUBWORLD {
+		resetSpatialWorldsOwnedBy(ch)
 	}
 
 	atomic.AddInt32(&ch.removing, 1)
@@ -341,6 +380,16 @@ func (ch *Channel) GetTime() ChannelTime {
 	return ChannelTime(time.Since(ch.startTime))
 }
 
+// Converts the Unix timestamp in milliseconds to the relative time since the channel created.
+func (ch *Channel) UnixMilliToChannelTime(ms int64) ChannelTime {
+	return ChannelTime(time.UnixMilli(ms).Sub(ch.startTime))
+}
+
+// Converts the relative time since the channel created to the Unix timestamp in milliseconds.
+func (ch *Channel) ChannelTimeToUnixMilli(t ChannelTime) int64 {
+	return ch.startTime.Add(time.Duration(t)).UnixMilli()
+}
+
 func (ch *Channel) Tick() {
 	for {
 		if ch.IsRemoving() {
@@ -353,38 +402,50 @@ func (ch *Channel) Tick() {
 		if ch.channelType == channeldpb.ChannelType_GLOBAL && spatialController != nil {
 			spatialController.Tick()
 		}
+		if ch.channelType == channeldpb.ChannelType_GLOBAL {
+			tickSpatialWorlds()
+			tickEntityInterest(tickStart)
+			tickPendingHandovers(tickStart)
+		}
 
 		ch.tickFrames++
 
 		ch.tickMessages(tickStart)
 
+		if ch.channelType == channeldpb.ChannelType_SPATIAL {
+			ch.updateEntityCount()
+		} else if ch.channelType == channeldpb.ChannelType_ENTITY {
+			ch.updateSpatialInfo()
+		}
+
 		ch.subLock.RLock()
-		ch.tickData(ch.GetTime())
+		// The dormant channel still ticks the data for the pending fan-outs, e.g. the first fan-out to the new subscribers.
+		idle := ch.IsDormant() && !ch.hasPendingFanOut()
+		if !idle {
+			ch.tickData(ch.GetTime())
+		}
 		ch.tickConnections()
+		if ch.channelType == channeldpb.ChannelType_ENTITY {
+			ch.tickDormancy(tickStart)
+		}
 		ch.subLock.RUnlock()
 
 		tickDuration := time.Since(tickStart)
+		atomic.StoreInt64(&ch.lastTickDuration, int64(tickDuration))
 		channelTickDuration.WithLabelValues(ch.channelType.String()).Set(float64(tickDuration) / float64(time.Millisecond))
 
-		time.Sleep(ch.tickInterval - tickDuration)
+		if idle {
+			ch.waitDormant()
+		} else {
+			time.Sleep(ch.tickInterval - tickDuration)
+		}
 	}
 }
 
 func (ch *Channel) tickMessages(tickStart time.Time) {
 	for len(ch.inMsgQueue) > 0 {
 		cm := <-ch.inMsgQueue
-
-		// No message in the context, just execute the handler.
-		if cm.ctx.Msg == nil {
-			cm.handler(cm.ctx)
-			continue
-		}
-
-		if cm.ctx.Connection == nil {
-			ch.Logger().Warn("drops message as the sender is lost", zap.Uint32("msgType", uint32(cm.ctx.MsgType)))
-			continue
-		}
-		cm.handler(cm.ctx)
+		ch.handleChannelMessage(cm)
 		if ch.tickInterval > 0 && time.Since(tickStart) >= ch.tickInterval {
 			ch.Logger().Warn("spent too long handling messages, will delay the left to the next tick",
 				zap.Duration("duration", time.Since(tickStart)),
@@ -395,6 +456,20 @@ func (ch *Channel) tickMessages(tickStart time.Time) {
 	}
 }
 
+func (ch *Channel) handleChannelMessage(cm channelMessage) {
+	// No message in the context, just execute the handler.
+	if cm.ctx.Msg == nil {
+		cm.handler(cm.ctx)
+		return
+	}
+
+	if cm.ctx.Connection == nil {
+		ch.Logger().Warn("drops message as the sender is lost", zap.Uint32("msgType", uint32(cm.ctx.MsgType)))
+		return
+	}
+	cm.handler(cm.ctx)
+}
+
 func (ch *Channel) tickConnections() {
 	// defer func() {
 	// 	ch.subLock.RUnlock()
@@ -413,7 +488,7 @@ func (ch *Channel) tickConnections() {
 					Event_GlobalChannelUnpossessed.Broadcast(struct{}{})
 				}
 				conn.Logger().Info("found removed ownner connection of channel", zap.Uint32("channelId", uint32(ch.id)))
-				if GlobalSettings.GetChannelSettings(ch.channelType).RemoveChannelAfterOwnerRemoved {
+				if GlobalSettings.GetChannelSettings(ch.channelType).RemoveChannelAfterOwnerRemoved && !shouldKeepChannelForFailover(ch) {
 					atomic.AddInt32(&ch.removing, 1)
 
 					// DO NOT remove the GLOBAL channel!
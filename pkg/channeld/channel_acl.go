@@ -2,6 +2,26 @@ package channeld
 
 import (
 	"errors"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"go.uber.org/zap"
+)
+
+/* Role-based ACL
+ * The roles (e.g. admin, moderator, player, spectator) of a connection are assigned at the auth time by the RolesAuthTag
+ * (comma-separated), see AuthTagsProvider. The server connections always have the ServerRole.
+ * The rules are set in ChannelSettingsType.ACLSettings.RoleRules per channel type, and can be overridden per channel
+ * by CreateChannelMessage.roleACL or CreateEntityChannelMessage.roleACL. The first rule that matches the connection's roles decides.
+ * If none matches, the access level of the channel type applies (sub/unsub/remove), or the access is allowed (user-space messages).
+ * The data write rules can only deny: the connection also needs to be the owner or have the WRITE_ACCESS subscription.
+ * The channel owner is not subject to the rules.
+ * The denied connection receives the AccessDeniedMessage.
+ */
+
+const (
+	RolesAuthTag = "roles"
+	ServerRole   = "server"
+	AnyRole      = "*"
 )
 
 type ChannelAccessType uint8
@@ -10,6 +30,9 @@ const (
 	ChannelAccessType_Sub    ChannelAccessType = 0
 	ChannelAccessType_Unsub  ChannelAccessType = 1
 	ChannelAccessType_Remove ChannelAccessType = 2
+	// Only checked by the role-based rules
+	ChannelAccessType_DataWrite   ChannelAccessType = 3
+	ChannelAccessType_UserMessage ChannelAccessType = 4
 )
 
 type ChannelAccessLevel uint8
@@ -26,9 +49,17 @@ var (
 	ErrOwnerOnlyAccess           = errors.New("only the channel owenr can access")
 	ErrOwnerAndGlobalOwnerAccess = errors.New("only the channel owenr or global channel owner can access")
 	ErrIllegalAccessLevel        = errors.New("illegal channel access level")
+	ErrRoleDenied                = errors.New("the role of the connection is denied")
 )
 
 func (ch *Channel) CheckACL(c ConnectionInChannel, accessType ChannelAccessType) (bool, error) {
+	if allowed, matched := ch.checkRoleACL(c, accessType, 0); matched {
+		if allowed {
+			return true, nil
+		}
+		return false, ErrRoleDenied
+	}
+
 	// default level is none
 	level := ChannelAccessLevel_None
 
@@ -69,3 +100,138 @@ func (ch *Channel) CheckACL(c ConnectionInChannel, accessType ChannelAccessType)
 	}
 
 }
+
+// Returns the role-based rules of the access. The rules of the channel override the ones in the settings.
+func (ch *Channel) getRoleACLRules(accessType ChannelAccessType, msgType uint32) []RoleACLRule {
+	if ch.roleACL != nil {
+		if rules := ch.roleACL.getRules(accessType, msgType); len(rules) > 0 {
+			return rules
+		}
+	}
+	if channelSettings, exists := GlobalSettings.ChannelSettings[ch.channelType]; exists {
+		return channelSettings.ACLSettings.RoleRules.getRules(accessType, msgType)
+	}
+	return nil
+}
+
+func (acl *RoleACLSettingsType) getRules(accessType ChannelAccessType, msgType uint32) []RoleACLRule {
+	switch accessType {
+	case ChannelAccessType_Sub:
+		return acl.Sub
+	case ChannelAccessType_Unsub:
+		return acl.Unsub
+	case ChannelAccessType_Remove:
+		return acl.Remove
+	case ChannelAccessType_DataWrite:
+		return acl.DataWrite
+	case ChannelAccessType_UserMessage:
+		return acl.UserMessages[msgType]
+	}
+	return nil
+}
+
+// Checks the role-based rules of the access. msgType is only used by ChannelAccessType_UserMessage.
+// Returns matched = false if no rule matches the connection, or the connection is the channel owner or nil (internal access).
+func (ch *Channel) checkRoleACL(c ConnectionInChannel, accessType ChannelAccessType, msgType uint32) (allowed bool, matched bool) {
+	if c == nil || c == ch.GetOwner() {
+		return false, false
+	}
+	// The internal access may also pass a typed nil, e.g. the removal of the channel queued by tickConnections
+	if conn, ok := c.(*Connection); ok && conn == nil {
+		return false, false
+	}
+
+	rules := ch.getRoleACLRules(accessType, msgType)
+	if len(rules) == 0 {
+		return false, false
+	}
+
+	var roles []string
+	if conn, ok := c.(interface{ GetRoles() []string }); ok {
+		roles = conn.GetRoles()
+	} else if c.GetConnectionType() == channeldpb.ConnectionType_SERVER {
+		roles = []string{ServerRole}
+	}
+
+	for _, rule := range rules {
+		for _, ruleRole := range rule.Roles {
+			if ruleRole == AnyRole {
+				return rule.Allow, true
+			}
+			for _, role := range roles {
+				if role == ruleRole {
+					return rule.Allow, true
+				}
+			}
+		}
+	}
+	return false, false
+}
+
+func roleACLRulesFromProto(rules []*channeldpb.RoleACLRule) []RoleACLRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]RoleACLRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, RoleACLRule{Roles: rule.Roles, Allow: rule.Allow})
+	}
+	return result
+}
+
+// Returns nil if the message is nil.
+func roleACLFromProto(msg *channeldpb.ChannelRoleACL) *RoleACLSettingsType {
+	if msg == nil {
+		return nil
+	}
+	acl := &RoleACLSettingsType{
+		Sub:       roleACLRulesFromProto(msg.Sub),
+		Unsub:     roleACLRulesFromProto(msg.Unsub),
+		Remove:    roleACLRulesFromProto(msg.Remove),
+		DataWrite: roleACLRulesFromProto(msg.DataWrite),
+	}
+	if len(msg.UserMessages) > 0 {
+		acl.UserMessages = make(map[uint32][]RoleACLRule, len(msg.UserMessages))
+		for msgType, list := range msg.UserMessages {
+			acl.UserMessages[msgType] = roleACLRulesFromProto(list.GetRules())
+		}
+	}
+	return acl
+}
+
+func accessDeniedReason(err error) channeldpb.AccessDeniedMessage_Reason {
+	switch err {
+	case ErrNoneAccess:
+		return channeldpb.AccessDeniedMessage_NO_ACCESS
+	case ErrOwnerOnlyAccess:
+		return channeldpb.AccessDeniedMessage_OWNER_ONLY
+	case ErrOwnerAndGlobalOwnerAccess:
+		return channeldpb.AccessDeniedMessage_OWNER_AND_GLOBAL_OWNER_ONLY
+	case ErrRoleDenied:
+		return channeldpb.AccessDeniedMessage_ROLE_DENIED
+	}
+	return channeldpb.AccessDeniedMessage_UNKNOWN
+}
+
+// Reports the denied message to the sender.
+func sendAccessDenied(ctx MessageContext, channelId uint32, reason channeldpb.AccessDeniedMessage_Reason) {
+	if ctx.Connection == nil {
+		return
+	}
+	ctx.Connection.Logger().Debug("access denied",
+		zap.Uint32("msgType", uint32(ctx.MsgType)),
+		zap.Uint32("channelId", channelId),
+		zap.String("reason", reason.String()),
+	)
+	ctx.Connection.Send(MessageContext{
+		MsgType: channeldpb.MessageType_ACCESS_DENIED,
+		Msg: &channeldpb.AccessDeniedMessage{
+			MsgType:   uint32(ctx.MsgType),
+			ChannelId: channelId,
+			Reason:    reason,
+		},
+		Channel:   ctx.Channel,
+		ChannelId: channelId,
+		StubId:    ctx.StubId,
+	})
+}
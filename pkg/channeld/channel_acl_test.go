@@ -2,6 +2,7 @@ package channeld
 
 import (
 	"net"
+	"sync/atomic"
 	"testing"
 
 	"github.com/metaworking/channeld/pkg/channeldpb"
@@ -107,10 +108,40 @@ func setChannelACLSettings(chTypes []channeldpb.ChannelType, acl ChannelAccessLe
 	}
 }
 
-func TestCheckACL(t *testing.T) {
+// Restores the channel settings and the GLOBAL channel after the test, and stops the channels created in the test,
+// so the test doesn't affect the other tests in the same run (e.g. the channels ticking with no interval).
+func isolateChannelTest(t *testing.T) {
 	InitLogs()
 	InitChannels()
 
+	oldSettings := make(map[channeldpb.ChannelType]ChannelSettingsType, len(GlobalSettings.ChannelSettings))
+	for chType, settings := range GlobalSettings.ChannelSettings {
+		oldSettings[chType] = settings
+	}
+	oldGlobalChannel := globalChannel
+	oldChannelIds := make(map[common.ChannelId]struct{})
+	allChannels.Range(func(chId common.ChannelId, _ *Channel) bool {
+		oldChannelIds[chId] = struct{}{}
+		return true
+	})
+
+	t.Cleanup(func() {
+		allChannels.Range(func(chId common.ChannelId, ch *Channel) bool {
+			if _, exists := oldChannelIds[chId]; !exists {
+				// Stop the channel.Tick() goroutine
+				atomic.AddInt32(&ch.removing, 1)
+				allChannels.Delete(chId)
+			}
+			return true
+		})
+		GlobalSettings.ChannelSettings = oldSettings
+		globalChannel = oldGlobalChannel
+	})
+}
+
+func TestCheckACL(t *testing.T) {
+	isolateChannelTest(t)
+
 	accessTypes := []ChannelAccessType{ChannelAccessType_Sub, ChannelAccessType_Unsub, ChannelAccessType_Remove}
 
 	const ChannelType_Test1 channeldpb.ChannelType = 201
@@ -309,3 +340,124 @@ func TestCheckACL(t *testing.T) {
 	// ch = nil
 	// ch.CheckACL(ownerConn, o)
 }
+
+type roleTestConnection struct {
+	*aclTestConnection
+	roles []string
+	sent  []MessageContext
+}
+
+func (c *roleTestConnection) GetRoles() []string {
+	return c.roles
+}
+
+func (c *roleTestConnection) Send(ctx MessageContext) {
+	c.sent = append(c.sent, ctx)
+}
+
+func (c *roleTestConnection) lastDeniedReason() channeldpb.AccessDeniedMessage_Reason {
+	if len(c.sent) == 0 || c.sent[len(c.sent)-1].MsgType != channeldpb.MessageType_ACCESS_DENIED {
+		return channeldpb.AccessDeniedMessage_UNKNOWN
+	}
+	return c.sent[len(c.sent)-1].Msg.(*channeldpb.AccessDeniedMessage).Reason
+}
+
+func createRoleTestConnection(roles ...string) *roleTestConnection {
+	return &roleTestConnection{aclTestConnection: createACLTestConnection(), roles: roles}
+}
+
+func TestRoleACL(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	const ChannelType_Test2 channeldpb.ChannelType = 202
+	GlobalSettings.ChannelSettings[ChannelType_Test2] = ChannelSettingsType{
+		ACLSettings: ACLSettingsType{
+			Sub:    ChannelAccessLevel_Any,
+			Unsub:  ChannelAccessLevel_OwnerOnly,
+			Remove: ChannelAccessLevel_OwnerOnly,
+			RoleRules: RoleACLSettingsType{
+				Sub:       []RoleACLRule{{Roles: []string{"spectator"}, Allow: false}},
+				Remove:    []RoleACLRule{{Roles: []string{"admin", "moderator"}, Allow: true}},
+				DataWrite: []RoleACLRule{{Roles: []string{"spectator"}, Allow: false}, {Roles: []string{"player"}, Allow: true}},
+				UserMessages: map[uint32][]RoleACLRule{
+					100: {{Roles: []string{"admin"}, Allow: true}, {Roles: []string{AnyRole}, Allow: false}},
+				},
+			},
+		},
+	}
+
+	owner := createRoleTestConnection("spectator")
+	admin := createRoleTestConnection("player", "admin")
+	player := createRoleTestConnection("player")
+	spectator := createRoleTestConnection("spectator")
+	ch, _ := createChannelForTestACL(ChannelType_Test2, owner)
+
+	// The first matched rule decides, otherwise the access level applies
+	hasAccess, err := ch.CheckACL(spectator, ChannelAccessType_Sub)
+	assert.False(t, hasAccess)
+	assert.ErrorIs(t, err, ErrRoleDenied)
+	hasAccess, _ = ch.CheckACL(player, ChannelAccessType_Sub)
+	assert.True(t, hasAccess)
+	hasAccess, _ = ch.CheckACL(admin, ChannelAccessType_Remove)
+	assert.True(t, hasAccess)
+	hasAccess, err = ch.CheckACL(player, ChannelAccessType_Remove)
+	assert.False(t, hasAccess)
+	assert.ErrorIs(t, err, ErrOwnerOnlyAccess)
+	// The channel owner is not subject to the rules
+	hasAccess, _ = ch.CheckACL(owner, ChannelAccessType_Sub)
+	assert.True(t, hasAccess)
+
+	allowed, matched := ch.checkRoleACL(admin, ChannelAccessType_UserMessage, 100)
+	assert.True(t, matched)
+	assert.True(t, allowed)
+	allowed, matched = ch.checkRoleACL(player, ChannelAccessType_UserMessage, 100)
+	assert.True(t, matched)
+	assert.False(t, allowed)
+	_, matched = ch.checkRoleACL(player, ChannelAccessType_UserMessage, 101)
+	assert.False(t, matched)
+
+	// The denied data update is reported to the sender
+	handleChannelDataUpdate(MessageContext{MsgType: channeldpb.MessageType_CHANNEL_DATA_UPDATE, Connection: spectator, Channel: ch})
+	assert.Equal(t, channeldpb.AccessDeniedMessage_ROLE_DENIED, spectator.lastDeniedReason())
+	// The allowing rule doesn't grant the write access
+	handleChannelDataUpdate(MessageContext{MsgType: channeldpb.MessageType_CHANNEL_DATA_UPDATE, Connection: player, Channel: ch})
+	assert.Equal(t, channeldpb.AccessDeniedMessage_NO_WRITE_ACCESS, player.lastDeniedReason())
+
+	// The rules of the channel override the ones of the channel type
+	ch.roleACL = roleACLFromProto(&channeldpb.ChannelRoleACL{
+		Sub: []*channeldpb.RoleACLRule{{Roles: []string{"player"}, Allow: false}},
+		UserMessages: map[uint32]*channeldpb.RoleACLRuleList{
+			101: {Rules: []*channeldpb.RoleACLRule{{Roles: []string{"spectator"}, Allow: false}}},
+		},
+	})
+	hasAccess, _ = ch.CheckACL(spectator, ChannelAccessType_Sub)
+	assert.True(t, hasAccess)
+	hasAccess, err = ch.CheckACL(player, ChannelAccessType_Sub)
+	assert.False(t, hasAccess)
+	assert.ErrorIs(t, err, ErrRoleDenied)
+	hasAccess, _ = ch.CheckACL(admin, ChannelAccessType_Remove)
+	assert.True(t, hasAccess)
+	allowed, matched = ch.checkRoleACL(spectator, ChannelAccessType_UserMessage, 101)
+	assert.True(t, matched)
+	assert.False(t, allowed)
+
+	// The roles are parsed from the auth tags, and the server connections always have the server role
+	conn := &Connection{connectionType: channeldpb.ConnectionType_SERVER}
+	assert.Equal(t, []string{ServerRole}, conn.GetRoles())
+	conn.SetAuthTags(map[string]string{RolesAuthTag: "admin, moderator"})
+	assert.Equal(t, []string{ServerRole, "admin", "moderator"}, conn.GetRoles())
+
+	// The internal removal (without a connection) is not subject to the rules
+	_, matched = ch.checkRoleACL((*Connection)(nil), ChannelAccessType_Remove, 0)
+	assert.False(t, matched)
+	assert.NotPanics(t, func() {
+		handleRemoveChannel(MessageContext{
+			MsgType:    channeldpb.MessageType_REMOVE_CHANNEL,
+			Msg:        &channeldpb.RemoveChannelMessage{ChannelId: uint32(ch.id)},
+			Connection: (*Connection)(nil),
+			Channel:    globalChannel,
+		})
+	})
+	assert.Nil(t, GetChannel(ch.id))
+}
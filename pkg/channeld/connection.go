@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -55,7 +56,7 @@ type queuedMessagePackSender struct {
 }
 
 func (s *queuedMessagePackSender) Send(c *Connection, ctx MessageContext) {
-	msgBody, err := proto.Marshal(ctx.Msg)
+	msgBody, err := c.marshalMessage(ctx.Msg)
 	if err != nil {
 		c.logger.Error("failed to marshal message", zap.Error(err), zap.Uint32("msgType", uint32(ctx.MsgType)))
 		return
@@ -87,9 +88,13 @@ type Connection struct {
 	id              ConnectionId
 	connectionType  channeldpb.ConnectionType
 	compressionType channeldpb.CompressionType
-	conn            net.Conn
-	readBuffer      []byte
-	readPos         int
+	// Negotiated by the first received packet. Read/write with atomic operations. See getEncodingType().
+	encodingType channeldpb.EncodingType
+	// Only accessed in the receiving goroutine
+	encodingNegotiated bool
+	conn               net.Conn
+	readBuffer         []byte
+	readPos            int
 	// reader          *bufio.Reader
 	// writer          *bufio.Writer
 	sender               MessageSender
@@ -104,6 +109,25 @@ type Connection struct {
 	closeHandlers        []func()
 	replaySession        *replaypb.ReplaySession
 	spatialSubscriptions *xsync.MapOf[common.ChannelId, *channeldpb.ChannelSubscriptionOptions]
+	// The position for calculating the distance-based fan-out priority of the entity channels. See SetTrackedPosition().
+	trackedPosition     *common.SpatialInfo
+	trackedPositionLock sync.RWMutex
+	// Only created for the client connections if GlobalSettings.EntityFanOutBudgetBytesPerSec > 0
+	entityFanOutBudget *fanOutBudget
+	// The rate limits of the received messages. Nil = no limit. See rate_limit.go.
+	rateLimiter *connRateLimiter
+	// The name of the spatial damping profile (string). See SetSpatialDampingProfile().
+	spatialDampingProfile atomic.Value
+	// The last spatial interest update of the client connection (*channeldpb.UpdateSpatialInterestMessage). See ReloadSpatialDampingProfiles().
+	spatialInterestMsg atomic.Value
+	// The query of the entity-level interest (*channeldpb.SpatialInterestQuery). See SetEntityInterestQuery().
+	entityInterestQuery atomic.Value
+	// The states of the subscriptions made by the entity-level interest. Only accessed in the GLOBAL channel.
+	entityInterestSubs map[common.ChannelId]entityInterestSubState
+	// The tags resolved at the auth time (map[string]string). See AuthTagsProvider.
+	authTags atomic.Value
+	// The roles parsed from the auth tags ([]string). See GetRoles().
+	roles atomic.Value
 }
 
 var allConnections *xsync.MapOf[ConnectionId, *Connection]
@@ -220,8 +244,7 @@ func StartListening(t channeldpb.ConnectionType, network string, address string)
 
 			// Check if the IP address is banned.
 			ip := GetIP(conn.RemoteAddr())
-			_, banned := ipBlacklist[ip]
-			if banned {
+			if bans.IsIPBanned(ip) {
 				securityLogger.Info("refused connection of banned IP address", zap.String("ip", ip))
 				conn.Close()
 				continue
@@ -283,6 +306,7 @@ func AddConnection(c net.Conn, t channeldpb.ConnectionType) *Connection {
 		id:              ConnectionId(nextConnectionId),
 		connectionType:  t,
 		compressionType: channeldpb.CompressionType_NO_COMPRESSION,
+		encodingType:    channeldpb.EncodingType_PROTOBUF,
 		conn:            c,
 		readBuffer:      make([]byte, readerSize),
 		readPos:         0,
@@ -301,6 +325,11 @@ func AddConnection(c net.Conn, t channeldpb.ConnectionType) *Connection {
 		spatialSubscriptions: xsync.NewTypedMapOf[common.ChannelId, *channeldpb.ChannelSubscriptionOptions](UintIdHasher[common.ChannelId]()),
 	}
 
+	if t == channeldpb.ConnectionType_CLIENT && GlobalSettings.EntityFanOutBudgetBytesPerSec > 0 {
+		connection.entityFanOutBudget = newFanOutBudget(GlobalSettings.EntityFanOutBudgetBytesPerSec)
+	}
+	connection.rateLimiter = newConnRateLimiter(t, connection.connTime)
+
 	if connection.isPacketRecordingEnabled() {
 		connection.replaySession = &replaypb.ReplaySession{
 			Packets: make([]*replaypb.ReplayPacket, 0, 1024),
@@ -504,8 +533,32 @@ func (c *Connection) readPacket(bufPos *int) (*channeldpb.Packet, error) {
 		}
 	}
 
-	var p channeldpb.Packet
-	if err := proto.Unmarshal(bytes, &p); err != nil {
+	// The first received packet decides the encoding of the connection, and the following packets should use the same encoding.
+	encodingType := channeldpb.EncodingType_PROTOBUF
+	if isJsonPacket(bytes) {
+		encodingType = channeldpb.EncodingType_JSON
+	}
+	if !c.encodingNegotiated {
+		atomic.StoreInt32((*int32)(&c.encodingType), int32(encodingType))
+		c.encodingNegotiated = true
+	} else if encodingType != c.getEncodingType() {
+		connectionClosed.WithLabelValues(c.connectionType.String()).Inc()
+		c.Logger().Warn("packet encoding is different from the negotiated one, the connection will be closed",
+			zap.String("encoding", encodingType.String()),
+			zap.String("negotiated", c.getEncodingType().String()),
+		)
+		return nil, errors.New("packet encoding mismatch")
+	}
+
+	var p *channeldpb.Packet
+	var err error
+	if encodingType == channeldpb.EncodingType_JSON {
+		p, err = unmarshalJsonPacket(bytes)
+	} else {
+		p = &channeldpb.Packet{}
+		err = proto.Unmarshal(bytes, p)
+	}
+	if err != nil {
 		c.Logger().Error("failed to unmarshall packet, the connection will be closed", zap.Error(err),
 			zap.Uint32("size", uint32(packetSize)),
 			zap.Binary("tag", tag),
@@ -518,7 +571,7 @@ func (c *Connection) readPacket(bufPos *int) (*channeldpb.Packet, error) {
 	packetReceived.WithLabelValues(c.connectionType.String()).Inc()
 
 	if c.isPacketRecordingEnabled() {
-		c.recordPacket(&p)
+		c.recordPacket(p)
 	}
 
 	for _, mp := range p.Messages {
@@ -526,7 +579,7 @@ func (c *Connection) readPacket(bufPos *int) (*channeldpb.Packet, error) {
 	}
 
 	*bufPos += fullSize
-	return &p, nil
+	return p, nil
 }
 
 func (c *Connection) isPacketRecordingEnabled() bool {
@@ -534,6 +587,10 @@ func (c *Connection) isPacketRecordingEnabled() bool {
 }
 
 func (c *Connection) receiveMessage(mp *channeldpb.MessagePack) {
+	if c.rateLimiter != nil && !c.checkRateLimit(mp) {
+		return
+	}
+
 	channel := GetChannel(common.ChannelId(mp.ChannelId))
 	if channel == nil {
 		// Sub to/unsub from a removed channel is allowed
@@ -561,6 +618,22 @@ func (c *Connection) receiveMessage(mp *channeldpb.MessagePack) {
 		return
 	}
 
+	if mp.MsgType >= uint32(channeldpb.MessageType_USER_SPACE_START) {
+		if allowed, matched := channel.checkRoleACL(c, ChannelAccessType_UserMessage, mp.MsgType); matched && !allowed {
+			c.Logger().Warn("user-space message is denied by the role-based rules",
+				zap.Uint32("msgType", mp.MsgType),
+				zap.Uint32("channelId", mp.ChannelId),
+			)
+			sendAccessDenied(MessageContext{
+				MsgType:    channeldpb.MessageType(mp.MsgType),
+				StubId:     mp.StubId,
+				Connection: c,
+				Channel:    channel,
+			}, mp.ChannelId, channeldpb.AccessDeniedMessage_ROLE_DENIED)
+			return
+		}
+	}
+
 	var msg common.Message
 	var handler MessageHandlerFunc
 	if mp.MsgType >= uint32(channeldpb.MessageType_USER_SPACE_START) && entry == nil {
@@ -657,7 +730,7 @@ func (c *Connection) flush() {
 		)*/
 	}
 
-	bytes, err := proto.Marshal(&p)
+	bytes, err := c.marshalPacket(&p)
 	if err != nil {
 		c.Logger().Error("failed to marshal packet", zap.Error(err))
 		return
@@ -0,0 +1,106 @@
+package channeld
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// The JSON representation of channeldpb.MessagePack. Unlike protojson, the message body is embedded as a JSON object instead of base64-encoded bytes.
+type jsonMessagePack struct {
+	ChannelId uint32          `json:"channelId"`
+	Broadcast uint32          `json:"broadcast,omitempty"`
+	StubId    uint32          `json:"stubId,omitempty"`
+	MsgType   uint32          `json:"msgType"`
+	MsgBody   json.RawMessage `json:"msgBody,omitempty"`
+}
+
+// The JSON representation of channeldpb.Packet
+type jsonPacket struct {
+	Messages []*jsonMessagePack `json:"messages"`
+}
+
+var jsonUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// Returns true if the packet body is encoded in JSON.
+func isJsonPacket(bytes []byte) bool {
+	return len(bytes) > 0 && bytes[0] == '{'
+}
+
+// Returns the encoding negotiated by the first received packet. Safe to call from any goroutine.
+func (c *Connection) getEncodingType() channeldpb.EncodingType {
+	return channeldpb.EncodingType(atomic.LoadInt32((*int32)(&c.encodingType)))
+}
+
+// Marshals the message with the connection's encoding.
+func (c *Connection) marshalMessage(msg common.Message) ([]byte, error) {
+	if c.getEncodingType() == channeldpb.EncodingType_JSON {
+		return protojson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+// Marshals the packet with the connection's encoding. For the JSON encoding, the message bodies should have been marshalled in JSON.
+func (c *Connection) marshalPacket(p *channeldpb.Packet) ([]byte, error) {
+	if c.getEncodingType() != channeldpb.EncodingType_JSON {
+		return proto.Marshal(p)
+	}
+
+	jp := jsonPacket{Messages: make([]*jsonMessagePack, len(p.Messages))}
+	for i, mp := range p.Messages {
+		jp.Messages[i] = &jsonMessagePack{
+			ChannelId: mp.ChannelId,
+			Broadcast: mp.Broadcast,
+			StubId:    mp.StubId,
+			MsgType:   mp.MsgType,
+			MsgBody:   mp.MsgBody,
+		}
+	}
+	return json.Marshal(&jp)
+}
+
+// Unmarshals the JSON packet, and transcodes the message bodies to the Protobuf's binary format, so they can be handled as the normal messages.
+// The user-space messages without the registered handler are forwarded as-is.
+func unmarshalJsonPacket(bytes []byte) (*channeldpb.Packet, error) {
+	var jp jsonPacket
+	if err := json.Unmarshal(bytes, &jp); err != nil {
+		return nil, err
+	}
+
+	p := &channeldpb.Packet{Messages: make([]*channeldpb.MessagePack, len(jp.Messages))}
+	for i, jmp := range jp.Messages {
+		if jmp == nil {
+			return nil, fmt.Errorf("message pack #%d is null", i)
+		}
+
+		msgBody := []byte(jmp.MsgBody)
+		if entry := MessageMap[channeldpb.MessageType(jmp.MsgType)]; entry != nil {
+			msg := proto.Clone(entry.msg)
+			if len(jmp.MsgBody) > 0 {
+				if err := jsonUnmarshalOptions.Unmarshal(jmp.MsgBody, msg); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal the body of message pack #%d (msgType=%d): %w", i, jmp.MsgType, err)
+				}
+			}
+
+			var err error
+			msgBody, err = proto.Marshal(msg)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		p.Messages[i] = &channeldpb.MessagePack{
+			ChannelId: jmp.ChannelId,
+			Broadcast: jmp.Broadcast,
+			StubId:    jmp.StubId,
+			MsgType:   jmp.MsgType,
+			MsgBody:   msgBody,
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,104 @@
+package channeld
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestUnmarshalJsonPacket(t *testing.T) {
+	bytes := []byte(`{"messages": [
+		{"channelId": 0, "msgType": 5, "stubId": 1, "msgBody": {"typeFilter": "SUBWORLD", "metadataFilters": ["a"]}},
+		{"channelId": 1, "msgType": 8, "msgBody": {"data": {"@type": "type.googleapis.com/testpb.TestChannelDataMessage", "text": "abc", "num": 123}}},
+		{"channelId": 1, "msgType": 100, "msgBody": {"foo": "bar"}}
+	]}`)
+	assert.True(t, isJsonPacket(bytes))
+
+	p, err := unmarshalJsonPacket(bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(p.Messages))
+
+	assert.EqualValues(t, 1, p.Messages[0].StubId)
+	listMsg := &channeldpb.ListChannelMessage{}
+	assert.NoError(t, proto.Unmarshal(p.Messages[0].MsgBody, listMsg))
+	assert.Equal(t, channeldpb.ChannelType_SUBWORLD, listMsg.TypeFilter)
+	assert.Equal(t, []string{"a"}, listMsg.MetadataFilters)
+
+	// The channel data is transcoded to the binary format
+	updateMsg := &channeldpb.ChannelDataUpdateMessage{}
+	assert.NoError(t, proto.Unmarshal(p.Messages[1].MsgBody, updateMsg))
+	dataMsg, err := updateMsg.Data.UnmarshalNew()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "abc", Num: 123}, dataMsg))
+
+	// The user-space message without handler is forwarded as-is
+	assert.JSONEq(t, `{"foo": "bar"}`, string(p.Messages[2].MsgBody))
+
+	_, err = unmarshalJsonPacket([]byte(`{"messages": [{"msgType": 8, "msgBody": {"data": {"@type": "type.googleapis.com/unknown.Message"}}}]}`))
+	assert.Error(t, err)
+}
+
+func TestMarshalJsonPacket(t *testing.T) {
+	c := &Connection{encodingType: channeldpb.EncodingType_JSON}
+
+	anyData, _ := anypb.New(&testpb.TestChannelDataMessage{Text: "abc", Num: 123})
+	msgBody, err := c.marshalMessage(&channeldpb.ChannelDataUpdateMessage{Data: anyData})
+	assert.NoError(t, err)
+
+	bytes, err := c.marshalPacket(&channeldpb.Packet{Messages: []*channeldpb.MessagePack{
+		{ChannelId: 1, MsgType: uint32(channeldpb.MessageType_CHANNEL_DATA_UPDATE), MsgBody: msgBody},
+	}})
+	assert.NoError(t, err)
+	assert.True(t, isJsonPacket(bytes))
+
+	var jp jsonPacket
+	assert.NoError(t, json.Unmarshal(bytes, &jp))
+	assert.Equal(t, 1, len(jp.Messages))
+	assert.EqualValues(t, 1, jp.Messages[0].ChannelId)
+	assert.JSONEq(t, `{"data": {"@type": "type.googleapis.com/testpb.TestChannelDataMessage", "text": "abc", "num": 123}}`, string(jp.Messages[0].MsgBody))
+
+	// Round trip
+	p, err := unmarshalJsonPacket(bytes)
+	assert.NoError(t, err)
+	updateMsg := &channeldpb.ChannelDataUpdateMessage{}
+	assert.NoError(t, proto.Unmarshal(p.Messages[0].MsgBody, updateMsg))
+	assert.True(t, proto.Equal(anyData, updateMsg.Data))
+
+	// The binary encoding is not affected
+	c.encodingType = channeldpb.EncodingType_PROTOBUF
+	bytes, err = c.marshalPacket(&channeldpb.Packet{})
+	assert.NoError(t, err)
+	assert.False(t, isJsonPacket(bytes))
+}
+
+func TestReadJsonPacket(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	c := &Connection{
+		readBuffer: make([]byte, 1024),
+		logger:     rootLogger,
+	}
+
+	bytes := []byte(`{"messages": [{"channelId": 12345, "msgType": 5, "msgBody": {}}]}`)
+	c.readPos = copy(c.readBuffer, append([]byte{67, 72, 0, byte(len(bytes)), 0}, bytes...))
+	bufPos := 0
+	p, err := c.readPacket(&bufPos)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(p.Messages))
+	assert.Equal(t, channeldpb.EncodingType_JSON, c.getEncodingType())
+	assert.Equal(t, c.readPos, bufPos)
+
+	// The encoding can't be changed after the negotiation
+	bytes, _ = proto.Marshal(&channeldpb.Packet{Messages: []*channeldpb.MessagePack{{ChannelId: 12345, MsgType: 5}}})
+	c.readPos = copy(c.readBuffer, append([]byte{67, 72, 0, byte(len(bytes)), 0}, bytes...))
+	bufPos = 0
+	_, err = c.readPacket(&bufPos)
+	assert.Error(t, err)
+	assert.Equal(t, channeldpb.EncodingType_JSON, c.getEncodingType())
+}
@@ -2,6 +2,7 @@ package channeld
 
 import (
 	"container/list"
+	"errors"
 	"fmt"
 
 	"github.com/indiest/fmutils"
@@ -22,6 +23,10 @@ type ChannelData struct {
 	updateMsgBuffer      *list.List
 	maxFanOutIntervalMs  uint32
 	msgIndex             uint64
+	// Only created if ChannelSettingsType.DataHistorySize > 0
+	history *channelDataHistory
+	// The last accepted update of each top-level field. Only created if ChannelDataMergeOptions.ConflictPolicy is set.
+	fieldWriteStates map[protoreflect.FieldNumber]*fieldWriteState
 }
 
 // Indicate that the channel data message should be initialized with default values.
@@ -39,6 +44,10 @@ type fanOutConnection struct {
 	hadFirstFanOut   bool
 	lastFanOutTime   ChannelTime
 	lastMessageIndex uint64
+	// The size of the last fan-out, used for estimating the size of the next one
+	lastFanOutSize int
+	// Has the fan-out been postponed because of the connection's fan-out budget?
+	postponed bool
 }
 
 type updateMsgBufferElement struct {
@@ -106,6 +115,10 @@ func (ch *Channel) InitData(dataMsg common.ChannelDataMessage, mergeOptions *cha
 		mergeOptions:    mergeOptions,
 	}
 
+	if historySize := GlobalSettings.GetChannelSettings(ch.channelType).DataHistorySize; historySize > 0 {
+		ch.data.history = newChannelDataHistory(historySize, GlobalSettings.GetChannelSettings(ch.channelType).DataHistorySnapshotIntervalMs)
+	}
+
 	if dataMsg == nil {
 		var err error
 		ch.data.msg, err = ReflectChannelDataMessage(ch.channelType)
@@ -123,6 +136,11 @@ func (ch *Channel) InitData(dataMsg common.ChannelDataMessage, mergeOptions *cha
 			return
 		}
 	}
+
+	if ch.data.history != nil {
+		// Record the initial state
+		ch.data.history.record(ch.data.msg, nil, ch.data.msgIndex, ch.GetTime())
+	}
 }
 
 func (ch *Channel) Data() *ChannelData {
@@ -141,8 +159,47 @@ func (ch *Channel) SetDataUpdateConnId(connId ConnectionId) {
 	ch.latestDataUpdateConnId = connId
 }
 
+var ErrChannelDataNotInitialized = errors.New("channel data is not initialized")
+
+// Checks if the connection can read the channel data without subscribing to the channel.
+// Both GET_CHANNEL_DATA and FETCH_CHANNEL_DATA use the same rule: the connection should have the authority over the channel,
+// or have the access to sub to the channel, as it could read the data by subscribing to the channel anyway.
+func (ch *Channel) CheckDataReadAccess(conn ConnectionInChannel) error {
+	if conn.HasAuthorityOver(ch) {
+		return nil
+	}
+	if hasAccess, err := ch.CheckACL(conn, ChannelAccessType_Sub); !hasAccess {
+		return err
+	}
+	return nil
+}
+
+// Returns a snapshot of the channel data without subscribing the connection to the channel.
+// The connection should pass CheckDataReadAccess.
+// CAUTION: this function is not goroutine-safe. It should be called in the channel's goroutine.
+func (ch *Channel) FetchData(conn ConnectionInChannel, fieldMasks []string) (*anypb.Any, error) {
+	if err := ch.CheckDataReadAccess(conn); err != nil {
+		return nil, err
+	}
+
+	dataMsg := ch.GetDataMessage()
+	if dataMsg == nil {
+		return nil, ErrChannelDataNotInitialized
+	}
+
+	if len(fieldMasks) > 0 {
+		// Don't filter the channel data message itself
+		dataMsg = proto.Clone(dataMsg)
+		fmutils.Filter(dataMsg, fieldMasks)
+	}
+	return anypb.New(dataMsg)
+}
+
 func (d *ChannelData) OnUpdate(updateMsg common.ChannelDataMessage, t ChannelTime, senderConnId ConnectionId, spatialNotifier common.SpatialInfoChangedNotifier) {
+	// The update message to record in the history. Nil means the update message is the initial state.
+	historyUpdateMsg := updateMsg
 	if d.msg == nil {
+		historyUpdateMsg = nil
 		d.msg = updateMsg
 		rootLogger.Info("initialized channel data with update message",
 			zap.Uint32("senderConnId", uint32(senderConnId)),
@@ -158,6 +215,9 @@ func (d *ChannelData) OnUpdate(updateMsg common.ChannelDataMessage, t ChannelTim
 		senderConnId: senderConnId,
 		messageIndex: d.msgIndex,
 	})
+	if d.history != nil {
+		d.history.record(d.msg, historyUpdateMsg, d.msgIndex, t)
+	}
 	if d.updateMsgBuffer.Len() > MaxUpdateMsgBufferSize {
 		oldest := d.updateMsgBuffer.Front()
 		// Remove the oldest update message if it should has been fanned-out
@@ -197,9 +257,22 @@ func (ch *Channel) tickData(t ChannelTime) {
 			   |------FanOutDelay------|---FanOutInterval---|
 			   subTime                 firstFanOutTime      secondFanOutTime
 		*/
-		nextFanOutTime := foc.lastFanOutTime.AddMs(*cs.options.FanOutIntervalMs)
+		fanOutIntervalMs, priority := ch.getFanOutPriority(conn, cs)
+		nextFanOutTime := foc.lastFanOutTime.AddMs(fanOutIntervalMs)
 		// latestFanoutTime := foc.lastFanOutTime
 		if t >= nextFanOutTime {
+			if foc.hadFirstFanOut && !ch.acquireFanOutBudget(foc, t, nextFanOutTime, fanOutIntervalMs, priority) {
+				// Try again in the next tick
+				foc.postponed = true
+				focp = focp.Next()
+				continue
+			}
+			if foc.postponed {
+				// Catch up with all the updates during the postponement
+				nextFanOutTime = t
+				foc.postponed = false
+			}
+
 			latestFanoutTime := nextFanOutTime
 			var lastUpdateTime ChannelTime
 			bufp := ch.data.updateMsgBuffer.Front()
@@ -213,7 +286,7 @@ func (ch *Channel) tickData(t ChannelTime) {
 			//if foc.lastFanOutTime <= cs.subTime {
 			if !foc.hadFirstFanOut {
 				// Send the whole data for the first time
-				ch.fanOutDataUpdate(conn, cs, ch.data.msg)
+				ch.onFanOut(foc, ch.fanOutDataUpdate(conn, cs, ch.data.msg))
 				foc.hadFirstFanOut = true
 				foc.lastMessageIndex = ch.data.msgIndex
 				latestFanoutTime = t
@@ -260,7 +333,7 @@ func (ch *Channel) tickData(t ChannelTime) {
 				}
 
 				if hasEverMerged {
-					ch.fanOutDataUpdate(conn, cs, ch.data.accumulatedUpdateMsg)
+					ch.onFanOut(foc, ch.fanOutDataUpdate(conn, cs, ch.data.accumulatedUpdateMsg))
 				}
 			}
 			foc.lastFanOutTime = latestFanoutTime
@@ -285,12 +358,13 @@ func (ch *Channel) tickData(t ChannelTime) {
 	}
 }
 
-func (ch *Channel) fanOutDataUpdate(conn ConnectionInChannel, cs *ChannelSubscription, updateMsg common.ChannelDataMessage) {
+// Returns the size of the marshalled channel data.
+func (ch *Channel) fanOutDataUpdate(conn ConnectionInChannel, cs *ChannelSubscription, updateMsg common.ChannelDataMessage) int {
 	fmutils.Filter(updateMsg, cs.options.DataFieldMasks)
 	any, err := anypb.New(updateMsg)
 	if err != nil {
 		ch.Logger().Error("failed to marshal channel update data", zap.Error(err))
-		return
+		return 0
 	}
 	conn.Send(MessageContext{
 		MsgType:    channeldpb.MessageType_CHANNEL_DATA_UPDATE,
@@ -310,6 +384,14 @@ func (ch *Channel) fanOutDataUpdate(conn ConnectionInChannel, cs *ChannelSubscri
 	*/
 	// cs.lastFanOutTime = time.Now()
 	// cs.fanOutDataMsg = nil
+	return len(any.Value)
+}
+
+func (ch *Channel) onFanOut(foc *fanOutConnection, size int) {
+	foc.lastFanOutSize = size
+	if c, ok := foc.conn.(*Connection); ok && c.entityFanOutBudget != nil && ch.channelType == channeldpb.ChannelType_ENTITY {
+		c.entityFanOutBudget.consume(size)
+	}
 }
 
 // Implement this interface to manually merge the channel data. In most cases it can be MUCH more efficient than the default reflection-based merge.
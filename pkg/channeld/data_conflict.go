@@ -0,0 +1,225 @@
+package channeld
+
+import (
+	"bytes"
+
+	"github.com/indiest/fmutils"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The max time (in milliseconds) that the timestamp of a ChannelDataUpdateMessage can be ahead of the time channeld receives it.
+// A timestamp beyond is clamped, so a connection can't win all the LAST_WRITER_WINS conflicts with a timestamp in the future.
+const MaxUpdateTimestampSkewMs = 500
+
+// The last accepted update of a top-level field of the channel data. Used for resolving the conflicts.
+type fieldWriteState struct {
+	connId ConnectionId
+	// Unix timestamp in milliseconds
+	timestamp int64
+	// Has the field ever been updated by the channel owner?
+	byOwner bool
+}
+
+// Returns the timestamp of the update for resolving the conflicts: the time when channeld received the update if the sender didn't set it,
+// or clamped to the receive time plus MaxUpdateTimestampSkewMs.
+func (ch *Channel) updateTimestamp(msgTimestamp int64, arrivalTime ChannelTime) int64 {
+	receiveTime := ch.ChannelTimeToUnixMilli(arrivalTime)
+	if msgTimestamp == 0 {
+		return receiveTime
+	}
+	if msgTimestamp > receiveTime+MaxUpdateTimestampSkewMs {
+		return receiveTime + MaxUpdateTimestampSkewMs
+	}
+	return msgTimestamp
+}
+
+func (d *ChannelData) needsConflictResolution() bool {
+	return d.mergeOptions != nil && d.msg != nil && (d.mergeOptions.ConflictPolicy != channeldpb.ConflictResolutionPolicy_ARRIVAL_ORDER ||
+		len(d.mergeOptions.CounterFields) > 0 || len(d.mergeOptions.SetFields) > 0)
+}
+
+// Resolves the conflicts between the update message and the current channel data, according to the merge options.
+// The rejected fields are cleared from the update message, and the counter and set fields are converted to the values to merge.
+// Returns the names of the rejected fields, and if any counter field is updated.
+// CAUTION: this function is not goroutine-safe. It should be called in the channel's goroutine.
+func (d *ChannelData) resolveConflicts(updateMsg common.ChannelDataMessage, senderConnId ConnectionId, isOwner bool, timestamp int64) (rejectedFields []string, hasCounterUpdate bool) {
+	if !d.needsConflictResolution() {
+		return nil, false
+	}
+
+	dst := d.msg.ProtoReflect()
+	src := updateMsg.ProtoReflect()
+	if dst.Descriptor().FullName() != src.Descriptor().FullName() {
+		return nil, false
+	}
+
+	// Modifying the message while ranging over it is undefined, so collect the fields first.
+	fields := make([]protoreflect.FieldDescriptor, 0, src.Descriptor().Fields().Len())
+	src.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		name := string(fd.Name())
+		if Contains(d.mergeOptions.CounterFields, name) {
+			hasCounterUpdate = resolveCounterField(dst, src, fd) || hasCounterUpdate
+			continue
+		}
+		if Contains(d.mergeOptions.SetFields, name) {
+			resolveSetField(dst, src, fd, d.mergeOptions.ShouldReplaceList)
+			continue
+		}
+		if d.mergeOptions.ConflictPolicy == channeldpb.ConflictResolutionPolicy_ARRIVAL_ORDER {
+			continue
+		}
+
+		if d.fieldWriteStates == nil {
+			d.fieldWriteStates = make(map[protoreflect.FieldNumber]*fieldWriteState)
+		}
+		state, exists := d.fieldWriteStates[fd.Number()]
+		if !exists {
+			state = &fieldWriteState{}
+			d.fieldWriteStates[fd.Number()] = state
+		} else if !state.accepts(d.mergeOptions.ConflictPolicy, isOwner, timestamp) {
+			src.Clear(fd)
+			rejectedFields = append(rejectedFields, name)
+			continue
+		}
+
+		state.connId = senderConnId
+		state.timestamp = timestamp
+		state.byOwner = state.byOwner || isOwner
+	}
+
+	return rejectedFields, hasCounterUpdate
+}
+
+func (state *fieldWriteState) accepts(policy channeldpb.ConflictResolutionPolicy, isOwner bool, timestamp int64) bool {
+	switch policy {
+	case channeldpb.ConflictResolutionPolicy_LAST_WRITER_WINS:
+		return timestamp >= state.timestamp
+	case channeldpb.ConflictResolutionPolicy_OWNER_WINS:
+		return isOwner || !state.byOwner
+	default:
+		return true
+	}
+}
+
+// Converts the delta in the update message to the new value of the counter. Returns false if the field is not a numeric field.
+// The new value is also set to the channel data directly, as the zero value is cleared from the update message
+// if the field has no presence (not declared as optional), so merging the update message wouldn't reset the counter.
+func resolveCounterField(dst protoreflect.Message, src protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if fd.IsList() || fd.IsMap() {
+		return false
+	}
+
+	current := dst.Get(fd)
+	delta := src.Get(fd)
+	var sum protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		sum = protoreflect.ValueOfInt32(int32(current.Int() + delta.Int()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		sum = protoreflect.ValueOfInt64(current.Int() + delta.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		sum = protoreflect.ValueOfUint32(uint32(current.Uint() + delta.Uint()))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		sum = protoreflect.ValueOfUint64(current.Uint() + delta.Uint())
+	case protoreflect.FloatKind:
+		sum = protoreflect.ValueOfFloat32(float32(current.Float() + delta.Float()))
+	case protoreflect.DoubleKind:
+		sum = protoreflect.ValueOfFloat64(current.Float() + delta.Float())
+	default:
+		return false
+	}
+	dst.Set(fd, sum)
+	src.Set(fd, sum)
+	return true
+}
+
+// Removes the elements that already exist in the channel data from the update message.
+// If the list should be replaced when merging, the update message will have the union of both lists instead.
+func resolveSetField(dst protoreflect.Message, src protoreflect.Message, fd protoreflect.FieldDescriptor, shouldReplaceList bool) {
+	if !fd.IsList() {
+		return
+	}
+
+	dstList := dst.Get(fd).List()
+	srcList := src.Get(fd).List()
+	newList := src.NewField(fd).List()
+	if shouldReplaceList {
+		for i := 0; i < dstList.Len(); i++ {
+			newList.Append(dstList.Get(i))
+		}
+	}
+	for i := 0; i < srcList.Len(); i++ {
+		elem := srcList.Get(i)
+		if !listContains(dstList, elem, fd) && !listContains(newList, elem, fd) {
+			newList.Append(elem)
+		}
+	}
+
+	if newList.Len() > 0 {
+		src.Set(fd, protoreflect.ValueOfList(newList))
+	} else {
+		src.Clear(fd)
+	}
+}
+
+func listContains(list protoreflect.List, elem protoreflect.Value, fd protoreflect.FieldDescriptor) bool {
+	for i := 0; i < list.Len(); i++ {
+		v := list.Get(i)
+		switch fd.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			if proto.Equal(v.Message().Interface(), elem.Message().Interface()) {
+				return true
+			}
+		case protoreflect.BytesKind:
+			if bytes.Equal(v.Bytes(), elem.Bytes()) {
+				return true
+			}
+		default:
+			if v.Interface() == elem.Interface() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Sends the current values of the rejected fields to the connection, so it can correct its local state.
+func (ch *Channel) sendUpdateRejected(conn ConnectionInChannel, rejectedFields []string) {
+	currentData := proto.Clone(ch.data.msg)
+	fmutils.Filter(currentData, rejectedFields)
+	anyData, err := anypb.New(currentData)
+	if err != nil {
+		ch.Logger().Error("failed to marshal channel data", zap.Error(err))
+		return
+	}
+
+	conn.Send(MessageContext{
+		MsgType: channeldpb.MessageType_CHANNEL_DATA_UPDATE_REJECTED,
+		Msg: &channeldpb.ChannelDataUpdateRejectedMessage{
+			RejectedFields: rejectedFields,
+			CurrentData:    anyData,
+		},
+		Channel:   ch,
+		ChannelId: uint32(ch.id),
+	})
+}
+
+// Returns true if the message has any field set.
+func hasAnyField(msg common.Message) bool {
+	hasField := false
+	msg.ProtoReflect().Range(func(protoreflect.FieldDescriptor, protoreflect.Value) bool {
+		hasField = true
+		return false
+	})
+	return hasField
+}
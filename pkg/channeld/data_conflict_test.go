@@ -0,0 +1,147 @@
+package channeld
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func sendTestDataUpdate(ch *Channel, conn *Connection, updateMsg proto.Message, timestamp int64) {
+	anyData, _ := anypb.New(updateMsg)
+	handleChannelDataUpdate(MessageContext{
+		MsgType:     channeldpb.MessageType_CHANNEL_DATA_UPDATE,
+		Msg:         &channeldpb.ChannelDataUpdateMessage{Data: anyData, Timestamp: timestamp},
+		Connection:  conn,
+		Channel:     ch,
+		ChannelId:   uint32(ch.id),
+		arrivalTime: ch.GetTime(),
+	})
+}
+
+func createConflictTestChannel(t *testing.T, dataMsg proto.Message, mergeOptions *channeldpb.ChannelDataMergeOptions) (*Channel, *Connection, *Connection) {
+	InitLogs()
+	InitChannels()
+
+	ownerConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+	ch, _ := CreateChannel(channeldpb.ChannelType_TEST, ownerConn)
+	// Stop the channel.Tick() goroutine
+	ch.removing = 1
+	ch.InitData(dataMsg, mergeOptions)
+	_, shouldSend := clientConn.SubscribeToChannel(ch, &channeldpb.ChannelSubscriptionOptions{
+		DataAccess: Pointer(channeldpb.ChannelDataAccess_WRITE_ACCESS),
+	})
+	assert.True(t, shouldSend)
+	return ch, ownerConn, clientConn
+}
+
+func TestConflictLastWriterWins(t *testing.T) {
+	ch, ownerConn, clientConn := createConflictTestChannel(t, &testpb.TestChannelDataMessage{Text: "a", Num: 1},
+		&channeldpb.ChannelDataMergeOptions{ConflictPolicy: channeldpb.ConflictResolutionPolicy_LAST_WRITER_WINS})
+
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestChannelDataMessage{Text: "b"}, 2000)
+	assert.EqualValues(t, "b", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+
+	// Older update of the text is rejected, while the num is accepted
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Text: "c", Num: 2}, 1000)
+	dataMsg := ch.GetDataMessage().(*testpb.TestChannelDataMessage)
+	assert.EqualValues(t, "b", dataMsg.Text)
+	assert.EqualValues(t, 2, dataMsg.Num)
+
+	rejectedMsg, ok := clientConn.latestMsg().(*channeldpb.ChannelDataUpdateRejectedMessage)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"text"}, rejectedMsg.RejectedFields)
+	currentData, err := rejectedMsg.CurrentData.UnmarshalNew()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "b"}, currentData))
+
+	// Newer update is accepted
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Text: "d"}, 3000)
+	assert.EqualValues(t, "d", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+	assert.Nil(t, ownerConn.latestMsg())
+
+	// The timestamp far in the future is clamped, so it doesn't win the later updates
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Text: "e"}, time.Now().Add(time.Hour).UnixMilli())
+	assert.EqualValues(t, "e", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestChannelDataMessage{Text: "f"}, time.Now().UnixMilli()+MaxUpdateTimestampSkewMs+10)
+	assert.EqualValues(t, "f", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+	assert.Nil(t, ownerConn.latestMsg())
+}
+
+func TestConflictOwnerWins(t *testing.T) {
+	ch, ownerConn, clientConn := createConflictTestChannel(t, &testpb.TestChannelDataMessage{Text: "a", Num: 1},
+		&channeldpb.ChannelDataMergeOptions{ConflictPolicy: channeldpb.ConflictResolutionPolicy_OWNER_WINS})
+
+	// The field hasn't been updated by the owner yet
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Text: "b"}, 0)
+	assert.EqualValues(t, "b", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestChannelDataMessage{Text: "c"}, 0)
+	assert.EqualValues(t, "c", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+
+	// The whole update is rejected, so nothing is merged
+	msgIndex := ch.Data().msgIndex
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Text: "d"}, 0)
+	assert.EqualValues(t, "c", ch.GetDataMessage().(*testpb.TestChannelDataMessage).Text)
+	assert.Equal(t, msgIndex, ch.Data().msgIndex)
+	assert.IsType(t, &channeldpb.ChannelDataUpdateRejectedMessage{}, clientConn.latestMsg())
+}
+
+func TestConflictCRDTFields(t *testing.T) {
+	ch, ownerConn, clientConn := createConflictTestChannel(t, &testpb.TestChannelDataMessage{Text: "a", Num: 1},
+		&channeldpb.ChannelDataMergeOptions{
+			ConflictPolicy: channeldpb.ConflictResolutionPolicy_OWNER_WINS,
+			CounterFields:  []string{"num"},
+		})
+
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestChannelDataMessage{Num: 2}, 0)
+	// The conflict policy doesn't apply to the counter fields
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Num: 3}, 0)
+	assert.EqualValues(t, 6, ch.GetDataMessage().(*testpb.TestChannelDataMessage).Num)
+	assert.Nil(t, clientConn.latestMsg())
+	// The counter reaches zero. The unsigned delta wraps around, so it's a decrement by 6.
+	sendTestDataUpdate(ch, clientConn, &testpb.TestChannelDataMessage{Num: math.MaxUint32 - 5}, 0)
+	assert.EqualValues(t, 0, ch.GetDataMessage().(*testpb.TestChannelDataMessage).Num)
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestChannelDataMessage{Num: 1}, 0)
+	assert.EqualValues(t, 1, ch.GetDataMessage().(*testpb.TestChannelDataMessage).Num)
+
+	// The counters that reach zero are still updated and fanned out
+	ch, ownerConn, clientConn = createConflictTestChannel(t, &testpb.TestCounterMessage{Count: proto.Int64(2), Total: 2},
+		&channeldpb.ChannelDataMergeOptions{CounterFields: []string{"count", "total"}})
+
+	msgIndex := ch.Data().msgIndex
+	sendTestDataUpdate(ch, clientConn, &testpb.TestCounterMessage{Count: proto.Int64(-2), Total: -2}, 0)
+	counterMsg := ch.GetDataMessage().(*testpb.TestCounterMessage)
+	assert.EqualValues(t, 0, counterMsg.GetCount())
+	assert.EqualValues(t, 0, counterMsg.Total)
+	assert.Equal(t, msgIndex+1, ch.Data().msgIndex)
+	// The optional counter keeps the zero value in the update message to fan out
+	fanOutMsg := ch.Data().updateMsgBuffer.Back().Value.(*updateMsgBufferElement).updateMsg.(*testpb.TestCounterMessage)
+	if assert.NotNil(t, fanOutMsg.Count) {
+		assert.EqualValues(t, 0, *fanOutMsg.Count)
+	}
+
+	// The non-optional counter reaches zero alone
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestCounterMessage{Total: 1}, 0)
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestCounterMessage{Total: -1}, 0)
+	assert.EqualValues(t, 0, ch.GetDataMessage().(*testpb.TestCounterMessage).Total)
+	assert.Equal(t, msgIndex+3, ch.Data().msgIndex)
+
+	ch, ownerConn, clientConn = createConflictTestChannel(t, &testpb.TestMergeMessage{List: []string{"a"}},
+		&channeldpb.ChannelDataMergeOptions{SetFields: []string{"list"}})
+
+	sendTestDataUpdate(ch, ownerConn, &testpb.TestMergeMessage{List: []string{"a", "b"}}, 0)
+	sendTestDataUpdate(ch, clientConn, &testpb.TestMergeMessage{List: []string{"b", "c", "c"}}, 0)
+	assert.Equal(t, []string{"a", "b", "c"}, ch.GetDataMessage().(*testpb.TestMergeMessage).List)
+
+	// Nothing new to add
+	msgIndex = ch.Data().msgIndex
+	sendTestDataUpdate(ch, clientConn, &testpb.TestMergeMessage{List: []string{"a"}}, 0)
+	assert.Equal(t, msgIndex, ch.Data().msgIndex)
+}
@@ -0,0 +1,145 @@
+package channeld
+
+import (
+	"errors"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrDataHistoryDisabled = errors.New("channel data history is not enabled for the channel type")
+var ErrDataHistoryOutOfRange = errors.New("the requested state is out of the range of the channel data history")
+
+type dataHistoryEntry struct {
+	msgIndex    uint64
+	arrivalTime ChannelTime
+	// The update message that is merged into the channel data. Not set if the entry has the snapshot.
+	updateMsg common.ChannelDataMessage
+	// The full state of the channel data after the update. Only set periodically (see ChannelSettingsType.DataHistorySnapshotIntervalMs).
+	snapshot common.ChannelDataMessage
+}
+
+// channelDataHistory is a ring buffer of the channel data updates, with the full snapshots taken periodically.
+// A past state is restored by merging the updates after the nearest snapshot.
+type channelDataHistory struct {
+	entries          []*dataHistoryEntry
+	head             int
+	count            int
+	snapshotInterval ChannelTime
+	lastSnapshotTime ChannelTime
+	// How many entries have been added since the last snapshot. Used to make sure there's always a snapshot in the ring buffer.
+	entriesSinceSnapshot int
+}
+
+func newChannelDataHistory(size int, snapshotIntervalMs uint32) *channelDataHistory {
+	return &channelDataHistory{
+		entries:          make([]*dataHistoryEntry, size),
+		snapshotInterval: ChannelTime(time.Duration(snapshotIntervalMs) * time.Millisecond),
+	}
+}
+
+// Returns the entry at the position from the oldest one.
+func (h *channelDataHistory) at(pos int) *dataHistoryEntry {
+	return h.entries[(h.head+pos)%len(h.entries)]
+}
+
+func (h *channelDataHistory) add(e *dataHistoryEntry) {
+	if h.count < len(h.entries) {
+		h.entries[(h.head+h.count)%len(h.entries)] = e
+		h.count++
+	} else {
+		// Overwrite the oldest entry
+		h.entries[h.head] = e
+		h.head = (h.head + 1) % len(h.entries)
+	}
+}
+
+// Records the update message that has just been merged into the channel data message.
+// If updateMsg is nil, the data message is recorded as the initial state.
+func (h *channelDataHistory) record(dataMsg common.ChannelDataMessage, updateMsg common.ChannelDataMessage, msgIndex uint64, t ChannelTime) {
+	e := &dataHistoryEntry{
+		msgIndex:    msgIndex,
+		arrivalTime: t,
+	}
+
+	// Take the snapshot every half of the ring buffer at least, otherwise the states after the only snapshot can't be restored once it's overwritten.
+	if updateMsg == nil || h.count == 0 || t >= h.lastSnapshotTime+h.snapshotInterval || h.entriesSinceSnapshot >= len(h.entries)/2 {
+		e.snapshot = proto.Clone(dataMsg)
+		h.lastSnapshotTime = t
+		h.entriesSinceSnapshot = 0
+	} else {
+		e.updateMsg = updateMsg
+		h.entriesSinceSnapshot++
+	}
+
+	h.add(e)
+}
+
+// Returns the position of the latest entry that matches the condition. Returns -1 if not found.
+func (h *channelDataHistory) findLatest(match func(e *dataHistoryEntry) bool) int {
+	for pos := h.count - 1; pos >= 0; pos-- {
+		if match(h.at(pos)) {
+			return pos
+		}
+	}
+	return -1
+}
+
+// Restores the state of the channel data at the entry of the position.
+func (h *channelDataHistory) restore(pos int, mergeOptions *channeldpb.ChannelDataMergeOptions) (common.ChannelDataMessage, *dataHistoryEntry, error) {
+	snapshotPos := pos
+	for ; snapshotPos >= 0; snapshotPos-- {
+		if h.at(snapshotPos).snapshot != nil {
+			break
+		}
+	}
+	if snapshotPos < 0 {
+		return nil, nil, ErrDataHistoryOutOfRange
+	}
+
+	dataMsg := proto.Clone(h.at(snapshotPos).snapshot)
+	for i := snapshotPos + 1; i <= pos; i++ {
+		mergeWithOptions(dataMsg, h.at(i).updateMsg, mergeOptions, nil)
+	}
+	return dataMsg, h.at(pos), nil
+}
+
+// Returns a copy of the channel data message that includes the update of the msgIndex, as well as the index and arrival time of the latest update it includes.
+// CAUTION: this function is not goroutine-safe. It should be called in the channel's goroutine.
+func (d *ChannelData) GetHistoryByIndex(msgIndex uint64) (common.ChannelDataMessage, uint64, ChannelTime, error) {
+	if d.history == nil {
+		return nil, 0, 0, ErrDataHistoryDisabled
+	}
+
+	pos := d.history.findLatest(func(e *dataHistoryEntry) bool {
+		return e.msgIndex <= msgIndex
+	})
+	return d.restoreHistory(pos)
+}
+
+func (d *ChannelData) restoreHistory(pos int) (common.ChannelDataMessage, uint64, ChannelTime, error) {
+	if pos < 0 {
+		return nil, 0, 0, ErrDataHistoryOutOfRange
+	}
+
+	dataMsg, e, err := d.history.restore(pos, d.mergeOptions)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return dataMsg, e.msgIndex, e.arrivalTime, nil
+}
+
+// Returns a copy of the channel data message at the channel time, as well as the index and arrival time of the latest update it includes.
+// CAUTION: this function is not goroutine-safe. It should be called in the channel's goroutine.
+func (d *ChannelData) GetHistoryByTime(t ChannelTime) (common.ChannelDataMessage, uint64, ChannelTime, error) {
+	if d.history == nil {
+		return nil, 0, 0, ErrDataHistoryDisabled
+	}
+
+	pos := d.history.findLatest(func(e *dataHistoryEntry) bool {
+		return e.arrivalTime <= t
+	})
+	return d.restoreHistory(pos)
+}
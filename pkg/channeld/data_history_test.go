@@ -0,0 +1,156 @@
+package channeld
+
+import (
+	"container/list"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestChannelDataHistory(t *testing.T) {
+	InitLogs()
+
+	d := &ChannelData{
+		msg:             &testpb.TestChannelDataMessage{Text: "a", Num: 0},
+		updateMsgBuffer: list.New(),
+		history:         newChannelDataHistory(4, 1000),
+	}
+	_, _, _, err := d.GetHistoryByIndex(0)
+	assert.ErrorIs(t, err, ErrDataHistoryOutOfRange)
+
+	d.history.record(d.msg, nil, d.msgIndex, 0)
+	for i := 1; i <= 3; i++ {
+		d.OnUpdate(&testpb.TestChannelDataMessage{Num: uint32(i)}, ChannelTime(i)*ChannelTime(time.Millisecond), 0, nil)
+	}
+
+	// Only the initial state is the snapshot, the states after are restored by merging the updates
+	dataMsg, msgIndex, arrivalTime, err := d.GetHistoryByIndex(2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, msgIndex)
+	assert.EqualValues(t, 2*time.Millisecond, arrivalTime)
+	assert.EqualValues(t, "a", dataMsg.(*testpb.TestChannelDataMessage).Text)
+	assert.EqualValues(t, 2, dataMsg.(*testpb.TestChannelDataMessage).Num)
+
+	dataMsg, msgIndex, _, err = d.GetHistoryByTime(ChannelTime(1500 * time.Microsecond))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, msgIndex)
+	assert.EqualValues(t, 1, dataMsg.(*testpb.TestChannelDataMessage).Num)
+
+	// The restored state should not affect the current state
+	dataMsg.(*testpb.TestChannelDataMessage).Num = 100
+	assert.EqualValues(t, 3, d.msg.(*testpb.TestChannelDataMessage).Num)
+
+	// Overwrite the oldest entries. A snapshot should have been taken before the initial state is overwritten.
+	for i := 4; i <= 6; i++ {
+		d.OnUpdate(&testpb.TestChannelDataMessage{Num: uint32(i)}, ChannelTime(i)*ChannelTime(time.Millisecond), 0, nil)
+	}
+	_, _, _, err = d.GetHistoryByIndex(2)
+	assert.ErrorIs(t, err, ErrDataHistoryOutOfRange)
+
+	dataMsg, msgIndex, _, err = d.GetHistoryByIndex(5)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, msgIndex)
+	assert.EqualValues(t, "a", dataMsg.(*testpb.TestChannelDataMessage).Text)
+	assert.EqualValues(t, 5, dataMsg.(*testpb.TestChannelDataMessage).Num)
+
+	d.history = nil
+	_, _, _, err = d.GetHistoryByTime(0)
+	assert.ErrorIs(t, err, ErrDataHistoryDisabled)
+}
+
+func TestHandleGetChannelData(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	oldSettings, exists := GlobalSettings.ChannelSettings[channeldpb.ChannelType_TEST]
+	defer func() {
+		if exists {
+			GlobalSettings.ChannelSettings[channeldpb.ChannelType_TEST] = oldSettings
+		} else {
+			delete(GlobalSettings.ChannelSettings, channeldpb.ChannelType_TEST)
+		}
+	}()
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_TEST] = ChannelSettingsType{
+		TickIntervalMs:                10,
+		DefaultFanOutIntervalMs:       20,
+		DataHistorySize:               10,
+		DataHistorySnapshotIntervalMs: 1000,
+	}
+
+	ownerConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+	ch := createChannelWithId(1000, channeldpb.ChannelType_TEST, ownerConn)
+	// Stop the channel.Tick() goroutine
+	ch.removing = 1
+	ch.InitData(&testpb.TestChannelDataMessage{Text: "a", Num: 0}, nil)
+	ch.Data().OnUpdate(&testpb.TestChannelDataMessage{Num: 1}, ch.GetTime(), ownerConn.Id(), nil)
+	ch.Data().OnUpdate(&testpb.TestChannelDataMessage{Text: "b"}, ch.GetTime(), ownerConn.Id(), nil)
+
+	ctx := MessageContext{
+		MsgType:    channeldpb.MessageType_GET_CHANNEL_DATA,
+		Msg:        &channeldpb.GetChannelDataMessage{},
+		Connection: clientConn,
+		Channel:    ch,
+		ChannelId:  uint32(ch.id),
+	}
+
+	// Non-owner connection has no access
+	handleGetChannelData(ctx)
+	result, ok := clientConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	assert.True(t, ok)
+	assert.EqualValues(t, ch.id, result.ChannelId)
+	assert.Nil(t, result.Data)
+
+	// The current state
+	ctx.Connection = ownerConn
+	handleGetChannelData(ctx)
+	result, ok = ownerConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	assert.True(t, ok)
+	assert.EqualValues(t, ch.id, result.ChannelId)
+	assert.EqualValues(t, 2, result.MsgIndex)
+	dataMsg, err := result.Data.UnmarshalNew()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "b", Num: 1}, dataMsg))
+
+	// The state by the message index
+	ctx.Msg = &channeldpb.GetChannelDataMessage{MsgIndex: proto.Uint64(1)}
+	handleGetChannelData(ctx)
+	result = ownerConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	assert.EqualValues(t, 1, result.MsgIndex)
+	dataMsg, _ = result.Data.UnmarshalNew()
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "a", Num: 1}, dataMsg))
+
+	// The state by the timestamp
+	ctx.Msg = &channeldpb.GetChannelDataMessage{Timestamp: proto.Int64(time.Now().Add(time.Millisecond).UnixMilli())}
+	handleGetChannelData(ctx)
+	result = ownerConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	assert.EqualValues(t, 2, result.MsgIndex)
+	dataMsg, _ = result.Data.UnmarshalNew()
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "b", Num: 1}, dataMsg))
+
+	// Before the channel was created
+	ctx.Msg = &channeldpb.GetChannelDataMessage{Timestamp: proto.Int64(ch.startTime.Add(-time.Second).UnixMilli())}
+	handleGetChannelData(ctx)
+	result = ownerConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	assert.EqualValues(t, ch.id, result.ChannelId)
+	assert.Nil(t, result.Data)
+
+	// Non-owner connection that has the access to sub to the channel can get the data, the same as FETCH_CHANNEL_DATA
+	channelSettings := GlobalSettings.ChannelSettings[channeldpb.ChannelType_TEST]
+	channelSettings.ACLSettings.Sub = ChannelAccessLevel_Any
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_TEST] = channelSettings
+	ctx.Connection = clientConn
+	ctx.Msg = &channeldpb.GetChannelDataMessage{}
+	handleGetChannelData(ctx)
+	result = clientConn.latestMsg().(*channeldpb.GetChannelDataResultMessage)
+	dataMsg, err = result.Data.UnmarshalNew()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "b", Num: 1}, dataMsg))
+	fetchedData, err := ch.FetchData(clientConn, nil)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(result.Data, fetchedData))
+}
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"net"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +22,8 @@ import (
 
 type testQueuedMessageSender struct {
 	MessageSender
+	// The messages can be sent from any channel's goroutine
+	lock         sync.Mutex
 	msgQueue     []common.Message
 	msgProcessor func(common.Message) (common.Message, error)
 }
@@ -33,7 +36,9 @@ func (s *testQueuedMessageSender) Send(c *Connection, ctx MessageContext) {
 			panic(err)
 		}
 	}
+	s.lock.Lock()
 	s.msgQueue = append(s.msgQueue, ctx.Msg)
+	s.lock.Unlock()
 }
 
 func addTestConnection(t channeldpb.ConnectionType) *Connection {
@@ -48,7 +53,10 @@ func addTestConnectionWithProcessor(t channeldpb.ConnectionType, p func(common.M
 }
 
 func (c *Connection) testQueue() []common.Message {
-	return c.sender.(*testQueuedMessageSender).msgQueue
+	s := c.sender.(*testQueuedMessageSender)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.msgQueue
 }
 
 func (c *Connection) latestMsg() common.Message {
@@ -453,3 +461,77 @@ func TestProtobufMapMerge(t *testing.T) {
 	*/
 	assert.Equal(t, "", testMsg.Kv2[2].Content)
 }
+
+func TestFetchChannelData(t *testing.T) {
+	isolateChannelTest(t)
+	setChannelACLSettings([]channeldpb.ChannelType{channeldpb.ChannelType_TEST}, ChannelAccessLevel_Any)
+	setChannelACLSettings([]channeldpb.ChannelType{channeldpb.ChannelType_PRIVATE}, ChannelAccessLevel_OwnerAndGlobalOwner)
+
+	serverConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+
+	testChannel, _ := CreateChannel(channeldpb.ChannelType_TEST, serverConn)
+	testChannel.InitData(&testpb.TestChannelDataMessage{Text: "a", Num: 1}, nil)
+	privateChannel, _ := CreateChannel(channeldpb.ChannelType_PRIVATE, serverConn)
+	privateChannel.InitData(&testpb.TestChannelDataMessage{Text: "b", Num: 2}, nil)
+	noDataChannel, _ := CreateChannel(channeldpb.ChannelType_TEST, serverConn)
+
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_FETCH_CHANNEL_DATA,
+		Msg: &channeldpb.FetchChannelDataMessage{
+			ChannelIds:     []uint32{uint32(testChannel.id), uint32(privateChannel.id), uint32(noDataChannel.id), 12345},
+			DataFieldMasks: []string{"text"},
+		},
+		Connection: clientConn,
+		Channel:    globalChannel,
+		ChannelId:  uint32(globalChannel.id),
+	}
+
+	// The client can only fetch the TEST channel's data
+	handleFetchChannelData(ctx)
+	assert.Eventually(t, func() bool { return clientConn.latestMsg() != nil }, time.Second, 10*time.Millisecond)
+	result := clientConn.latestMsg().(*channeldpb.FetchChannelDataResultMessage)
+	assert.Equal(t, 1, len(result.Results))
+	assert.EqualValues(t, testChannel.id, result.Results[0].ChannelId)
+	dataMsg, err := result.Results[0].Data.UnmarshalNew()
+	assert.NoError(t, err)
+	// The field masks are applied
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "a"}, dataMsg))
+	// The channel data itself is not filtered
+	assert.EqualValues(t, 1, testChannel.GetDataMessage().(*testpb.TestChannelDataMessage).Num)
+	assert.ElementsMatch(t, []uint32{uint32(privateChannel.id), uint32(noDataChannel.id), 12345}, result.FailedChannelIds)
+
+	// The channel owner can fetch the PRIVATE channel's data
+	ctx.Connection = serverConn
+	ctx.Msg = &channeldpb.FetchChannelDataMessage{
+		ChannelIds: []uint32{uint32(privateChannel.id)},
+	}
+	handleFetchChannelData(ctx)
+	assert.Eventually(t, func() bool { return serverConn.latestMsg() != nil }, time.Second, 10*time.Millisecond)
+	result = serverConn.latestMsg().(*channeldpb.FetchChannelDataResultMessage)
+	assert.Equal(t, 1, len(result.Results))
+	assert.Empty(t, result.FailedChannelIds)
+	dataMsg, _ = result.Results[0].Data.UnmarshalNew()
+	assert.True(t, proto.Equal(&testpb.TestChannelDataMessage{Text: "b", Num: 2}, dataMsg))
+
+	// The channel which never runs the fetch (e.g. removed before the fetch runs) is counted as not found after the timeout
+	stalledChannel := &Channel{id: 54321, inMsgQueue: make(chan channelMessage, 1)}
+	allChannels.Store(stalledChannel.id, stalledChannel)
+	defer allChannels.Delete(stalledChannel.id)
+	ctx.Msg = &channeldpb.FetchChannelDataMessage{
+		ChannelIds: []uint32{uint32(privateChannel.id), uint32(stalledChannel.id)},
+	}
+	handleFetchChannelData(ctx)
+	assert.Eventually(t, func() bool { return serverConn.latestMsg() != result }, fetchChannelDataTimeout*2, 10*time.Millisecond)
+	result = serverConn.latestMsg().(*channeldpb.FetchChannelDataResultMessage)
+	assert.Equal(t, 1, len(result.Results))
+	assert.Equal(t, []uint32{uint32(stalledChannel.id)}, result.FailedChannelIds)
+
+	// Too many channels to fetch
+	ctx.Msg = &channeldpb.FetchChannelDataMessage{
+		ChannelIds: make([]uint32, MaxFetchChannelIds+1),
+	}
+	handleFetchChannelData(ctx)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, result, serverConn.latestMsg())
+}
@@ -11,8 +11,15 @@ import (
 var unauthenticatedConnections sync.Map
 
 var failedAuthCounters = make(map[string]int)
-var ipBlacklist = make(map[string]time.Time)
-var pitBlacklist = make(map[string]time.Time)
+var failedAuthCountersLock sync.Mutex
+
+// Increases the failed auth counter of the PIT or IP, and returns the new value.
+func addFailedAuth(key string) int {
+	failedAuthCountersLock.Lock()
+	defer failedAuthCountersLock.Unlock()
+	failedAuthCounters[key]++
+	return failedAuthCounters[key]
+}
 
 func InitAntiDDoS() {
 	Event_AuthComplete.Listen(func(data AuthEventData) {
@@ -22,10 +29,9 @@ func InitAntiDDoS() {
 
 		if data.AuthResult == channeldpb.AuthResultMessage_INVALID_LT {
 			// Invalid access token - record the PIT
-			failedAuthCounters[data.PlayerIdentifierToken]++
-			if GlobalSettings.MaxFailedAuthAttempts > 0 && failedAuthCounters[data.PlayerIdentifierToken] >= GlobalSettings.MaxFailedAuthAttempts {
-				pitBlacklist[data.PlayerIdentifierToken] = time.Now()
-				securityLogger.Info("blacklisted PIT due to too many failed auth attempts", zap.String("pit", data.PlayerIdentifierToken))
+			failed := addFailedAuth(data.PlayerIdentifierToken)
+			if GlobalSettings.MaxFailedAuthAttempts > 0 && failed >= GlobalSettings.MaxFailedAuthAttempts {
+				banPIT(data.PlayerIdentifierToken, "too many failed auth attempts")
 				data.Connection.Close()
 			}
 		} else if data.AuthResult == channeldpb.AuthResultMessage_INVALID_PIT {
@@ -35,10 +41,9 @@ func InitAntiDDoS() {
 				return
 			}
 			ip := GetIP(addr)
-			failedAuthCounters[ip]++
-			if GlobalSettings.MaxFailedAuthAttempts > 0 && failedAuthCounters[ip] >= GlobalSettings.MaxFailedAuthAttempts {
-				ipBlacklist[ip] = time.Now()
-				securityLogger.Info("blacklisted IP due to too many failed auth attempts", zap.String("ip", ip))
+			failed := addFailedAuth(ip)
+			if GlobalSettings.MaxFailedAuthAttempts > 0 && failed >= GlobalSettings.MaxFailedAuthAttempts {
+				banIP(ip, "too many failed auth attempts")
 				data.Connection.Close()
 			}
 		}
@@ -52,8 +57,7 @@ func InitAntiDDoS() {
 
 		c.fsmDisallowedCounter++
 		if GlobalSettings.MaxFsmDisallowed > 0 && c.fsmDisallowedCounter >= GlobalSettings.MaxFsmDisallowed {
-			pitBlacklist[c.pit] = time.Now()
-			securityLogger.Info("blacklisted PIT due to too many FSM disallowed", zap.String("pit", c.pit))
+			banPIT(c.pit, "too many FSM disallowed")
 			c.Close()
 		}
 	})
@@ -61,6 +65,24 @@ func InitAntiDDoS() {
 	go checkUnauthConns()
 }
 
+func banIP(ip string, reason string) {
+	entry, err := bans.Ban(BanType_IP, ip, 0, reason)
+	if err != nil {
+		securityLogger.Error("failed to ban IP", zap.String("ip", ip), zap.Error(err))
+		return
+	}
+	securityLogger.Info("banned IP due to "+reason, zap.String("ip", ip), zap.Int("offenses", entry.Offenses), zap.Time("expiresAt", entry.ExpiresAt))
+}
+
+func banPIT(pit string, reason string) {
+	entry, err := bans.Ban(BanType_PIT, pit, 0, reason)
+	if err != nil {
+		securityLogger.Error("failed to ban PIT", zap.String("pit", pit), zap.Error(err))
+		return
+	}
+	securityLogger.Info("banned PIT due to "+reason, zap.String("pit", pit), zap.Int("offenses", entry.Offenses), zap.Time("expiresAt", entry.ExpiresAt))
+}
+
 // Disconnection unauthenticated connections after ConnectionAuthTimeoutMs.
 func checkUnauthConns() {
 	for {
@@ -70,7 +92,7 @@ func checkUnauthConns() {
 				return true
 			}
 			if conn.state == ConnectionState_UNAUTHENTICATED && time.Since(conn.connTime).Milliseconds() >= GlobalSettings.ConnectionAuthTimeoutMs {
-				ipBlacklist[GetIP(conn.RemoteAddr())] = time.Now()
+				banIP(GetIP(conn.RemoteAddr()), "unauthenticated timeout")
 				conn.Close()
 				securityLogger.Info("closed and blacklisted unauthenticated connection due to timeout", zap.String("ip", conn.conn.RemoteAddr().String()))
 			}
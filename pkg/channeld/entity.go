@@ -53,6 +53,40 @@ type EntityGroupController interface {
 	AddToGroup(t channeldpb.EntityGroupType, entitiesToAdd []EntityId) error
 	RemoveFromGroup(t channeldpb.EntityGroupType, entitiesToRemove []EntityId) error
 	GetHandoverEntities() []EntityId
+	// Returns the entities that lock the handover of the entity. Empty = the handover is not locked.
+	GetLockedEntities() []EntityId
+}
+
+// Creates an EntityGroupController instance for an entity channel.
+type EntityGroupControllerFactory func() EntityGroupController
+
+var entityGroupControllerTypeRegistry = map[string]EntityGroupControllerFactory{
+	"FlatEntityGroupController":         func() EntityGroupController { return &FlatEntityGroupController{} },
+	"HierarchicalEntityGroupController": func() EntityGroupController { return &HierarchicalEntityGroupController{} },
+}
+
+// The type that is used when 'EntityGroupControllerType' is not set in the channel settings
+const DefaultEntityGroupControllerType = "FlatEntityGroupController"
+
+// Registers a custom EntityGroupController type, so it can be selected by 'EntityGroupControllerType' in the channel settings.
+// Should be called before InitChannels(). Registering an existing name replaces the previous factory.
+func RegisterEntityGroupControllerType(name string, factory EntityGroupControllerFactory) {
+	if _, exists := entityGroupControllerTypeRegistry[name]; exists && rootLogger != nil {
+		rootLogger.Warn("entity group controller type already exists, will be replaced", zap.String("name", name))
+	}
+	entityGroupControllerTypeRegistry[name] = factory
+}
+
+// Creates an EntityGroupController of the registered type. An empty typeName selects DefaultEntityGroupControllerType.
+func CreateEntityGroupController(typeName string) (EntityGroupController, error) {
+	if typeName == "" {
+		typeName = DefaultEntityGroupControllerType
+	}
+	factory, exists := entityGroupControllerTypeRegistry[typeName]
+	if !exists {
+		return nil, fmt.Errorf("unknown entity group controller type: %s", typeName)
+	}
+	return factory(), nil
 }
 
 // FlatEntityGroupController is a simple implementation of EntityGroupController which has
@@ -170,7 +204,9 @@ func (ctl *FlatEntityGroupController) RemoveFromGroup(t channeldpb.EntityGroupTy
 				// Reset the removed entity's entity channel's handover group
 				entityCh := GetChannel(common.ChannelId(entityId))
 				if entityCh != nil {
-					entityCh.entityController.(*FlatEntityGroupController).handoverGroup = newEntityGroup()
+					if flatCtl, ok := entityCh.entityController.(*FlatEntityGroupController); ok {
+						flatCtl.handoverGroup = newEntityGroup()
+					}
 				}
 			}
 		} else {
@@ -183,7 +219,9 @@ func (ctl *FlatEntityGroupController) RemoveFromGroup(t channeldpb.EntityGroupTy
 				// Reset the removed entity's entity channel's lock group
 				entityCh := GetChannel(common.ChannelId(entityId))
 				if entityCh != nil {
-					entityCh.entityController.(*FlatEntityGroupController).lockGroup = newEntityGroup()
+					if flatCtl, ok := entityCh.entityController.(*FlatEntityGroupController); ok {
+						flatCtl.lockGroup = newEntityGroup()
+					}
 				}
 			}
 		} else {
@@ -195,14 +233,19 @@ func (ctl *FlatEntityGroupController) RemoveFromGroup(t channeldpb.EntityGroupTy
 }
 
 func (ctl *FlatEntityGroupController) GetHandoverEntities() []EntityId {
-	// If AddToGroup is never called, return the entity itself
+	ctl.rwLock.RLock()
+	defer ctl.rwLock.RUnlock()
+
+	// If AddToGroup(HANDOVER) is never called, return the entity itself
 	if ctl.handoverGroup == nil {
+		if ctl.lockGroup != nil {
+			if _, locked := ctl.lockGroup.entityIds.Load(ctl.entityId); locked {
+				return []EntityId{}
+			}
+		}
 		return []EntityId{ctl.entityId}
 	}
 
-	ctl.rwLock.RLock()
-	defer ctl.rwLock.RUnlock()
-
 	arr := make([]EntityId, 0, ctl.handoverGroup.entityIds.Size())
 	locked := false
 	ctl.handoverGroup.entityIds.Range(func(key EntityId, _ interface{}) bool {
@@ -223,6 +266,31 @@ func (ctl *FlatEntityGroupController) GetHandoverEntities() []EntityId {
 	return arr
 }
 
+func (ctl *FlatEntityGroupController) GetLockedEntities() []EntityId {
+	ctl.rwLock.RLock()
+	defer ctl.rwLock.RUnlock()
+
+	if ctl.lockGroup == nil {
+		return nil
+	}
+
+	if ctl.handoverGroup == nil {
+		if _, locked := ctl.lockGroup.entityIds.Load(ctl.entityId); locked {
+			return []EntityId{ctl.entityId}
+		}
+		return nil
+	}
+
+	var arr []EntityId
+	ctl.handoverGroup.entityIds.Range(func(key EntityId, _ interface{}) bool {
+		if _, locked := ctl.lockGroup.entityIds.Load(key); locked {
+			arr = append(arr, key)
+		}
+		return true
+	})
+	return arr
+}
+
 func (ch *Channel) GetHandoverEntities(notifyingEntityId EntityId) map[EntityId]common.Message {
 	if ch.entityController == nil {
 		ch.Logger().Error("channel doesn't have the entity controller")
@@ -291,4 +359,9 @@ func handleRemoveEntityGroup(ctx MessageContext) {
 			zap.Uint32s("entitiesToRemove", removeMsg.EntitiesToRemove),
 		)
 	}
+
+	// The deferred handovers of the unlocked entities can happen now
+	if removeMsg.Type == channeldpb.EntityGroupType_LOCK {
+		retryPendingHandovers()
+	}
 }
@@ -0,0 +1,126 @@
+package channeld
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+)
+
+/* Entity dormancy
+ * Most entity channels (e.g. trees, doors and parked vehicles) rarely change. A dormant entity channel skips tickData(), and its goroutine
+ * sleeps until a message arrives instead of ticking every TickIntervalMs. It still ticks every dormantTickInterval to clean up
+ * the disconnected subscribers.
+ * The entity channel becomes dormant when its owner sends SetEntityDormancyMessage, or automatically after EntityDormancyTimeoutMs
+ * (in the ENTITY channel settings) without any data update. It wakes up on the next data update, or SetEntityDormancyMessage{dormant: false}.
+ * The pending fan-outs, including the first fan-out to the new subscribers, are still sent while the channel is dormant.
+ */
+
+// How often the dormant channel ticks if no message arrives.
+const dormantTickInterval = time.Second
+
+func (ch *Channel) IsDormant() bool {
+	return atomic.LoadInt32(&ch.dormant) > 0
+}
+
+// Should be called in the channel's goroutine.
+func (ch *Channel) setDormant() {
+	if ch.IsRemoving() || !atomic.CompareAndSwapInt32(&ch.dormant, 0, 1) {
+		return
+	}
+	dormantChannelNum.Inc()
+	ch.Logger().Debug("entity channel becomes dormant")
+}
+
+// Should be called in the channel's goroutine.
+func (ch *Channel) wake() {
+	ch.lastActiveTime = time.Now()
+	if !atomic.CompareAndSwapInt32(&ch.dormant, 1, 0) {
+		return
+	}
+	dormantChannelNum.Dec()
+	ch.Logger().Debug("entity channel wakes up")
+}
+
+// Returns true if any subscriber is waiting for the first fan-out, or hasn't received the latest update. Should be called with the subLock held.
+func (ch *Channel) hasPendingFanOut() bool {
+	if ch.data == nil || ch.data.msg == nil {
+		return false
+	}
+	var lastUpdateTime ChannelTime
+	if be := ch.data.updateMsgBuffer.Back(); be != nil {
+		lastUpdateTime = be.Value.(*updateMsgBufferElement).arrivalTime
+	}
+	for e := ch.fanOutQueue.Front(); e != nil; e = e.Next() {
+		foc := e.Value.(*fanOutConnection)
+		if foc.conn == nil || foc.conn.IsClosing() {
+			continue
+		}
+		cs := ch.subscribedConnections[foc.conn]
+		if cs == nil || *cs.options.DataAccess == channeldpb.ChannelDataAccess_NO_ACCESS {
+			continue
+		}
+		if !foc.hadFirstFanOut || foc.postponed || foc.lastFanOutTime < lastUpdateTime {
+			return true
+		}
+	}
+	return false
+}
+
+// Puts the entity channel into dormancy if it hasn't been updated for EntityDormancyTimeoutMs.
+// Called in the entity channel's Tick(), with the subLock held.
+func (ch *Channel) tickDormancy(now time.Time) {
+	if ch.IsDormant() {
+		return
+	}
+	timeoutMs := GlobalSettings.GetChannelSettings(ch.channelType).EntityDormancyTimeoutMs
+	if timeoutMs == 0 {
+		return
+	}
+	if ch.lastActiveTime.IsZero() {
+		ch.lastActiveTime = now
+		return
+	}
+	if now.Sub(ch.lastActiveTime) < time.Duration(timeoutMs)*time.Millisecond || ch.hasPendingFanOut() {
+		return
+	}
+	ch.setDormant()
+}
+
+// Blocks the dormant channel's goroutine until a message arrives, or dormantTickInterval elapses.
+func (ch *Channel) waitDormant() {
+	timer := time.NewTimer(dormantTickInterval)
+	defer timer.Stop()
+	select {
+	case cm, ok := <-ch.inMsgQueue:
+		// The queue is closed when the channel is removed
+		if ok {
+			ch.handleChannelMessage(cm)
+		}
+	case <-timer.C:
+	}
+}
+
+func handleSetEntityDormancy(ctx MessageContext) {
+	if ctx.Channel.channelType != channeldpb.ChannelType_ENTITY {
+		ctx.Connection.Logger().Error("illegal attemp to set the dormancy of a non-entity channel")
+		return
+	}
+
+	if ctx.Connection != ctx.Channel.GetOwner() {
+		ctx.Connection.Logger().Error("SetEntityDormancyMessage should only handled for the owner connection of the entity channel")
+		return
+	}
+
+	msg, ok := ctx.Msg.(*channeldpb.SetEntityDormancyMessage)
+	if !ok {
+		ctx.Connection.Logger().Error("message is not a SetEntityDormancyMessage, will not be handled.")
+		return
+	}
+
+	if msg.Dormant {
+		ctx.Channel.setDormant()
+	} else {
+		ctx.Channel.wake()
+	}
+}
@@ -0,0 +1,122 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Runs the callback in the channel's goroutine and waits for it to finish.
+func executeAndWait(ch *Channel, callback func(ch *Channel)) {
+	done := make(chan struct{})
+	ch.Execute(func(ch *Channel) {
+		callback(ch)
+		close(done)
+	})
+	<-done
+}
+
+func TestEntityDormancy(t *testing.T) {
+	isolateChannelTest(t)
+
+	settings := ChannelSettingsType{
+		TickIntervalMs:          10,
+		EntityDormancyTimeoutMs: 50,
+	}
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = settings
+
+	owner := createACLTestConnectionById(1)
+	other := createACLTestConnectionById(2)
+	ch := createChannelWithId(common.ChannelId(401), channeldpb.ChannelType_ENTITY, owner)
+	defer func() {
+		RemoveChannel(ch)
+	}()
+	executeAndWait(ch, func(ch *Channel) {
+		ch.InitData(&testpb.TestChannelDataMessage{Text: "a"}, nil)
+	})
+
+	// Becomes dormant without any data update
+	assert.Eventually(t, ch.IsDormant, time.Second, 10*time.Millisecond)
+
+	setDormancy := func(conn ConnectionInChannel, dormant bool) bool {
+		var isDormant bool
+		executeAndWait(ch, func(ch *Channel) {
+			handleSetEntityDormancy(MessageContext{
+				MsgType:    channeldpb.MessageType_SET_ENTITY_DORMANCY,
+				Msg:        &channeldpb.SetEntityDormancyMessage{Dormant: dormant},
+				Connection: conn,
+				Channel:    ch,
+			})
+			isDormant = ch.IsDormant()
+		})
+		return isDormant
+	}
+
+	// Only the owner can wake up the channel
+	assert.True(t, setDormancy(other, false))
+	assert.False(t, setDormancy(owner, false))
+	assert.Eventually(t, ch.IsDormant, time.Second, 10*time.Millisecond)
+
+	// The data update wakes up the channel
+	updateData, _ := anypb.New(&testpb.TestChannelDataMessage{Num: 1})
+	executeAndWait(ch, func(ch *Channel) {
+		handleChannelDataUpdate(MessageContext{
+			MsgType:    channeldpb.MessageType_CHANNEL_DATA_UPDATE,
+			Msg:        &channeldpb.ChannelDataUpdateMessage{Data: updateData},
+			Connection: owner,
+			Channel:    ch,
+		})
+		assert.False(t, ch.IsDormant())
+		assert.EqualValues(t, 1, ch.GetDataMessage().(*testpb.TestChannelDataMessage).Num)
+	})
+
+	// The owner puts the channel into dormancy explicitly
+	assert.True(t, setDormancy(owner, true))
+	assert.False(t, setDormancy(owner, false))
+
+	// No automatic dormancy by default
+	settings.EntityDormancyTimeoutMs = 0
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = settings
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, ch.IsDormant())
+}
+
+func TestHasPendingFanOut(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	ch := createChannelWithId(common.ChannelId(402), channeldpb.ChannelType_ENTITY, nil)
+	// Stop the channel.Tick() goroutine
+	ch.removing = 1
+	defer allChannels.Delete(ch.id)
+	assert.False(t, ch.hasPendingFanOut())
+
+	ch.InitData(&testpb.TestChannelDataMessage{Text: "a"}, nil)
+	assert.False(t, ch.hasPendingFanOut())
+
+	conn := createACLTestConnectionById(1)
+	ch.subscribedConnections[conn] = &ChannelSubscription{options: *defaultSubOptions(ch.channelType)}
+	foc := &fanOutConnection{conn: conn}
+	ch.fanOutQueue.PushBack(foc)
+	// Waiting for the first fan-out
+	assert.True(t, ch.hasPendingFanOut())
+
+	foc.hadFirstFanOut = true
+	foc.lastFanOutTime = 100
+	assert.False(t, ch.hasPendingFanOut())
+
+	// The update hasn't been fanned out
+	ch.data.OnUpdate(&testpb.TestChannelDataMessage{Num: 1}, 200, 0, nil)
+	assert.True(t, ch.hasPendingFanOut())
+
+	foc.lastFanOutTime = 200
+	assert.False(t, ch.hasPendingFanOut())
+
+	foc.postponed = true
+	assert.True(t, ch.hasPendingFanOut())
+}
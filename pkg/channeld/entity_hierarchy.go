@@ -0,0 +1,233 @@
+package channeld
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"go.uber.org/zap"
+)
+
+/* Hierarchical entity groups
+ * The HierarchicalEntityGroupController keeps an explicit attachment tree of the entities, instead of merging the groups:
+ * - Adding entities to the HANDOVER group of an entity attaches them (along with their own children) to the entity as the children.
+ *   An entity that is already attached to another parent is moved to the new parent.
+ * - Removing an entity from the HANDOVER group detaches it (along with its own children) from its parent, so the subtree is handed over
+ *   independently again. E.g. the PlayerController and PlayerState stay attached to the character after the character dismounts a vehicle.
+ * - Only the root of a tree hands over, and the whole tree is handed over with it. The attached entities don't hand over by themselves.
+ * - Locking an entity locks the whole tree it's attached to, including its descendants, from handover.
+ *   A detached subtree is no longer locked by the locks in its former tree.
+ */
+
+type entityHierarchy struct {
+	lock     sync.RWMutex
+	parents  map[EntityId]EntityId
+	children map[EntityId]map[EntityId]struct{}
+	locked   map[EntityId]struct{}
+}
+
+func newEntityHierarchy() *entityHierarchy {
+	return &entityHierarchy{
+		parents:  make(map[EntityId]EntityId),
+		children: make(map[EntityId]map[EntityId]struct{}),
+		locked:   make(map[EntityId]struct{}),
+	}
+}
+
+// Shared by all the HierarchicalEntityGroupControllers, as the trees span multiple entity channels.
+var entityTree = newEntityHierarchy()
+
+// Should be called with the lock held.
+func (h *entityHierarchy) isAncestor(ancestor EntityId, entityId EntityId) bool {
+	for {
+		parent, exists := h.parents[entityId]
+		if !exists {
+			return false
+		}
+		if parent == ancestor {
+			return true
+		}
+		entityId = parent
+	}
+}
+
+// Should be called with the lock held.
+func (h *entityHierarchy) getRoot(entityId EntityId) EntityId {
+	for {
+		parent, exists := h.parents[entityId]
+		if !exists {
+			return entityId
+		}
+		entityId = parent
+	}
+}
+
+// Should be called with the lock held.
+func (h *entityHierarchy) attach(parent EntityId, child EntityId) error {
+	if child == parent || h.isAncestor(child, parent) {
+		return fmt.Errorf("can't attach entity %d to its descendant %d", child, parent)
+	}
+	h.detach(child)
+	h.parents[child] = parent
+	children, exists := h.children[parent]
+	if !exists {
+		children = make(map[EntityId]struct{})
+		h.children[parent] = children
+	}
+	children[child] = struct{}{}
+	return nil
+}
+
+// Should be called with the lock held.
+func (h *entityHierarchy) detach(entityId EntityId) {
+	parent, exists := h.parents[entityId]
+	if !exists {
+		return
+	}
+	delete(h.parents, entityId)
+	if children, exists := h.children[parent]; exists {
+		delete(children, entityId)
+		if len(children) == 0 {
+			delete(h.children, parent)
+		}
+	}
+}
+
+// Detaches the entity from its parent and its children, and unlocks it. Should be called with the lock held.
+func (h *entityHierarchy) remove(entityId EntityId) {
+	h.detach(entityId)
+	for child := range h.children[entityId] {
+		delete(h.parents, child)
+	}
+	delete(h.children, entityId)
+	delete(h.locked, entityId)
+}
+
+// Appends the entity and its descendants to the array. Returns false if any of them is locked. Should be called with the lock held.
+func (h *entityHierarchy) collectSubtree(entityId EntityId, arr []EntityId) ([]EntityId, bool) {
+	if _, locked := h.locked[entityId]; locked {
+		return arr, false
+	}
+	arr = append(arr, entityId)
+	for child := range h.children[entityId] {
+		var ok bool
+		if arr, ok = h.collectSubtree(child, arr); !ok {
+			return arr, false
+		}
+	}
+	return arr, true
+}
+
+// HierarchicalEntityGroupController is an implementation of EntityGroupController which keeps the attachment tree
+// of the entities, so adding an entity to a group is revertible by removing it. See entityHierarchy for the rules.
+type HierarchicalEntityGroupController struct {
+	entityId EntityId
+}
+
+func (ctl *HierarchicalEntityGroupController) Initialize(ch *Channel) {
+	ctl.entityId = EntityId(ch.Id())
+}
+
+func (ctl *HierarchicalEntityGroupController) Uninitialize(ch *Channel) {
+	if ch.Type() != channeldpb.ChannelType_ENTITY {
+		return
+	}
+
+	// The children become the roots of their own subtrees
+	entityTree.lock.Lock()
+	defer entityTree.lock.Unlock()
+	entityTree.remove(ctl.entityId)
+}
+
+// The groups are not merged, so there's nothing to cascade.
+func (ctl *HierarchicalEntityGroupController) cascadeGroup(t channeldpb.EntityGroupType, group *EntityGroup) {
+}
+
+func (ctl *HierarchicalEntityGroupController) AddToGroup(t channeldpb.EntityGroupType, entitiesToAdd []EntityId) error {
+	entityTree.lock.Lock()
+	defer entityTree.lock.Unlock()
+
+	var err error
+	if t == channeldpb.EntityGroupType_HANDOVER {
+		for _, entityId := range entitiesToAdd {
+			// The entity itself can be in the list, as in FlatEntityGroupController
+			if entityId == ctl.entityId {
+				continue
+			}
+			if attachErr := entityTree.attach(ctl.entityId, entityId); attachErr != nil {
+				err = attachErr
+			}
+		}
+		rootLogger.Debug("updated entity hierarchy", zap.Uint32("entityId", uint32(ctl.entityId)),
+			zap.Uint32("rootEntityId", uint32(entityTree.getRoot(ctl.entityId))))
+	} else if t == channeldpb.EntityGroupType_LOCK {
+		for _, entityId := range entitiesToAdd {
+			entityTree.locked[entityId] = struct{}{}
+		}
+	}
+
+	return err
+}
+
+func (ctl *HierarchicalEntityGroupController) RemoveFromGroup(t channeldpb.EntityGroupType, entitiesToRemove []EntityId) error {
+	entityTree.lock.Lock()
+	defer entityTree.lock.Unlock()
+
+	var err error
+	if t == channeldpb.EntityGroupType_HANDOVER {
+		for _, entityId := range entitiesToRemove {
+			// Only the entity itself or its descendants can be detached
+			if entityId != ctl.entityId && !entityTree.isAncestor(ctl.entityId, entityId) {
+				err = fmt.Errorf("entity %d is not attached to entity %d", entityId, ctl.entityId)
+				continue
+			}
+			entityTree.detach(entityId)
+		}
+	} else if t == channeldpb.EntityGroupType_LOCK {
+		for _, entityId := range entitiesToRemove {
+			delete(entityTree.locked, entityId)
+		}
+	}
+
+	return err
+}
+
+// Appends the locked entities in the subtree of the entity to the array. Should be called with the lock held.
+func (h *entityHierarchy) collectLocked(entityId EntityId, arr []EntityId) []EntityId {
+	if _, locked := h.locked[entityId]; locked {
+		arr = append(arr, entityId)
+	}
+	for child := range h.children[entityId] {
+		arr = h.collectLocked(child, arr)
+	}
+	return arr
+}
+
+// Returns the entity and its descendants if the entity is the root of the tree, and none of the tree is locked.
+// Otherwise returns an empty array.
+func (ctl *HierarchicalEntityGroupController) GetHandoverEntities() []EntityId {
+	entityTree.lock.RLock()
+	defer entityTree.lock.RUnlock()
+
+	if _, attached := entityTree.parents[ctl.entityId]; attached {
+		return []EntityId{}
+	}
+
+	arr, ok := entityTree.collectSubtree(ctl.entityId, make([]EntityId, 0, 1))
+	if !ok {
+		return []EntityId{}
+	}
+	return arr
+}
+
+// Returns the locked entities in the tree if the entity is the root of the tree. The attached entities don't hand over by themselves,
+// so their handovers are never locked.
+func (ctl *HierarchicalEntityGroupController) GetLockedEntities() []EntityId {
+	entityTree.lock.RLock()
+	defer entityTree.lock.RUnlock()
+
+	if _, attached := entityTree.parents[ctl.entityId]; attached {
+		return nil
+	}
+	return entityTree.collectLocked(ctl.entityId, nil)
+}
@@ -0,0 +1,221 @@
+package channeld
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Entity ID allocation
+ * The entity channel IDs are in [EntityChannelIdStart, MaxUint32]. By default, the servers supply the IDs (e.g. the netId of the actors)
+ * when creating the entity channels, and two servers may pick the same ID. To avoid that, a server can lease a block of the entity IDs
+ * by ALLOCATE_ENTITY_IDS. The IDs leased to a connection can't be used by other connections, until the connection is closed.
+ * Alternatively, channeld assigns the entity ID if CreateEntityChannelMessage.entityId is 0 (or the ENTITY channel is created by CREATE_CHANNEL),
+ * and returns it in CreateChannelResultMessage.channelId. The assigned IDs are never in any lease.
+ * Neither the leased blocks nor the assigned IDs contain the IDs of the existing entity channels.
+ */
+
+// The max number of the entity IDs in an AllocateEntityIdsMessage
+const MaxEntityIdLeaseCount = 0x10000
+
+type entityIdLease struct {
+	start EntityId
+	// Inclusive
+	end    EntityId
+	connId ConnectionId
+}
+
+// Accessed from the GLOBAL and the SPATIAL channels' goroutines, so it's protected by the mutex.
+type entityIdAllocator struct {
+	lock sync.Mutex
+	// Sorted by start
+	leases []entityIdLease
+	// Where the search of the next free IDs starts
+	nextId EntityId
+}
+
+var entityIds = &entityIdAllocator{}
+
+// Returns the start of the first free block of the IDs, and moves nextId after the block. Should be called with the lock held.
+func (a *entityIdAllocator) findFreeBlock(count uint32) (EntityId, error) {
+	minId := uint64(GlobalSettings.EntityChannelIdStart)
+	maxId := uint64(math.MaxUint32)
+	if uint64(count) > maxId-minId+1 {
+		return 0, ErrEntityChannelFull
+	}
+
+	start := uint64(a.nextId)
+	if start < minId || start > maxId {
+		start = minId
+	}
+	// How many IDs have been skipped, in order to stop after a full round.
+	var searched uint64
+	for searched <= maxId-minId+1 {
+		end := start + uint64(count) - 1
+		if end > maxId {
+			searched += maxId - start + 1
+			start = minId
+			continue
+		}
+
+		// Skip the overlapped lease
+		if lease, exists := a.findFirstLeaseIn(EntityId(start), EntityId(end)); exists {
+			searched += uint64(lease.end) + 1 - start
+			start = uint64(lease.end) + 1
+			continue
+		}
+
+		// Skip the existing entity channel
+		usedId, used := uint64(0), false
+		for id := start; id <= end; id++ {
+			if GetChannel(common.ChannelId(id)) != nil {
+				usedId, used = id, true
+				break
+			}
+		}
+		if used {
+			searched += usedId + 1 - start
+			start = usedId + 1
+			continue
+		}
+
+		if end < maxId {
+			a.nextId = EntityId(end + 1)
+		} else {
+			a.nextId = EntityId(minId)
+		}
+		return EntityId(start), nil
+	}
+	return 0, ErrEntityChannelFull
+}
+
+// Returns the first lease in [start, end]. Should be called with the lock held.
+func (a *entityIdAllocator) findFirstLeaseIn(start EntityId, end EntityId) (entityIdLease, bool) {
+	i := sort.Search(len(a.leases), func(i int) bool {
+		return a.leases[i].end >= start
+	})
+	if i < len(a.leases) && a.leases[i].start <= end {
+		return a.leases[i], true
+	}
+	return entityIdLease{}, false
+}
+
+// Leases a block of count entity IDs to the connection. Returns the first ID of the block.
+func (a *entityIdAllocator) lease(connId ConnectionId, count uint32) (EntityId, error) {
+	if count == 0 || count > MaxEntityIdLeaseCount {
+		return 0, fmt.Errorf("the count of the entity IDs should be in [1, %d]", MaxEntityIdLeaseCount)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	start, err := a.findFreeBlock(count)
+	if err != nil {
+		return 0, err
+	}
+	lease := entityIdLease{start: start, end: start + EntityId(count-1), connId: connId}
+	i := sort.Search(len(a.leases), func(i int) bool {
+		return a.leases[i].start > start
+	})
+	a.leases = append(a.leases, entityIdLease{})
+	copy(a.leases[i+1:], a.leases[i:])
+	a.leases[i] = lease
+	return start, nil
+}
+
+// Assigns a free entity ID that is not in any lease.
+func (a *entityIdAllocator) allocateId() (EntityId, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.findFreeBlock(1)
+}
+
+// Returns the connection that the entity ID is leased to, or false if the ID is not leased.
+func (a *entityIdAllocator) getLeaseOwner(entityId EntityId) (ConnectionId, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	lease, exists := a.findFirstLeaseIn(entityId, entityId)
+	return lease.connId, exists
+}
+
+func (a *entityIdAllocator) hasLease(connId ConnectionId) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for _, lease := range a.leases {
+		if lease.connId == connId {
+			return true
+		}
+	}
+	return false
+}
+
+// Releases all the leases of the connection. Returns how many IDs are released.
+func (a *entityIdAllocator) release(connId ConnectionId) uint64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	var released uint64
+	leases := a.leases[:0]
+	for _, lease := range a.leases {
+		if lease.connId == connId {
+			released += uint64(lease.end-lease.start) + 1
+		} else {
+			leases = append(leases, lease)
+		}
+	}
+	a.leases = leases
+	return released
+}
+
+// Returns an error if the entity ID can't be used by the connection to create the entity channel.
+func checkEntityIdLease(entityId EntityId, connId ConnectionId) error {
+	if ownerConnId, leased := entityIds.getLeaseOwner(entityId); leased && ownerConnId != connId {
+		return fmt.Errorf("entity ID %d is leased to connection %d", entityId, ownerConnId)
+	}
+	return nil
+}
+
+func handleAllocateEntityIds(ctx MessageContext) {
+	if ctx.Channel != globalChannel {
+		ctx.Connection.Logger().Error("illegal attemp to allocate entity IDs outside the GLOBAL channel")
+		return
+	}
+
+	msg, ok := ctx.Msg.(*channeldpb.AllocateEntityIdsMessage)
+	if !ok {
+		ctx.Connection.Logger().Error("message is not an AllocateEntityIdsMessage, will not be handled.")
+		return
+	}
+
+	if ctx.Connection.GetConnectionType() != channeldpb.ConnectionType_SERVER {
+		ctx.Connection.Logger().Error("illegal attemp to allocate entity IDs from client connection")
+		return
+	}
+
+	connId := ctx.Connection.Id()
+	firstLease := !entityIds.hasLease(connId)
+	start, err := entityIds.lease(connId, msg.Count)
+	if err != nil {
+		ctx.Connection.Logger().Error("failed to allocate entity IDs", zap.Uint32("count", msg.Count), zap.Error(err))
+		return
+	}
+
+	// Release the leases when the connection is closed
+	if conn, ok := ctx.Connection.(*Connection); ok && firstLease {
+		conn.AddCloseHandler(func() {
+			released := entityIds.release(connId)
+			rootLogger.Info("released the entity IDs of the closed connection", zap.Uint32("connId", uint32(connId)), zap.Uint64("released", released))
+		})
+	}
+
+	ctx.Connection.Logger().Info("leased entity IDs", zap.Uint32("startEntityId", uint32(start)), zap.Uint32("count", msg.Count))
+
+	ctx.Msg = &channeldpb.AllocateEntityIdsResultMessage{
+		StartEntityId: uint32(start),
+		Count:         msg.Count,
+	}
+	ctx.Connection.Send(ctx)
+}
@@ -0,0 +1,90 @@
+package channeld
+
+import (
+	"math"
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityIdLease(t *testing.T) {
+	InitChannels()
+	entityIds = &entityIdAllocator{}
+	defer func() {
+		entityIds = &entityIdAllocator{}
+	}()
+	start := EntityId(GlobalSettings.EntityChannelIdStart)
+
+	_, err := entityIds.lease(1, 0)
+	assert.Error(t, err)
+	_, err = entityIds.lease(1, MaxEntityIdLeaseCount+1)
+	assert.Error(t, err)
+
+	// The existing entity channel is skipped
+	existingCh := createChannelWithId(common.ChannelId(start+5), channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		existingCh.removing = 1
+		allChannels.Delete(existingCh.id)
+	}()
+
+	startA, err := entityIds.lease(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, start, startA)
+	startB, err := entityIds.lease(2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, start+6, startB)
+
+	assert.NoError(t, checkEntityIdLease(startA+4, 1))
+	assert.Error(t, checkEntityIdLease(startA+4, 2))
+	assert.NoError(t, checkEntityIdLease(startB+9, 2))
+	assert.Error(t, checkEntityIdLease(startB+9, 1))
+	// Not leased
+	assert.NoError(t, checkEntityIdLease(startB+10, 1))
+
+	// The assigned ID is not in any lease
+	entityId, err := entityIds.allocateId()
+	assert.NoError(t, err)
+	assert.Equal(t, startB+10, entityId)
+
+	// The released IDs can be leased again
+	assert.True(t, entityIds.hasLease(1))
+	assert.EqualValues(t, 5, entityIds.release(1))
+	assert.False(t, entityIds.hasLease(1))
+	assert.NoError(t, checkEntityIdLease(startA, 2))
+
+	// Wraps around at the end of the entity IDs
+	entityIds.nextId = math.MaxUint32 - 2
+	startC, err := entityIds.lease(3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, start, startC)
+	entityIds.nextId = math.MaxUint32
+	entityId, err = entityIds.allocateId()
+	assert.NoError(t, err)
+	assert.EqualValues(t, math.MaxUint32, entityId)
+	// The block before the existing entity channel is too small
+	startD, err := entityIds.lease(4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, startB+10, startD)
+}
+
+func TestCreateEntityChannelWithAssignedId(t *testing.T) {
+	InitChannels()
+	entityIds = &entityIdAllocator{}
+	defer func() {
+		entityIds = &entityIdAllocator{}
+	}()
+
+	_, err := entityIds.lease(1, 10)
+	assert.NoError(t, err)
+
+	ch, err := CreateChannel(channeldpb.ChannelType_ENTITY, nil)
+	assert.NoError(t, err)
+	defer func() {
+		ch.removing = 1
+		allChannels.Delete(ch.id)
+	}()
+	assert.Equal(t, GlobalSettings.EntityChannelIdStart+10, ch.Id())
+	assert.NotNil(t, ch.entityController)
+}
@@ -0,0 +1,321 @@
+package channeld
+
+import (
+	"math"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Entity-level interest management
+ * The client connection sets the query by UpdateSpatialInterestMessage.entityInterest. Periodically, the GLOBAL channel checks the
+ * positions of the entities against the query, subscribes the client to the entity channels in the area,
+ * and unsubscribes the client from the entity channels out of the area (plus the hysteresis distance).
+ * Only the entities in the spatial channels that intersect with the query are checked, by the spatial entity index (see spatial_query.go).
+ */
+
+const defaultEntityInterestUpdateIntervalMs = 200
+
+// Only accessed in the GLOBAL channel
+var lastEntityInterestUpdateTime time.Time
+
+type entityInterest struct {
+	query   *channeldpb.SpatialInterestQuery
+	worldId string
+}
+
+// Sets the query of the connection's entity-level interest in the default world. nil = the entity-level interest is disabled,
+// and the entity channels subscribed by it will be unsubscribed in the next update.
+func (c *Connection) SetEntityInterestQuery(query *channeldpb.SpatialInterestQuery) {
+	c.SetEntityInterestQueryInWorld(DefaultSpatialWorldId, query)
+}
+
+// Same as SetEntityInterestQuery, but only the entities in the spatial world are subscribed.
+func (c *Connection) SetEntityInterestQueryInWorld(worldId string, query *channeldpb.SpatialInterestQuery) {
+	c.entityInterestQuery.Store(&entityInterest{query: query, worldId: worldId})
+}
+
+// Returns the query of the connection's entity-level interest, or nil if it's disabled.
+func (c *Connection) GetEntityInterestQuery() *channeldpb.SpatialInterestQuery {
+	return c.getEntityInterest().query
+}
+
+func (c *Connection) getEntityInterest() entityInterest {
+	interest, ok := c.entityInterestQuery.Load().(*entityInterest)
+	if !ok || interest == nil {
+		return entityInterest{}
+	}
+	return *interest
+}
+
+// Called in the entity channel's goroutine
+func (ch *Channel) updateSpatialInfo() {
+	dataMsgWithSpatialInfo, ok := ch.GetDataMessage().(EntityChannelDataWithSpatialInfo)
+	if !ok {
+		return
+	}
+	info := dataMsgWithSpatialInfo.GetSpatialInfo()
+	if info == nil {
+		return
+	}
+	// Only allocate when the entity moves
+	if oldInfo, ok := ch.spatialInfo.Load().(*common.SpatialInfo); ok && *oldInfo == *info {
+		return
+	}
+	newInfo := *info
+	ch.setSpatialInfo(&newInfo)
+}
+
+// Stores the position of the entity and indexes the entity by the spatial channel of the position. See QuerySpatialEntitiesInWorld.
+func (ch *Channel) setSpatialInfo(info *common.SpatialInfo) {
+	ch.spatialInfo.Store(info)
+	spatialCtl := GetSpatialControllerOfWorld(ch.spatialWorldId)
+	if spatialCtl == nil {
+		spatialEntities.remove(EntityId(ch.id))
+		return
+	}
+	spatialChId, err := spatialCtl.GetChannelId(*info)
+	if err != nil {
+		spatialEntities.remove(EntityId(ch.id))
+		return
+	}
+	spatialEntities.set(EntityId(ch.id), spatialChId)
+}
+
+// Returns the position of the entity as of the channel's last tick, or nil if the channel data doesn't implement EntityChannelDataWithSpatialInfo.
+func (ch *Channel) GetSpatialInfo() *common.SpatialInfo {
+	info, _ := ch.spatialInfo.Load().(*common.SpatialInfo)
+	return info
+}
+
+// Returns true if the position is in the area of any AOI in the query. The area of the box, sphere and cone AOIs is extended by the margin.
+// The distances are calculated in 3D if the spatialCtl is 3D (see StaticGrid3DSpatialController), otherwise on the XZ plane.
+// A spot AOI matches the positions in the same spatial channel of the spatialCtl.
+func isInSpatialInterest(query *channeldpb.SpatialInterestQuery, pos *common.SpatialInfo, margin float64, spatialCtl SpatialController) bool {
+	_, is3D := spatialCtl.(*StaticGrid3DSpatialController)
+	// Ignores the Y axis if the spatial controller is 2D
+	toSpatialInfo := func(x, y, z float64) *common.SpatialInfo {
+		if !is3D {
+			y = 0
+		}
+		return &common.SpatialInfo{X: x, Y: y, Z: z}
+	}
+
+	if box := query.GetBoxAOI(); box != nil && box.Center != nil && box.Extent != nil {
+		if math.Abs(pos.X-box.Center.X) <= box.Extent.X+margin && math.Abs(pos.Z-box.Center.Z) <= box.Extent.Z+margin &&
+			(!is3D || math.Abs(pos.Y-box.Center.Y) <= box.Extent.Y+margin) {
+			return true
+		}
+	}
+
+	if sphere := query.GetSphereAOI(); sphere != nil && sphere.Center != nil {
+		center := toSpatialInfo(sphere.Center.X, sphere.Center.Y, sphere.Center.Z)
+		if center.Dist3D(toSpatialInfo(pos.X, pos.Y, pos.Z)) <= sphere.Radius+margin {
+			return true
+		}
+	}
+
+	if cone := query.GetConeAOI(); cone != nil && cone.Center != nil && cone.Direction != nil {
+		dir := toSpatialInfo(pos.X-cone.Center.X, pos.Y-cone.Center.Y, pos.Z-cone.Center.Z)
+		dist := dir.Magnitude3D()
+		coneDir := toSpatialInfo(cone.Direction.X, cone.Direction.Y, cone.Direction.Z)
+		if dist <= margin {
+			return true
+		}
+		if dist <= cone.Radius+margin && coneDir.Magnitude3D() > 0 {
+			dir.Normalize3D()
+			coneDir.Normalize3D()
+			angle := math.Acos(math.Max(-1, math.Min(1, dir.Dot3D(coneDir))))
+			// The margin at the distance, in radians
+			angleMargin := math.Asin(math.Min(1, margin/dist))
+			if angle <= cone.Angle+angleMargin {
+				return true
+			}
+		}
+	}
+
+	if spots := query.GetSpotsAOI(); spots != nil && spatialCtl != nil {
+		chId, err := spatialCtl.GetChannelId(*pos)
+		if err == nil {
+			for _, spot := range spots.Spots {
+				spotChId, err := spatialCtl.GetChannelId(common.SpatialInfo{X: spot.X, Y: spot.Y, Z: spot.Z})
+				if err == nil && spotChId == chId {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Updates the entity-level interest of all the client connections. Called in the GLOBAL channel.
+func tickEntityInterest(now time.Time) {
+	settings := GlobalSettings.GetChannelSettings(channeldpb.ChannelType_ENTITY)
+	intervalMs := settings.EntityInterestUpdateIntervalMs
+	if intervalMs == 0 {
+		intervalMs = defaultEntityInterestUpdateIntervalMs
+	}
+	if now.Sub(lastEntityInterestUpdateTime) < time.Duration(intervalMs)*time.Millisecond {
+		return
+	}
+	lastEntityInterestUpdateTime = now
+
+	if allConnections == nil {
+		return
+	}
+
+	allConnections.Range(func(_ ConnectionId, conn *Connection) bool {
+		if conn.GetConnectionType() != channeldpb.ConnectionType_CLIENT || conn.IsClosing() {
+			return true
+		}
+		interest := conn.getEntityInterest()
+		if interest.query == nil && len(conn.entityInterestSubs) == 0 {
+			return true
+		}
+		conn.updateEntityInterest(interest, settings.EntityInterestHysteresis)
+		return true
+	})
+}
+
+// The state of the subscription made by the entity-level interest
+type entityInterestSubState uint8
+
+const (
+	// The sub message is queued in the entity channel
+	entityInterestSub_Pending entityInterestSubState = iota
+	entityInterestSub_Subscribed
+	// The subscription is denied (e.g. by the role-based ACL). Not retried until the entity leaves the area.
+	entityInterestSub_Denied
+)
+
+// Returns the entity channels in the spatial channels that intersect with the query. See spatialEntityIndex.
+func getEntityChannelsInQuery(query *channeldpb.SpatialInterestQuery, spatialCtl SpatialController) []*Channel {
+	if spatialCtl == nil {
+		return nil
+	}
+	spatialChIds, err := spatialCtl.QueryChannelIds(query)
+	if err != nil {
+		rootLogger.Debug("failed to query the spatial channels of the entity-level interest", zap.Error(err))
+		return nil
+	}
+	entityChannels := make([]*Channel, 0)
+	for spatialChId := range spatialChIds {
+		for _, entityId := range spatialEntities.getEntities(spatialChId) {
+			if entityCh := GetChannel(common.ChannelId(entityId)); entityCh != nil && !entityCh.IsRemoving() && entityCh.GetSpatialInfo() != nil {
+				entityChannels = append(entityChannels, entityCh)
+			}
+		}
+	}
+	return entityChannels
+}
+
+// Subscribes the connection to the entity channels that enter the query's area, and unsubscribes it from the ones that leave the area plus the hysteresis.
+// Only the entity channels in the spatial channels that intersect with the query, and the ones tracked by the connection are checked.
+// If the query is nil, the connection is unsubscribed from all the entity channels subscribed by the entity-level interest.
+// The entity channels out of the interest's spatial world are never in the interest.
+func (c *Connection) updateEntityInterest(interest entityInterest, hysteresis float64) {
+	query := interest.query
+	spatialCtl := GetSpatialControllerOfWorld(interest.worldId)
+	if c.entityInterestSubs == nil {
+		c.entityInterestSubs = make(map[common.ChannelId]entityInterestSubState)
+	}
+
+	// The removed entity channels, or the ones lost the spatial info
+	entityChannels := make([]*Channel, 0, len(c.entityInterestSubs))
+	for chId, state := range c.entityInterestSubs {
+		entityCh := GetChannel(chId)
+		if entityCh == nil || entityCh.IsRemoving() {
+			delete(c.entityInterestSubs, chId)
+		} else if query == nil || entityCh.GetSpatialInfo() == nil {
+			if state == entityInterestSub_Subscribed {
+				c.unsubFromEntityByInterest(entityCh)
+			}
+			delete(c.entityInterestSubs, chId)
+		} else {
+			// The tracked entity channel may have left the spatial channels of the query
+			entityChannels = append(entityChannels, entityCh)
+		}
+	}
+
+	if query == nil {
+		return
+	}
+
+	for _, entityCh := range append(entityChannels, getEntityChannelsInQuery(query, spatialCtl)...) {
+		state, tracked := c.entityInterestSubs[entityCh.id]
+		margin := 0.0
+		if tracked && state != entityInterestSub_Denied {
+			margin = hysteresis
+		}
+		inInterest := entityCh.spatialWorldId == interest.worldId && isInSpatialInterest(query, entityCh.GetSpatialInfo(), margin, spatialCtl)
+		if inInterest && !tracked {
+			// Don't take over the subscription that is not made by the entity-level interest
+			if entityCh.hasSubscriber(c) {
+				continue
+			}
+			c.entityInterestSubs[entityCh.id] = entityInterestSub_Pending
+			c.subToEntityByInterest(entityCh)
+		} else if !inInterest && tracked {
+			// The pending subscription is unsubscribed when it completes. See onEntityInterestSubscribed().
+			if state == entityInterestSub_Subscribed {
+				c.unsubFromEntityByInterest(entityCh)
+			}
+			delete(c.entityInterestSubs, entityCh.id)
+		}
+	}
+}
+
+// Called in the GLOBAL channel after the sub message is handled in the entity channel.
+func (c *Connection) onEntityInterestSubscribed(entityCh *Channel, subscribed bool) {
+	if state, tracked := c.entityInterestSubs[entityCh.id]; !tracked || state != entityInterestSub_Pending {
+		// The entity has left the area, or the interest is disabled, before the subscription completes.
+		if subscribed {
+			c.unsubFromEntityByInterest(entityCh)
+		}
+		return
+	}
+	if subscribed {
+		c.entityInterestSubs[entityCh.id] = entityInterestSub_Subscribed
+	} else {
+		c.entityInterestSubs[entityCh.id] = entityInterestSub_Denied
+	}
+}
+
+func (ch *Channel) hasSubscriber(conn ConnectionInChannel) bool {
+	ch.subLock.RLock()
+	defer ch.subLock.RUnlock()
+	_, exists := ch.subscribedConnections[conn]
+	return exists
+}
+
+func (c *Connection) subToEntityByInterest(entityCh *Channel) {
+	// Make sure the sub message is handled in the entity channel's goroutine
+	entityCh.PutMessageContext(MessageContext{
+		MsgType:    channeldpb.MessageType_SUB_TO_CHANNEL,
+		Msg:        &channeldpb.SubscribedToChannelMessage{ConnId: uint32(c.id)},
+		Connection: c,
+		Channel:    entityCh,
+		ChannelId:  uint32(entityCh.id),
+	}, func(ctx MessageContext) {
+		handleSubToChannel(ctx)
+		// The subscription can be denied, e.g. by the role-based ACL. Record the result in the GLOBAL channel.
+		subscribed := entityCh.hasSubscriber(c)
+		globalChannel.Execute(func(_ *Channel) {
+			c.onEntityInterestSubscribed(entityCh, subscribed)
+		})
+	})
+}
+
+func (c *Connection) unsubFromEntityByInterest(entityCh *Channel) {
+	// Make sure the unsub message is handled in the entity channel's goroutine
+	entityCh.PutMessageContext(MessageContext{
+		MsgType:    channeldpb.MessageType_UNSUB_FROM_CHANNEL,
+		Msg:        &channeldpb.UnsubscribedFromChannelMessage{ConnId: uint32(c.id)},
+		Connection: c,
+		Channel:    entityCh,
+		ChannelId:  uint32(entityCh.id),
+	}, handleUnsubFromChannel)
+}
@@ -0,0 +1,207 @@
+package channeld
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInSpatialInterest(t *testing.T) {
+	sphereQuery := &channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Radius: 10,
+		},
+	}
+	assert.True(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 6, Y: 100, Z: 8}, 0, nil))
+	assert.False(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 12, Z: 0}, 0, nil))
+	assert.True(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 12, Z: 0}, 5, nil))
+
+	boxQuery := &channeldpb.SpatialInterestQuery{
+		BoxAOI: &channeldpb.SpatialInterestQuery_BoxAOI{
+			Center: &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Extent: &channeldpb.SpatialInfo{X: 10, Z: 5},
+		},
+	}
+	assert.True(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: -10, Z: 5}, 0, nil))
+	assert.False(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: 0, Z: 6}, 0, nil))
+	assert.True(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: 0, Z: 6}, 1, nil))
+
+	coneQuery := &channeldpb.SpatialInterestQuery{
+		ConeAOI: &channeldpb.SpatialInterestQuery_ConeAOI{
+			Center:    &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Direction: &channeldpb.SpatialInfo{X: 1, Z: 0},
+			Angle:     math.Pi / 4,
+			Radius:    10,
+		},
+	}
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Z: 4}, 0, nil))
+	// Behind the center
+	assert.False(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: -5, Z: 0}, 0, nil))
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: -1, Z: 0}, 2, nil))
+	// Out of the angle, but within the margin
+	assert.False(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Z: 6}, 0, nil))
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Z: 6}, 1, nil))
+	assert.False(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 11, Z: 0}, 0, nil))
+
+	assert.False(t, isInSpatialInterest(&channeldpb.SpatialInterestQuery{}, &common.SpatialInfo{}, 100, nil))
+
+	// The Y axis is only checked if the spatial controller is 3D
+	ctl3D := &StaticGrid3DSpatialController{}
+	assert.False(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 6, Y: 100, Z: 8}, 0, ctl3D))
+	assert.True(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 6, Y: 0, Z: 8}, 0, ctl3D))
+	assert.True(t, isInSpatialInterest(sphereQuery, &common.SpatialInfo{X: 0, Y: 12, Z: 0}, 5, ctl3D))
+
+	boxQuery.BoxAOI.Extent.Y = 5
+	assert.True(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: -10, Y: 5, Z: 5}, 0, ctl3D))
+	assert.False(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: 0, Y: 6, Z: 0}, 0, ctl3D))
+	assert.True(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: 0, Y: 6, Z: 0}, 1, ctl3D))
+	assert.True(t, isInSpatialInterest(boxQuery, &common.SpatialInfo{X: 0, Y: 6, Z: 0}, 0, nil))
+
+	// Above the cone, which points along the X axis
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Y: 6, Z: 0}, 0, nil))
+	assert.False(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Y: 6, Z: 0}, 0, ctl3D))
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 5, Y: 4, Z: 0}, 0, ctl3D))
+	coneQuery.ConeAOI.Direction = &channeldpb.SpatialInfo{Y: 1}
+	assert.True(t, isInSpatialInterest(coneQuery, &common.SpatialInfo{X: 1, Y: 8, Z: 1}, 0, ctl3D))
+}
+
+// The entity-level interest is updated in the GLOBAL channel's tick.
+func TestEntityInterest(t *testing.T) {
+	isolateChannelTest(t)
+
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = ChannelSettingsType{
+		TickIntervalMs:                 10,
+		DefaultFanOutIntervalMs:        100,
+		EntityInterestUpdateIntervalMs: 10,
+		EntityInterestHysteresis:       5,
+	}
+
+	// The entities are indexed by the spatial channels of a 4-by-4-grid world of 50x50 grids
+	oldSpatialController := spatialController
+	defer func() {
+		spatialController = oldSpatialController
+	}()
+	spatialController = &StaticGrid2DSpatialController{
+		GridWidth:  50,
+		GridHeight: 50,
+		GridCols:   4,
+		GridRows:   4,
+		ServerCols: 1,
+		ServerRows: 1,
+	}
+
+	serverConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+	ch := createChannelWithId(GlobalSettings.EntityChannelIdStart+10, channeldpb.ChannelType_ENTITY, serverConn)
+	defer RemoveChannel(ch)
+	ch.setSpatialInfo(&common.SpatialInfo{X: 12, Z: 0})
+
+	isSubscribed := func() bool {
+		return ch.hasSubscriber(clientConn)
+	}
+	isUnsubscribed := func() bool {
+		return !ch.hasSubscriber(clientConn)
+	}
+	// Waits for a few updates of the entity-level interest
+	waitForUpdates := func() {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	clientConn.SetEntityInterestQuery(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Radius: 10,
+		},
+	})
+
+	// Out of the area
+	waitForUpdates()
+	assert.False(t, isSubscribed())
+
+	// Enters the area
+	ch.setSpatialInfo(&common.SpatialInfo{X: 8, Z: 0})
+	assert.Eventually(t, isSubscribed, time.Second, 10*time.Millisecond)
+
+	// Leaves the area, but within the hysteresis
+	ch.setSpatialInfo(&common.SpatialInfo{X: 12, Z: 0})
+	waitForUpdates()
+	assert.True(t, isSubscribed())
+
+	// Leaves the area plus the hysteresis
+	ch.setSpatialInfo(&common.SpatialInfo{X: 16, Z: 0})
+	assert.Eventually(t, isUnsubscribed, time.Second, 10*time.Millisecond)
+
+	// Disables the entity-level interest
+	ch.setSpatialInfo(&common.SpatialInfo{X: 0, Z: 0})
+	assert.Eventually(t, isSubscribed, time.Second, 10*time.Millisecond)
+	clientConn.SetEntityInterestQuery(nil)
+	assert.Eventually(t, isUnsubscribed, time.Second, 10*time.Millisecond)
+
+	// The subscription made by the client itself is not taken over
+	ch.PutMessageContext(MessageContext{
+		MsgType:    channeldpb.MessageType_SUB_TO_CHANNEL,
+		Msg:        &channeldpb.SubscribedToChannelMessage{ConnId: uint32(clientConn.Id())},
+		Connection: clientConn,
+		Channel:    ch,
+	}, handleSubToChannel)
+	assert.Eventually(t, isSubscribed, time.Second, 10*time.Millisecond)
+	clientConn.SetEntityInterestQuery(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Radius: 10,
+		},
+	})
+	waitForUpdates()
+	ch.setSpatialInfo(&common.SpatialInfo{X: 100, Z: 0})
+	waitForUpdates()
+	assert.True(t, isSubscribed())
+	clientConn.SetEntityInterestQuery(nil)
+
+	// The subscription denied by the role-based ACL is not recorded, and not retried until the entity leaves the area
+	deniedCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+11, channeldpb.ChannelType_ENTITY, serverConn)
+	defer RemoveChannel(deniedCh)
+	deniedCh.roleACL = roleACLFromProto(&channeldpb.ChannelRoleACL{
+		Sub: []*channeldpb.RoleACLRule{{Roles: []string{AnyRole}, Allow: false}},
+	})
+	deniedCh.setSpatialInfo(&common.SpatialInfo{X: 5, Z: 0})
+	getSubState := func() (state entityInterestSubState, tracked bool) {
+		executeAndWait(globalChannel, func(_ *Channel) {
+			state, tracked = clientConn.entityInterestSubs[deniedCh.id]
+		})
+		return
+	}
+	countAccessDenied := func() int {
+		count := 0
+		for _, msg := range clientConn.testQueue() {
+			if _, ok := msg.(*channeldpb.AccessDeniedMessage); ok {
+				count++
+			}
+		}
+		return count
+	}
+	clientConn.SetEntityInterestQuery(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 0, Z: 0},
+			Radius: 10,
+		},
+	})
+	assert.Eventually(t, func() bool {
+		state, tracked := getSubState()
+		return tracked && state == entityInterestSub_Denied
+	}, time.Second, 10*time.Millisecond)
+	waitForUpdates()
+	assert.False(t, deniedCh.hasSubscriber(clientConn))
+	assert.Equal(t, 1, countAccessDenied())
+
+	deniedCh.setSpatialInfo(&common.SpatialInfo{X: 100, Z: 0})
+	assert.Eventually(t, func() bool {
+		_, tracked := getSubState()
+		return !tracked
+	}, time.Second, 10*time.Millisecond)
+	clientConn.SetEntityInterestQuery(nil)
+}
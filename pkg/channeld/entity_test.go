@@ -103,3 +103,83 @@ func TestEntityChannelGroupController(t *testing.T) {
 	assert.NotContains(t, handoverEntities, charA, "Character A should NOT be handed over with the vehicle")
 	assert.Contains(t, handoverEntities, charC, "Character C should be handed over with the vehicle")
 }
+
+func TestHierarchicalEntityGroupController(t *testing.T) {
+	InitChannels()
+	entityTree = newEntityHierarchy()
+
+	oldSettings, exists := GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY]
+	defer func() {
+		if exists {
+			GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = oldSettings
+		} else {
+			delete(GlobalSettings.ChannelSettings, channeldpb.ChannelType_ENTITY)
+		}
+	}()
+	settings := GlobalSettings.GetChannelSettings(channeldpb.ChannelType_ENTITY)
+	settings.EntityGroupControllerType = "HierarchicalEntityGroupController"
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = settings
+
+	createEntity := func(entityId EntityId) *Channel {
+		ch := createChannelWithId(common.ChannelId(entityId), channeldpb.ChannelType_ENTITY, nil)
+		assert.IsType(t, &HierarchicalEntityGroupController{}, ch.entityController)
+		t.Cleanup(func() {
+			ch.removing = 1
+		})
+		return ch
+	}
+
+	charA := EntityId(101)
+	pcA := EntityId(102)
+	psA := EntityId(103)
+	chA := createEntity(charA)
+	assert.Equal(t, []EntityId{charA}, chA.entityController.GetHandoverEntities())
+
+	// The PlayerController and PlayerState are attached to Character A
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{charA, pcA, psA}))
+	assert.ElementsMatch(t, []EntityId{charA, pcA, psA}, chA.entityController.GetHandoverEntities())
+
+	// Character A gets into the vehicle, the whole subtree is handed over with the vehicle
+	vehicle := EntityId(104)
+	chV := createEntity(vehicle)
+	assert.NoError(t, chV.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{vehicle, charA}))
+	assert.Empty(t, chA.entityController.GetHandoverEntities(), "Character A is attached to the vehicle, should not handover by itself")
+	assert.ElementsMatch(t, []EntityId{vehicle, charA, pcA, psA}, chV.entityController.GetHandoverEntities())
+
+	// Attaching the vehicle to its descendant makes a cycle
+	assert.Error(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{vehicle}))
+
+	// Locking Character A locks the whole tree
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{charA}))
+	assert.Empty(t, chV.entityController.GetHandoverEntities())
+	assert.NoError(t, chA.entityController.RemoveFromGroup(channeldpb.EntityGroupType_LOCK, []EntityId{charA}))
+
+	// Locking the vehicle locks its passengers, until they get off
+	assert.NoError(t, chV.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{vehicle}))
+	assert.Empty(t, chV.entityController.GetHandoverEntities())
+
+	// Only the entity itself or its descendants can be detached
+	assert.Error(t, chA.entityController.RemoveFromGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{vehicle}))
+
+	// Character A gets off the vehicle, with its PlayerController and PlayerState still attached
+	assert.NoError(t, chA.entityController.RemoveFromGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{charA}))
+	assert.ElementsMatch(t, []EntityId{charA, pcA, psA}, chA.entityController.GetHandoverEntities())
+	assert.Empty(t, chV.entityController.GetHandoverEntities())
+	assert.NoError(t, chV.entityController.RemoveFromGroup(channeldpb.EntityGroupType_LOCK, []EntityId{vehicle}))
+	assert.Equal(t, []EntityId{vehicle}, chV.entityController.GetHandoverEntities())
+
+	// Character B picks up the weapon held by Character A
+	weapon := EntityId(105)
+	charB := EntityId(106)
+	chB := createEntity(charB)
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{weapon}))
+	assert.NoError(t, chB.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{weapon}))
+	assert.ElementsMatch(t, []EntityId{charA, pcA, psA}, chA.entityController.GetHandoverEntities())
+	assert.ElementsMatch(t, []EntityId{charB, weapon}, chB.entityController.GetHandoverEntities())
+
+	// Removing Character A's channel leaves the PlayerController and PlayerState independent
+	chA.entityController.Uninitialize(chA)
+	assert.NotContains(t, entityTree.parents, pcA)
+	assert.NotContains(t, entityTree.parents, psA)
+	assert.NotContains(t, entityTree.children, charA)
+}
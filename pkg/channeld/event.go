@@ -17,6 +17,8 @@ type AuthEventData struct {
 	AuthResult            channeldpb.AuthResultMessage_AuthResult
 	Connection            ConnectionInChannel
 	PlayerIdentifierToken string
+	// The tags resolved by the AuthTagsProvider. Nil if the auth failed or there's no AuthTagsProvider.
+	AuthTags map[string]string
 }
 
 var Event_AuthComplete = &Event[AuthEventData]{}
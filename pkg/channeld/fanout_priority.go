@@ -0,0 +1,167 @@
+package channeld
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+)
+
+// Defines the fan-out interval and the priority of an entity channel for the subscribers within the distance.
+// Similar to the spatial damping, but based on the distance between the subscriber's tracked position and the entity.
+type EntityFanOutPrioritySettings struct {
+	// The max distance (3D) between the subscriber's tracked position and the entity's SpatialInfo.
+	MaxDistance float64
+	// Overrides the FanOutIntervalMs of the subscription.
+	FanOutIntervalMs uint32
+	// 0-1. The higher the priority is, the more of the connection's fan-out budget the entity can use.
+	// When the budget is running low, only the entities with the highest priority can be fanned out.
+	Priority float64
+}
+
+// The lowest priority that a fan-out can get. The priority increases with the waiting time, so the entities with zero priority won't be starved.
+const minFanOutPriority = 0.01
+
+// Returns the fan-out interval and the priority of the channel for the subscriber.
+// If the channel is not an entity channel, or the distance can't be calculated, the subscription's FanOutIntervalMs and the highest priority are returned.
+func (ch *Channel) getFanOutPriority(conn ConnectionInChannel, cs *ChannelSubscription) (uint32, float64) {
+	if ch.channelType != channeldpb.ChannelType_ENTITY {
+		return *cs.options.FanOutIntervalMs, 1
+	}
+
+	prioritySettings := GlobalSettings.GetChannelSettings(ch.channelType).EntityFanOutPriorities
+	if len(prioritySettings) == 0 {
+		return *cs.options.FanOutIntervalMs, 1
+	}
+
+	c, ok := conn.(*Connection)
+	if !ok {
+		return *cs.options.FanOutIntervalMs, 1
+	}
+	trackedPos := c.GetTrackedPosition()
+	if trackedPos == nil {
+		return *cs.options.FanOutIntervalMs, 1
+	}
+
+	// The position cached in the channel's tick, so the channel data doesn't need to be read for every subscriber
+	entityPos := ch.GetSpatialInfo()
+	if entityPos == nil {
+		return *cs.options.FanOutIntervalMs, 1
+	}
+
+	dist := trackedPos.Dist3D(entityPos)
+	for _, s := range prioritySettings {
+		if dist <= s.MaxDistance {
+			return s.FanOutIntervalMs, s.Priority
+		}
+	}
+
+	// Out of the range of all the settings
+	return *cs.options.FanOutIntervalMs, 0
+}
+
+// The bandwidth budget of a connection for the entity channels' fan-out, in bytes per second.
+// As each entity channel runs in its own goroutine, the budget is shared with a lock.
+type fanOutBudget struct {
+	lock        sync.Mutex
+	bytesPerSec float64
+	// The available bytes. Can be negative as the size of an update is only known after it's sent.
+	tokens         float64
+	lastRefillTime time.Time
+}
+
+func newFanOutBudget(bytesPerSec int) *fanOutBudget {
+	return &fanOutBudget{
+		bytesPerSec:    float64(bytesPerSec),
+		tokens:         float64(bytesPerSec),
+		lastRefillTime: time.Now(),
+	}
+}
+
+func (b *fanOutBudget) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefillTime).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(b.bytesPerSec, b.tokens+elapsed*b.bytesPerSec)
+	b.lastRefillTime = now
+}
+
+// Returns true if an update of the estimated size and the priority can be sent.
+// An update with priority P can only use the budget above (1-P) of the capacity, so the highest priority entities are updated first.
+func (b *fanOutBudget) tryAcquire(now time.Time, estimatedSize int, priority float64) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill(now)
+	reserved := b.bytesPerSec * (1 - math.Min(1, priority))
+	return b.tokens-float64(estimatedSize) >= reserved
+}
+
+func (b *fanOutBudget) consume(size int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.tokens -= float64(size)
+}
+
+// Returns true if the fan-out to the connection can happen now. Only the entity channels are limited by the connection's fan-out budget.
+// The longer the fan-out has been postponed, the higher priority it gets.
+func (ch *Channel) acquireFanOutBudget(foc *fanOutConnection, t ChannelTime, nextFanOutTime ChannelTime, fanOutIntervalMs uint32, priority float64) bool {
+	if ch.channelType != channeldpb.ChannelType_ENTITY {
+		return true
+	}
+
+	c, ok := foc.conn.(*Connection)
+	if !ok || c.entityFanOutBudget == nil {
+		return true
+	}
+
+	// Starvation prevention: the priority is doubled after the fan-out has been postponed for another interval.
+	// The interval is at least one tick (or 1ms), so the fan-outs without the interval are also boosted.
+	intervalMs := math.Max(float64(fanOutIntervalMs), math.Max(float64(ch.tickInterval)/float64(time.Millisecond), 1))
+	waitingMs := math.Max(float64(t-nextFanOutTime)/float64(time.Millisecond), 0)
+	priority = math.Max(priority, minFanOutPriority) * (1 + waitingMs/intervalMs)
+
+	return c.entityFanOutBudget.tryAcquire(time.Now(), foc.lastFanOutSize, priority)
+}
+
+// Converts the query to the position to track for the connection. Returns nil if the query has no position.
+func getQueryCenter(query *channeldpb.SpatialInterestQuery) *common.SpatialInfo {
+	var center *channeldpb.SpatialInfo
+	if query.GetSphereAOI() != nil {
+		center = query.GetSphereAOI().GetCenter()
+	} else if query.GetBoxAOI() != nil {
+		center = query.GetBoxAOI().GetCenter()
+	} else if query.GetConeAOI() != nil {
+		center = query.GetConeAOI().GetCenter()
+	} else if len(query.GetSpotsAOI().GetSpots()) > 0 {
+		center = query.GetSpotsAOI().GetSpots()[0]
+	}
+
+	if center == nil {
+		return nil
+	}
+	return &common.SpatialInfo{X: center.X, Y: center.Y, Z: center.Z}
+}
+
+// Sets the position that is used for calculating the distance-based fan-out priority of the entity channels.
+// The position is updated automatically by the UpdateSpatialInterestMessage.
+func (c *Connection) SetTrackedPosition(pos *common.SpatialInfo) {
+	c.trackedPositionLock.Lock()
+	defer c.trackedPositionLock.Unlock()
+	c.trackedPosition = pos
+}
+
+// Returns a copy of the connection's tracked position, or nil if not set.
+func (c *Connection) GetTrackedPosition() *common.SpatialInfo {
+	c.trackedPositionLock.RLock()
+	defer c.trackedPositionLock.RUnlock()
+	if c.trackedPosition == nil {
+		return nil
+	}
+	pos := *c.trackedPosition
+	return &pos
+}
@@ -0,0 +1,137 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEntityDataWithSpatialInfo struct {
+	*testpb.TestChannelDataMessage
+	spatialInfo *common.SpatialInfo
+}
+
+func (d *testEntityDataWithSpatialInfo) GetSpatialInfo() *common.SpatialInfo {
+	return d.spatialInfo
+}
+
+func TestFanOutBudget(t *testing.T) {
+	now := time.Now()
+	b := newFanOutBudget(1000)
+	b.lastRefillTime = now
+
+	assert.True(t, b.tryAcquire(now, 100, 1))
+	b.consume(900)
+	assert.True(t, b.tryAcquire(now, 50, 1))
+	// Half of the budget is reserved for the higher priority
+	assert.False(t, b.tryAcquire(now, 50, 0.5))
+
+	// Refilled, but no more than the capacity
+	now = now.Add(time.Second * 2)
+	assert.True(t, b.tryAcquire(now, 100, 0.5))
+	assert.EqualValues(t, 1000, b.tokens)
+	assert.False(t, b.tryAcquire(now, 1100, 1))
+}
+
+func TestEntityFanOutPriority(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	oldSettings, exists := GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY]
+	defer func() {
+		if exists {
+			GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = oldSettings
+		} else {
+			delete(GlobalSettings.ChannelSettings, channeldpb.ChannelType_ENTITY)
+		}
+	}()
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = ChannelSettingsType{
+		TickIntervalMs:          10,
+		DefaultFanOutIntervalMs: 100,
+		EntityFanOutPriorities: []EntityFanOutPrioritySettings{
+			{MaxDistance: 10, FanOutIntervalMs: 20, Priority: 1},
+			{MaxDistance: 50, FanOutIntervalMs: 50, Priority: 0.5},
+		},
+	}
+
+	serverConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+	ch := createChannelWithId(GlobalSettings.EntityChannelIdStart+1, channeldpb.ChannelType_ENTITY, serverConn)
+	// Stop the channel.Tick() goroutine
+	ch.removing = 1
+	entityData := &testEntityDataWithSpatialInfo{
+		TestChannelDataMessage: &testpb.TestChannelDataMessage{},
+		spatialInfo:            &common.SpatialInfo{X: 0, Z: 0},
+	}
+	ch.data = &ChannelData{msg: entityData}
+	ch.updateSpatialInfo()
+	cs := &ChannelSubscription{options: *defaultSubOptions(channeldpb.ChannelType_ENTITY)}
+
+	// No tracked position
+	intervalMs, priority := ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 100, intervalMs)
+	assert.EqualValues(t, 1, priority)
+
+	clientConn.SetTrackedPosition(&common.SpatialInfo{X: 3, Z: 4})
+	intervalMs, priority = ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 20, intervalMs)
+	assert.EqualValues(t, 1, priority)
+
+	// The cached position is not updated until the channel's tick
+	entityData.spatialInfo = &common.SpatialInfo{X: 30, Z: 40}
+	intervalMs, priority = ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 20, intervalMs)
+	ch.updateSpatialInfo()
+	intervalMs, priority = ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 50, intervalMs)
+	assert.EqualValues(t, 0.5, priority)
+
+	// The distance is in 3D
+	entityData.spatialInfo = &common.SpatialInfo{X: 3, Y: 30, Z: 4}
+	ch.updateSpatialInfo()
+	intervalMs, priority = ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 50, intervalMs)
+	assert.EqualValues(t, 0.5, priority)
+
+	// Out of range
+	entityData.spatialInfo = &common.SpatialInfo{X: 300, Z: 400}
+	ch.updateSpatialInfo()
+	intervalMs, priority = ch.getFanOutPriority(clientConn, cs)
+	assert.EqualValues(t, 100, intervalMs)
+	assert.EqualValues(t, 0, priority)
+
+	// The budget only applies to the entity channels
+	clientConn.entityFanOutBudget = newFanOutBudget(1000)
+	foc := &fanOutConnection{conn: clientConn, lastFanOutSize: 600}
+	assert.False(t, ch.acquireFanOutBudget(foc, 0, 0, 100, 0.5))
+	assert.True(t, globalChannel.acquireFanOutBudget(foc, 0, 0, 100, 0.5))
+	// The priority increases with the waiting time
+	assert.True(t, ch.acquireFanOutBudget(foc, ChannelTime(100*time.Millisecond), 0, 100, 0.5))
+	// The zero priority without the fan-out interval is also boosted after waiting for long enough
+	assert.False(t, ch.acquireFanOutBudget(foc, 0, 0, 0, 0))
+	assert.True(t, ch.acquireFanOutBudget(foc, ChannelTime(time.Second), 0, 0, 0))
+}
+
+func TestGetQueryCenter(t *testing.T) {
+	assert.Nil(t, getQueryCenter(nil))
+	assert.Nil(t, getQueryCenter(&channeldpb.SpatialInterestQuery{}))
+
+	center := getQueryCenter(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 1, Y: 2, Z: 3},
+			Radius: 10,
+		},
+	})
+	assert.Equal(t, &common.SpatialInfo{X: 1, Y: 2, Z: 3}, center)
+
+	center = getQueryCenter(&channeldpb.SpatialInterestQuery{
+		SpotsAOI: &channeldpb.SpatialInterestQuery_SpotsAOI{
+			Spots: []*channeldpb.SpatialInfo{{X: 4, Y: 5, Z: 6}},
+		},
+	})
+	assert.Equal(t, &common.SpatialInfo{X: 4, Y: 5, Z: 6}, center)
+}
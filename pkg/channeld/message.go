@@ -1,11 +1,16 @@
 package channeld
 
 import (
+	"errors"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/metaworking/channeld/pkg/channeldpb"
 	"github.com/metaworking/channeld/pkg/common"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 // The context of a message for both sending and receiving
@@ -50,11 +55,16 @@ var MessageMap = map[channeldpb.MessageType]*messageMapEntry{
 	// CREATE_CHANNEL and CREATE_SPATIAL_CHANNEL shared the same message structure and handler
 	channeldpb.MessageType_CREATE_SPATIAL_CHANNEL:    {&channeldpb.CreateChannelMessage{}, handleCreateChannel},
 	channeldpb.MessageType_QUERY_SPATIAL_CHANNEL:     {&channeldpb.QuerySpatialChannelMessage{}, handleQuerySpatialChannel},
+	channeldpb.MessageType_QUERY_SPATIAL_ENTITIES:    {&channeldpb.QuerySpatialEntitiesMessage{}, handleQuerySpatialEntities},
+	channeldpb.MessageType_ALLOCATE_ENTITY_IDS:       {&channeldpb.AllocateEntityIdsMessage{}, handleAllocateEntityIds},
+	channeldpb.MessageType_SET_ENTITY_DORMANCY:       {&channeldpb.SetEntityDormancyMessage{}, handleSetEntityDormancy},
 	channeldpb.MessageType_DEBUG_GET_SPATIAL_REGIONS: {&channeldpb.DebugGetSpatialRegionsMessage{}, handleGetSpatialRegionsMessage},
 	channeldpb.MessageType_UPDATE_SPATIAL_INTEREST:   {&channeldpb.UpdateSpatialInterestMessage{}, handleUpdateSpatialInterest},
 	channeldpb.MessageType_CREATE_ENTITY_CHANNEL:     {&channeldpb.CreateEntityChannelMessage{}, handleCreateEntityChannel},
 	channeldpb.MessageType_ENTITY_GROUP_ADD:          {&channeldpb.AddEntityGroupMessage{}, handleAddEntityGroup},
 	channeldpb.MessageType_ENTITY_GROUP_REMOVE:       {&channeldpb.RemoveEntityGroupMessage{}, handleRemoveEntityGroup},
+	channeldpb.MessageType_GET_CHANNEL_DATA:          {&channeldpb.GetChannelDataMessage{}, handleGetChannelData},
+	channeldpb.MessageType_FETCH_CHANNEL_DATA:        {&channeldpb.FetchChannelDataMessage{}, handleFetchChannelData},
 }
 
 func RegisterMessageHandler(msgType uint32, msg common.Message, handler MessageHandlerFunc) {
@@ -180,11 +190,12 @@ func HandleServerToClientUserMessage(ctx MessageContext) {
 				ctx.Connection.Logger().Warn("BroadcastType_ADJACENT_CHANNELS only works for Spatial channel")
 				return
 			}
-			if spatialController == nil {
+			spatialCtl := GetSpatialControllerByChannelId(ctx.Channel.id)
+			if spatialCtl == nil {
 				ctx.Connection.Logger().Error("spatial controller doesn't exist")
 				return
 			}
-			channelIds, err := spatialController.GetAdjacentChannels(ctx.Channel.id)
+			channelIds, err := spatialCtl.GetAdjacentChannels(ctx.Channel.id)
 			if err != nil {
 				ctx.Connection.Logger().Error("failed to retrieve spatial regions", zap.Error(err))
 				return
@@ -244,8 +255,7 @@ func handleAuth(ctx MessageContext) {
 	}
 	//log.Printf("Auth PIT: %s, LT: %s\n", msg.PlayerIdentifierToken, msg.LoginToken)
 
-	_, banned := pitBlacklist[msg.PlayerIdentifierToken]
-	if banned {
+	if bans.IsPITBanned(msg.PlayerIdentifierToken) {
 		securityLogger.Info("refused authentication of banned PIT", zap.String("pit", msg.PlayerIdentifierToken))
 		ctx.Connection.Close()
 		return
@@ -258,28 +268,38 @@ func handleAuth(ctx MessageContext) {
 
 	authResult := channeldpb.AuthResultMessage_SUCCESSFUL
 	if ctx.Connection.GetConnectionType() == channeldpb.ConnectionType_SERVER && GlobalSettings.ServerBypassAuth {
-		onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken)
+		onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken, nil)
 	} else if authProvider != nil {
 		go func() {
-			authResult, err := authProvider.DoAuth(ctx.Connection.Id(), msg.PlayerIdentifierToken, msg.LoginToken)
+			var authResult channeldpb.AuthResultMessage_AuthResult
+			var authTags map[string]string
+			var err error
+			if tagsProvider, ok := authProvider.(AuthTagsProvider); ok {
+				authResult, authTags, err = tagsProvider.DoAuthWithTags(ctx.Connection.Id(), msg.PlayerIdentifierToken, msg.LoginToken)
+			} else {
+				authResult, err = authProvider.DoAuth(ctx.Connection.Id(), msg.PlayerIdentifierToken, msg.LoginToken)
+			}
 			if err != nil {
 				ctx.Connection.Logger().Error("failed to do auth", zap.Error(err))
 				ctx.Connection.Close()
 			} else {
-				onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken)
+				onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken, authTags)
 			}
 		}()
 	} else {
-		onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken)
+		onAuthComplete(ctx, authResult, msg.PlayerIdentifierToken, nil)
 	}
 }
 
-func onAuthComplete(ctx MessageContext, authResult channeldpb.AuthResultMessage_AuthResult, pit string) {
+func onAuthComplete(ctx MessageContext, authResult channeldpb.AuthResultMessage_AuthResult, pit string, authTags map[string]string) {
 	if ctx.Connection.IsClosing() {
 		return
 	}
 
 	if authResult == channeldpb.AuthResultMessage_SUCCESSFUL {
+		if conn, ok := ctx.Connection.(*Connection); ok && authTags != nil {
+			conn.SetAuthTags(authTags)
+		}
 		ctx.Connection.OnAuthenticated(pit)
 	}
 
@@ -300,6 +320,7 @@ func onAuthComplete(ctx MessageContext, authResult channeldpb.AuthResultMessage_
 		AuthResult:            authResult,
 		Connection:            ctx.Connection,
 		PlayerIdentifierToken: pit,
+		AuthTags:              authTags,
 	})
 }
 
@@ -336,6 +357,26 @@ func handleCreateChannel(ctx MessageContext) {
 		handleCreateSpatialChannel(ctx, msg)
 		return
 	} else {
+		var world *SpatialWorld
+		if msg.WorldId != "" {
+			if msg.ChannelType != channeldpb.ChannelType_SUBWORLD {
+				ctx.Connection.Logger().Error("illegal attemp to own the spatial world with non-SUBWORLD channel", zap.String("worldId", msg.WorldId))
+				return
+			}
+			world = GetSpatialWorld(msg.WorldId)
+			if world == nil {
+				ctx.Connection.Logger().Error("failed to create channel as the spatial world doesn't exist", zap.String("worldId", msg.WorldId))
+				return
+			}
+			if world.GetOwnerChannelId() != 0 {
+				ctx.Connection.Logger().Error("failed to create channel as the spatial world is already owned",
+					zap.String("worldId", msg.WorldId),
+					zap.Uint32("ownerChannelId", uint32(world.GetOwnerChannelId())),
+				)
+				return
+			}
+		}
+
 		newChannel, err = CreateChannel(msg.ChannelType, ctx.Connection)
 		if err != nil {
 			ctx.Connection.Logger().Error("failed to create channel",
@@ -345,9 +386,18 @@ func handleCreateChannel(ctx MessageContext) {
 			return
 		}
 		newChannel.Logger().Info("created channel with owner", zap.Uint32("ownerConnId", uint32(newChannel.GetOwner().Id())))
+
+		if world != nil {
+			if err := world.setOwnerChannel(newChannel); err != nil {
+				newChannel.Logger().Error("failed to own the spatial world", zap.Error(err))
+			} else {
+				newChannel.Logger().Info("owned the spatial world", zap.String("worldId", msg.WorldId))
+			}
+		}
 	}
 
 	newChannel.metadata = msg.Metadata
+	newChannel.roleACL = roleACLFromProto(msg.RoleACL)
 	if msg.Data != nil {
 		dataMsg, err := msg.Data.UnmarshalNew()
 		if err != nil {
@@ -408,6 +458,7 @@ func handleRemoveChannel(ctx MessageContext) {
 			zap.Uint32("channelId", uint32(channelToRemove.id)),
 			zap.Uint32("ownerConnId", ownerConnId),
 			zap.Error(err))
+		sendAccessDenied(ctx, uint32(channelToRemove.id), accessDeniedReason(err))
 		return
 	}
 
@@ -494,14 +545,16 @@ func handleSubToChannel(ctx MessageContext) {
 		return
 	}
 
+	// Subscribing the connection itself is only restricted by the role-based rules
 	hasAccess, err := ctx.Channel.CheckACL(ctx.Connection, ChannelAccessType_Sub)
-	if connToSub.Id() != ctx.Connection.Id() && !hasAccess {
+	if !hasAccess && (connToSub.Id() != ctx.Connection.Id() || err == ErrRoleDenied) {
 		ctx.Connection.Logger().Warn("connection doesn't have access to sub connection to this channel",
 			zap.Uint32("subConnId", msg.ConnId),
 			zap.String("channelType", ctx.Channel.channelType.String()),
 			zap.Uint32("channelId", uint32(ctx.Channel.id)),
 			zap.Error(err),
 		)
+		sendAccessDenied(ctx, uint32(ctx.Channel.id), accessDeniedReason(err))
 		return
 	}
 
@@ -562,6 +615,7 @@ func handleUnsubFromChannel(ctx MessageContext) {
 			zap.Uint32("channelId", uint32(ctx.Channel.id)),
 			zap.Error(accessErr),
 		)
+		sendAccessDenied(ctx, uint32(ctx.Channel.id), accessDeniedReason(accessErr))
 		return
 	}
 
@@ -594,6 +648,15 @@ func handleUnsubFromChannel(ctx MessageContext) {
 }
 
 func handleChannelDataUpdate(ctx MessageContext) {
+	// The role-based rules can only deny the update. An allowing rule doesn't grant the write access.
+	if roleAllowed, roleMatched := ctx.Channel.checkRoleACL(ctx.Connection, ChannelAccessType_DataWrite, 0); roleMatched && !roleAllowed {
+		ctx.Connection.Logger().Warn("attempt to update channel data but the role is denied",
+			zap.String("channelType", ctx.Channel.channelType.String()),
+			zap.Uint32("channelId", uint32(ctx.Channel.id)),
+		)
+		sendAccessDenied(ctx, uint32(ctx.Channel.id), channeldpb.AccessDeniedMessage_ROLE_DENIED)
+		return
+	}
 	// Only channel owner or writable subsciptors can update the data
 	if ownerConn := ctx.Channel.GetOwner(); ownerConn != ctx.Connection {
 		cs := ctx.Channel.subscribedConnections[ctx.Connection]
@@ -606,6 +669,7 @@ func handleChannelDataUpdate(ctx MessageContext) {
 					zap.String("channelType", ctx.Channel.channelType.String()),
 					zap.Uint32("channelId", uint32(ctx.Channel.id)),
 				)
+				sendAccessDenied(ctx, uint32(ctx.Channel.id), channeldpb.AccessDeniedMessage_NO_WRITE_ACCESS)
 				return
 			}
 		}
@@ -630,6 +694,23 @@ func handleChannelDataUpdate(ctx MessageContext) {
 		return
 	}
 
+	if ctx.Channel.Data().needsConflictResolution() {
+		timestamp := ctx.Channel.updateTimestamp(msg.Timestamp, ctx.arrivalTime)
+		rejectedFields, hasCounterUpdate := ctx.Channel.Data().resolveConflicts(updateMsg, ctx.Connection.Id(), ctx.Channel.GetOwner() == ctx.Connection, timestamp)
+		if len(rejectedFields) > 0 {
+			ctx.Connection.Logger().Debug("rejected channel data update fields",
+				zap.String("channelType", ctx.Channel.channelType.String()),
+				zap.Uint32("channelId", uint32(ctx.Channel.id)),
+				zap.Strings("fields", rejectedFields),
+			)
+			ctx.Channel.sendUpdateRejected(ctx.Connection, rejectedFields)
+		}
+		// Nothing left to merge. The counter update is always fanned out, even if the counter reaches zero.
+		if !hasAnyField(updateMsg) && !hasCounterUpdate {
+			return
+		}
+	}
+
 	if ctx.Channel.spatialNotifier != nil {
 		if ctx.Connection.GetConnectionType() == channeldpb.ConnectionType_CLIENT {
 			ctx.Channel.SetDataUpdateConnId(ctx.Connection.Id())
@@ -638,6 +719,200 @@ func handleChannelDataUpdate(ctx MessageContext) {
 		}
 	}
 	ctx.Channel.Data().OnUpdate(updateMsg, ctx.arrivalTime, ctx.Connection.Id(), ctx.Channel.spatialNotifier)
+	atomic.AddUint64(&ctx.Channel.dataUpdateCount, 1)
+	ctx.Channel.wake()
+}
+
+func handleGetChannelData(ctx MessageContext) {
+	msg, ok := ctx.Msg.(*channeldpb.GetChannelDataMessage)
+	if !ok {
+		ctx.Connection.Logger().Error("message is not a GetChannelDataMessage, will not be handled.")
+		return
+	}
+
+	// The result without the data is sent if the data can't be got, so the sender won't wait for it forever.
+	result := &channeldpb.GetChannelDataResultMessage{ChannelId: uint32(ctx.Channel.id)}
+	defer func() {
+		ctx.Msg = result
+		ctx.Connection.Send(ctx)
+	}()
+
+	// The same access rule as FETCH_CHANNEL_DATA
+	if err := ctx.Channel.CheckDataReadAccess(ctx.Connection); err != nil {
+		ctx.Connection.Logger().Warn("connection doesn't have access to get the channel data",
+			zap.String("channelType", ctx.Channel.channelType.String()),
+			zap.Uint32("channelId", uint32(ctx.Channel.id)),
+			zap.Error(err),
+		)
+		return
+	}
+
+	channelData := ctx.Channel.Data()
+	if channelData == nil || channelData.msg == nil {
+		ctx.Connection.Logger().Warn("failed to get the channel data as it's not initialized",
+			zap.String("channelType", ctx.Channel.channelType.String()),
+			zap.Uint32("channelId", uint32(ctx.Channel.id)),
+		)
+		return
+	}
+
+	var dataMsg common.ChannelDataMessage
+	var msgIndex uint64
+	var t ChannelTime
+	var err error
+	if msg.Timestamp != nil {
+		dataMsg, msgIndex, t, err = channelData.GetHistoryByTime(ctx.Channel.UnixMilliToChannelTime(*msg.Timestamp))
+	} else if msg.MsgIndex != nil && *msg.MsgIndex < channelData.msgIndex {
+		dataMsg, msgIndex, t, err = channelData.GetHistoryByIndex(*msg.MsgIndex)
+	} else {
+		// The current state
+		dataMsg = channelData.msg
+		msgIndex = channelData.msgIndex
+		t = ctx.Channel.GetTime()
+	}
+
+	if err != nil {
+		ctx.Connection.Logger().Warn("failed to get the channel data from the history",
+			zap.String("channelType", ctx.Channel.channelType.String()),
+			zap.Uint32("channelId", uint32(ctx.Channel.id)),
+			zap.Error(err),
+		)
+		return
+	}
+
+	anyData, err := anypb.New(dataMsg)
+	if err != nil {
+		ctx.Channel.Logger().Error("failed to marshal channel data", zap.Error(err))
+		return
+	}
+
+	result.Data = anyData
+	result.MsgIndex = msgIndex
+	result.Timestamp = ctx.Channel.ChannelTimeToUnixMilli(t)
+}
+
+var ErrChannelNotFound = errors.New("channel doesn't exist")
+
+const (
+	// The max number of channels to fetch in one FetchChannelDataMessage.
+	MaxFetchChannelIds = 256
+	// How long to wait for the channels to be fetched in their goroutines.
+	// A channel removed before the fetch runs never reports back, so it's counted as not found after the timeout.
+	fetchChannelDataTimeout = time.Second
+)
+
+// Fetches the data of the channels in their goroutines. onFetched is called for each channel, and onDone is called once
+// after all the channels have been fetched or fetchChannelDataTimeout. Both callbacks are called in any goroutine, but not concurrently.
+func fetchChannelsData(conn ConnectionInChannel, channelIds []common.ChannelId, fieldMasks []string, currentCh *Channel,
+	onFetched func(channelId common.ChannelId, data *anypb.Any, err error), onDone func()) {
+
+	lock := sync.Mutex{}
+	pending := make(map[common.ChannelId]struct{}, len(channelIds))
+	for _, channelId := range channelIds {
+		pending[channelId] = struct{}{}
+	}
+	var timer *time.Timer
+	finish := func(channelId common.ChannelId, data *anypb.Any, err error) {
+		lock.Lock()
+		if _, exists := pending[channelId]; !exists {
+			// Already fetched or timed out
+			lock.Unlock()
+			return
+		}
+		delete(pending, channelId)
+		onFetched(channelId, data, err)
+		done := len(pending) == 0
+		if done && timer != nil {
+			timer.Stop()
+		}
+		lock.Unlock()
+
+		if done {
+			onDone()
+		}
+	}
+
+	lock.Lock()
+	timer = time.AfterFunc(fetchChannelDataTimeout, func() {
+		lock.Lock()
+		if len(pending) == 0 {
+			lock.Unlock()
+			return
+		}
+		for channelId := range pending {
+			onFetched(channelId, nil, ErrChannelNotFound)
+		}
+		pending = make(map[common.ChannelId]struct{})
+		lock.Unlock()
+		onDone()
+	})
+	lock.Unlock()
+
+	for _, channelId := range channelIds {
+		ch := GetChannel(channelId)
+		if ch == nil || ch.IsRemoving() {
+			finish(channelId, nil, ErrChannelNotFound)
+			continue
+		}
+
+		if ch == currentCh {
+			data, err := ch.FetchData(conn, fieldMasks)
+			finish(channelId, data, err)
+		} else {
+			// Read the channel data in the channel's goroutine
+			ch.Execute(func(ch *Channel) {
+				data, err := ch.FetchData(conn, fieldMasks)
+				finish(ch.id, data, err)
+			})
+		}
+	}
+}
+
+func handleFetchChannelData(ctx MessageContext) {
+	msg, ok := ctx.Msg.(*channeldpb.FetchChannelDataMessage)
+	if !ok {
+		ctx.Connection.Logger().Error("message is not a FetchChannelDataMessage, will not be handled.")
+		return
+	}
+
+	if len(msg.ChannelIds) > MaxFetchChannelIds {
+		ctx.Connection.Logger().Warn("too many channels to fetch, will not be handled.",
+			zap.Int("channelNum", len(msg.ChannelIds)),
+			zap.Int("maxChannelNum", MaxFetchChannelIds),
+		)
+		return
+	}
+
+	channelIds := make([]common.ChannelId, len(msg.ChannelIds))
+	for i, channelId := range msg.ChannelIds {
+		channelIds[i] = common.ChannelId(channelId)
+	}
+	if len(channelIds) == 0 {
+		channelIds = []common.ChannelId{ctx.Channel.id}
+	}
+
+	result := &channeldpb.FetchChannelDataResultMessage{}
+	fetchChannelsData(ctx.Connection, channelIds, msg.DataFieldMasks, ctx.Channel,
+		func(channelId common.ChannelId, data *anypb.Any, err error) {
+			if err != nil {
+				ctx.Connection.Logger().Debug("failed to fetch channel data",
+					zap.Uint32("channelId", uint32(channelId)),
+					zap.Error(err),
+				)
+				result.FailedChannelIds = append(result.FailedChannelIds, uint32(channelId))
+			} else {
+				result.Results = append(result.Results, &channeldpb.FetchChannelDataResultMessage_ChannelDataResult{
+					ChannelId: uint32(channelId),
+					Data:      data,
+				})
+			}
+		},
+		// The result is sent after all the channels have been fetched.
+		func() {
+			ctx.Msg = result
+			ctx.Connection.Send(ctx)
+		},
+	)
 }
 
 func handleDisconnect(ctx MessageContext) {
@@ -5,38 +5,9 @@ import (
 	"github.com/metaworking/channeld/pkg/common"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
-type SpatialDampingSettings struct {
-	MaxDistance      uint
-	FanOutIntervalMs uint32
-	DataFieldMasks   []string
-}
-
-var spatialDampingSettings []*SpatialDampingSettings = []*SpatialDampingSettings{
-	{
-		MaxDistance:      0,
-		FanOutIntervalMs: 20,
-	},
-	{
-		MaxDistance:      1,
-		FanOutIntervalMs: 50,
-	},
-	{
-		MaxDistance:      2,
-		FanOutIntervalMs: 100,
-	},
-}
-
-func getSpatialDampingSettings(dist uint) *SpatialDampingSettings {
-	for _, s := range spatialDampingSettings {
-		if dist <= s.MaxDistance {
-			return s
-		}
-	}
-	return nil
-}
-
 // Executed in the spatial channels
 func handleUpdateSpatialInterest(ctx MessageContext) {
 	msg, ok := ctx.Msg.(*channeldpb.UpdateSpatialInterestMessage)
@@ -45,8 +16,9 @@ func handleUpdateSpatialInterest(ctx MessageContext) {
 		return
 	}
 
-	if spatialController == nil {
-		ctx.Connection.Logger().Error("cannot update spatial interest as the spatial controller does not exist")
+	spatialCtl := GetSpatialControllerOfWorld(msg.WorldId)
+	if spatialCtl == nil {
+		ctx.Connection.Logger().Error("cannot update spatial interest as the spatial controller does not exist", zap.String("worldId", msg.WorldId))
 		return
 	}
 
@@ -56,15 +28,37 @@ func handleUpdateSpatialInterest(ctx MessageContext) {
 		return
 	}
 
-	spatialChIds, err := spatialController.QueryChannelIds(msg.Query)
+	if center := getQueryCenter(msg.Query); center != nil {
+		clientConn.SetTrackedPosition(center)
+	}
+
+	if msg.DampingProfile != "" {
+		clientConn.SetSpatialDampingProfile(msg.DampingProfile)
+	}
+	clientConn.spatialInterestMsg.Store(msg)
+
+	if msg.EntityInterest {
+		clientConn.SetEntityInterestQueryInWorld(msg.WorldId, msg.Query)
+	} else {
+		clientConn.SetEntityInterestQuery(nil)
+	}
+
+	spatialChIds, err := spatialCtl.QueryChannelIds(msg.Query)
 	if err != nil {
 		ctx.Connection.Logger().Error("error querying spatial channel ids", zap.Error(err))
 		return
 	}
 
+	dampingSettings := assignSpatialDampingSettings(getSpatialDampingProfile(clientConn.GetSpatialDampingProfile()), spatialChIds, func(chId common.ChannelId) int {
+		if ch := GetChannel(chId); ch != nil {
+			return ch.GetEntityCount()
+		}
+		return 0
+	})
+
 	channelsToSub := make(map[common.ChannelId]*channeldpb.ChannelSubscriptionOptions)
-	for chId, dist := range spatialChIds {
-		dampSettings := getSpatialDampingSettings(dist)
+	for chId := range spatialChIds {
+		dampSettings := dampingSettings[chId]
 		if dampSettings == nil {
 			channelsToSub[chId] = &channeldpb.ChannelSubscriptionOptions{
 				// DataAccess:       Pointer(channeldpb.ChannelDataAccess_NO_ACCESS),
@@ -134,12 +128,13 @@ func handleCreateSpatialChannel(ctx MessageContext, msg *channeldpb.CreateChanne
 		return
 	}
 
-	if spatialController == nil {
-		ctx.Connection.Logger().Error("illegal attemp to create Spatial channel as there's no controller")
+	spatialCtl := GetSpatialControllerOfWorld(msg.WorldId)
+	if spatialCtl == nil {
+		ctx.Connection.Logger().Error("illegal attemp to create Spatial channel as there's no controller", zap.String("worldId", msg.WorldId))
 		return
 	}
 
-	channels, err := spatialController.CreateChannels(ctx)
+	channels, err := spatialCtl.CreateChannels(ctx)
 	if err != nil {
 		ctx.Connection.Logger().Error("failed to create Spatial channel", zap.Error(err))
 		return
@@ -164,7 +159,9 @@ func handleCreateSpatialChannel(ctx MessageContext, msg *channeldpb.CreateChanne
 		ownerConn.Send(ctx)
 	}
 
+	roleACL := roleACLFromProto(msg.RoleACL)
 	for _, newChannel := range channels {
+		newChannel.roleACL = roleACL
 		// Subscribe to channel after creation
 		cs, _ := ctx.Connection.SubscribeToChannel(newChannel, msg.SubOptions)
 		if cs != nil {
@@ -175,7 +172,7 @@ func handleCreateSpatialChannel(ctx MessageContext, msg *channeldpb.CreateChanne
 	ctx.Connection.Logger().Info("created spatial channel(s)", zap.Uint32s("channelIds", resultMsg.SpatialChannelId))
 
 	// Send the regions info upon the spatial channels creation
-	regions, err := spatialController.GetRegions()
+	regions, err := spatialCtl.GetRegions()
 	if err != nil {
 		ctx.Connection.Logger().Error("failed to send Spatial regions info upon the spatial channels creation",
 			zap.Uint32s("channelIds", resultMsg.SpatialChannelId))
@@ -202,23 +199,42 @@ func handleCreateEntityChannel(ctx MessageContext) {
 	}
 
 	entityChId := common.ChannelId(msg.EntityId)
-	if entityChId < GlobalSettings.EntityChannelIdStart {
+	if entityChId == 0 {
+		// Let channeld assign the entity ID
+		entityId, err := entityIds.allocateId()
+		if err != nil {
+			ctx.Connection.Logger().Error("failed to assign the entity ID", zap.Error(err))
+			return
+		}
+		entityChId = common.ChannelId(entityId)
+	} else if entityChId < GlobalSettings.EntityChannelIdStart {
 		ctx.Connection.Logger().Error("illegal attemp to create entity channel with invalid entityId", zap.Uint32("entityId", uint32(entityChId)))
 		return
 	}
 
+	if err := checkEntityIdLease(EntityId(entityChId), ctx.Connection.Id()); err != nil {
+		ctx.Connection.Logger().Error("illegal attemp to create entity channel with the entityId of another connection", zap.Error(err))
+		return
+	}
+
 	if entityCh := GetChannel(entityChId); entityCh != nil && !entityCh.IsRemoving() {
 		// This could happen when the UE server is restarted but the channeld is not.
 		ctx.Connection.Logger().Warn("illegal attemp to create entity channel with duplicated entityId", zap.Uint32("entityId", uint32(entityChId)))
 		return
 	}
 
-	newChannel := createChannelWithId(entityChId, channeldpb.ChannelType_ENTITY, ctx.Connection)
+	// The entity channel created in the spatial channel belongs to the same spatial world
+	worldId := DefaultSpatialWorldId
+	if ctx.Channel.Type() == channeldpb.ChannelType_SPATIAL {
+		worldId = GetSpatialWorldIdByChannelId(ctx.Channel.id)
+	}
+	newChannel := createChannelInWorld(entityChId, channeldpb.ChannelType_ENTITY, ctx.Connection, worldId)
 	newChannel.Logger().Info("created entity channel",
 		zap.Uint32("ownerConnId", uint32(newChannel.GetOwner().Id())),
 	)
 
 	newChannel.metadata = msg.Metadata
+	newChannel.roleACL = roleACLFromProto(msg.RoleACL)
 	if msg.Data != nil {
 		dataMsg, err := msg.Data.UnmarshalNew()
 		if err != nil {
@@ -282,19 +298,10 @@ func handleCreateEntityChannel(ctx MessageContext) {
 	// Should we also send the result to the GLOBAL channel owner?
 
 	if msg.IsWellKnown {
+		templates := msg.WellKnownSubTemplates
 		// Subscribe ALL the connections to the entity channel
 		allConnections.Range(func(_ ConnectionId, conn *Connection) bool {
-			// Ignore the well-known entity for server
-			if conn.GetConnectionType() == channeldpb.ConnectionType_SERVER {
-				return true
-			}
-			/*
-			 */
-
-			// FIXME: different subOptions for different connection?
-			cs, shouldSend := conn.SubscribeToChannel(newChannel, nil)
-			if shouldSend {
-				conn.sendSubscribed(MessageContext{}, newChannel, conn, 0, &cs.options)
+			if subscribeToWellKnownEntity(newChannel, conn, conn.GetAuthTags(), templates, nil) {
 				newChannel.Logger().Debug("subscribed existing connection for the well-known entity", zap.Uint32("connId", uint32(conn.Id())))
 			}
 			return true
@@ -302,18 +309,10 @@ func handleCreateEntityChannel(ctx MessageContext) {
 
 		// Add hook to subscribe the new connection to the entity channel
 		Event_AuthComplete.ListenFor(newChannel, func(data AuthEventData) {
-			// Ignore the well-known entity for server
-			if data.Connection.GetConnectionType() == channeldpb.ConnectionType_SERVER {
-				return
-			}
-
 			if data.AuthResult == channeldpb.AuthResultMessage_SUCCESSFUL {
-				// FIXME: different subOptions for different connection?
 				// Add some delay so the client won't have to spawn the entity immediately after the auth.
-				subOptions := &channeldpb.ChannelSubscriptionOptions{FanOutDelayMs: Pointer(int32(1000))}
-				cs, shouldSend := data.Connection.SubscribeToChannel(newChannel, subOptions)
-				if shouldSend {
-					data.Connection.sendSubscribed(MessageContext{}, newChannel, data.Connection, 0, &cs.options)
+				defaultOptions := &channeldpb.ChannelSubscriptionOptions{FanOutDelayMs: Pointer(int32(1000))}
+				if subscribeToWellKnownEntity(newChannel, data.Connection, data.AuthTags, templates, defaultOptions) {
 					newChannel.Logger().Debug("subscribed new connection for the well-known entity", zap.Uint32("connId", uint32(data.Connection.Id())))
 				}
 			}
@@ -344,14 +343,15 @@ func handleQuerySpatialChannel(ctx MessageContext) {
 		return
 	}
 
-	if spatialController == nil {
-		ctx.Connection.Logger().Error("cannot query spatial channel as the spatial controller does not exist")
+	spatialCtl := GetSpatialControllerOfWorld(msg.WorldId)
+	if spatialCtl == nil {
+		ctx.Connection.Logger().Error("cannot query spatial channel as the spatial controller does not exist", zap.String("worldId", msg.WorldId))
 		return
 	}
 
 	channelIds := make([]uint32, len(msg.SpatialInfo))
 	for i, info := range msg.SpatialInfo {
-		channelId, err := spatialController.GetChannelId(common.SpatialInfo{
+		channelId, err := spatialCtl.GetChannelId(common.SpatialInfo{
 			X: info.X,
 			Y: info.Y,
 			Z: info.Z,
@@ -368,3 +368,71 @@ func handleQuerySpatialChannel(ctx MessageContext) {
 	}
 	ctx.Connection.Send(ctx)
 }
+
+func handleQuerySpatialEntities(ctx MessageContext) {
+	if ctx.Channel != globalChannel {
+		ctx.Connection.Logger().Error("illegal attemp to query spatial entities outside the GLOBAL channel")
+		return
+	}
+
+	msg, ok := ctx.Msg.(*channeldpb.QuerySpatialEntitiesMessage)
+	if !ok {
+		ctx.Connection.Logger().Error("message is not a QuerySpatialEntitiesMessage, will not be handled.")
+		return
+	}
+
+	if ctx.Connection.GetConnectionType() != channeldpb.ConnectionType_SERVER {
+		ctx.Connection.Logger().Error("illegal attemp to query spatial entities from client connection")
+		return
+	}
+
+	entities, err := QuerySpatialEntitiesInWorld(msg.WorldId, msg.Query)
+	if err != nil {
+		ctx.Connection.Logger().Error("failed to query spatial entities", zap.Error(err))
+		return
+	}
+
+	result := &channeldpb.QuerySpatialEntitiesResultMessage{
+		Entities: make([]*channeldpb.QuerySpatialEntitiesResultMessage_EntityResult, len(entities)),
+	}
+	for i, entity := range entities {
+		result.Entities[i] = &channeldpb.QuerySpatialEntitiesResultMessage_EntityResult{
+			EntityId: uint32(entity.EntityId),
+			SpatialInfo: &channeldpb.SpatialInfo{
+				X: entity.SpatialInfo.X,
+				Y: entity.SpatialInfo.Y,
+				Z: entity.SpatialInfo.Z,
+			},
+		}
+	}
+
+	if !msg.WithData || len(entities) == 0 {
+		ctx.Msg = result
+		ctx.Connection.Send(ctx)
+		return
+	}
+
+	entityResults := make(map[common.ChannelId]*channeldpb.QuerySpatialEntitiesResultMessage_EntityResult, len(result.Entities))
+	channelIds := make([]common.ChannelId, len(result.Entities))
+	for i, entityResult := range result.Entities {
+		channelIds[i] = common.ChannelId(entityResult.EntityId)
+		entityResults[channelIds[i]] = entityResult
+	}
+	fetchChannelsData(ctx.Connection, channelIds, msg.DataFieldMasks, ctx.Channel,
+		func(channelId common.ChannelId, data *anypb.Any, err error) {
+			if err != nil {
+				ctx.Connection.Logger().Debug("failed to fetch entity channel data",
+					zap.Uint32("entityId", uint32(channelId)),
+					zap.Error(err),
+				)
+			} else {
+				entityResults[channelId].Data = data
+			}
+		},
+		// The result is sent after all the entity channels have been fetched.
+		func() {
+			ctx.Msg = result
+			ctx.Connection.Send(ctx)
+		},
+	)
+}
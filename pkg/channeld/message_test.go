@@ -98,6 +98,9 @@ func TestMessageHandlers(t *testing.T) {
 		case msgType == channeldpb.MessageType_INVALID:
 		case msgType == channeldpb.MessageType_CHANNEL_DATA_HANDOVER:
 		case msgType == channeldpb.MessageType_SPATIAL_REGIONS_UPDATE:
+		case msgType == channeldpb.MessageType_CHANNEL_DATA_UPDATE_REJECTED:
+		case msgType == channeldpb.MessageType_CHANNEL_OWNER_CHANGED:
+		case msgType == channeldpb.MessageType_ACCESS_DENIED:
 		case value >= int32(channeldpb.MessageType_USER_SPACE_START):
 			continue
 		default:
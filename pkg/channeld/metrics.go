@@ -114,6 +114,37 @@ var connectionClosed = prometheus.NewCounterVec(
 	[]string{"connType"},
 )
 
+var handoverTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "handover_total",
+		Help: "Committed spatial handovers",
+	},
+	[]string{"type"},
+)
+
+var handoverSuppressed = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "handover_suppressed",
+		Help: "Spatial handovers suppressed by the hysteresis or deferred by the entity locks",
+	},
+	[]string{"type", "reason"},
+)
+
+var dormantChannelNum = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "dormant_channel_num",
+		Help: "Number of dormant entity channels",
+	},
+)
+
+var rateLimitedMsgs = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "messages_rate_limited",
+		Help: "Received messages that exceeded the rate limits",
+	},
+	[]string{"connType", "action"},
+)
+
 func InitMetrics() {
 	prometheus.MustRegister(logNum)
 	prometheus.MustRegister(msgReceived)
@@ -129,4 +160,8 @@ func InitMetrics() {
 	prometheus.MustRegister(channelNum)
 	prometheus.MustRegister(channelTickDuration)
 	prometheus.MustRegister(connectionClosed)
+	prometheus.MustRegister(handoverTotal)
+	prometheus.MustRegister(handoverSuppressed)
+	prometheus.MustRegister(dormantChannelNum)
+	prometheus.MustRegister(rateLimitedMsgs)
 }
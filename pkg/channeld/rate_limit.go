@@ -0,0 +1,233 @@
+package channeld
+
+import (
+	"math"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"go.uber.org/zap"
+)
+
+/* Rate limiting
+ * The messages received from a connection are limited by the token buckets configured in GlobalSettings.RateLimits by the connection type:
+ * one for all the messages, and one for each configured message type. Each bucket limits both the message rate and the byte rate.
+ * The limits are enforced before the message is put into the channel's queue. When a message exceeds any limit,
+ * the most severe action of the exceeded limits applies.
+ */
+
+type RateLimitAction string
+
+const (
+	// Discards the message. The default action.
+	RateLimitAction_Drop RateLimitAction = "drop"
+	// Delays the message until the limit allows it, which also holds back reading from the connection.
+	// If the delay is longer than RateLimitSettingsType.MaxThrottleMs, the message is dropped.
+	RateLimitAction_Throttle RateLimitAction = "throttle"
+	// Closes the connection.
+	RateLimitAction_Kick RateLimitAction = "kick"
+	// Closes the connection and bans its IP address. See ban.go.
+	RateLimitAction_Ban RateLimitAction = "ban"
+)
+
+const defaultMaxThrottleDelay = time.Second
+
+func (a RateLimitAction) severity() int {
+	switch a {
+	case RateLimitAction_Throttle:
+		return 1
+	case RateLimitAction_Kick:
+		return 2
+	case RateLimitAction_Ban:
+		return 3
+	}
+	return 0
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+	// The available tokens. Can be negative after a throttled message is consumed.
+	tokens         float64
+	lastRefillTime time.Time
+}
+
+// Returns nil if the rate is 0 (no limit). Burst 0 = one second of the rate.
+func newTokenBucket(rate float64, burst float64, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+	return &tokenBucket{
+		rate:           rate,
+		burst:          burst,
+		tokens:         burst,
+		lastRefillTime: now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefillTime).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	b.lastRefillTime = now
+}
+
+// Returns how long it takes until n tokens are available. n larger than the burst only needs the full burst.
+func (b *tokenBucket) delay(n float64, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.refill(now)
+	n = math.Min(n, b.burst)
+	if b.tokens >= n {
+		return 0
+	}
+	return time.Duration((n - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) consume(n float64, now time.Time) {
+	if b != nil {
+		b.refill(now)
+		b.tokens -= math.Min(n, b.burst)
+	}
+}
+
+type rateLimitBuckets struct {
+	msgs   *tokenBucket
+	bytes  *tokenBucket
+	action RateLimitAction
+}
+
+// Returns nil if the rule has no limit.
+func newRateLimitBuckets(rule RateLimitRule, now time.Time) *rateLimitBuckets {
+	msgs := newTokenBucket(rule.MsgPerSec, rule.MsgBurst, now)
+	bytes := newTokenBucket(rule.BytesPerSec, rule.BytesBurst, now)
+	if msgs == nil && bytes == nil {
+		return nil
+	}
+	action := rule.Action
+	if action == "" {
+		action = RateLimitAction_Drop
+	}
+	return &rateLimitBuckets{msgs: msgs, bytes: bytes, action: action}
+}
+
+// The rate limits of a connection. Only accessed in the connection's receiving goroutine.
+type connRateLimiter struct {
+	total       *rateLimitBuckets
+	byMsgType   map[uint32]*rateLimitBuckets
+	maxThrottle time.Duration
+}
+
+// Returns nil if no limit is configured for the connection type.
+func newConnRateLimiter(t channeldpb.ConnectionType, now time.Time) *connRateLimiter {
+	settings, exists := GlobalSettings.RateLimits[t]
+	if !exists {
+		return nil
+	}
+
+	l := &connRateLimiter{
+		total:       newRateLimitBuckets(settings.Total, now),
+		byMsgType:   make(map[uint32]*rateLimitBuckets),
+		maxThrottle: time.Duration(settings.MaxThrottleMs) * time.Millisecond,
+	}
+	if l.maxThrottle == 0 {
+		l.maxThrottle = defaultMaxThrottleDelay
+	}
+	for msgType, rule := range settings.MsgTypes {
+		if buckets := newRateLimitBuckets(rule, now); buckets != nil {
+			l.byMsgType[msgType] = buckets
+		}
+	}
+	if l.total == nil && len(l.byMsgType) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Returns exceeded = true if the message exceeds any limit, with the most severe action and the longest delay until the limits allow it.
+// The tokens are only consumed if the limits are not exceeded.
+func (l *connRateLimiter) check(msgType uint32, size int, now time.Time) (action RateLimitAction, delay time.Duration, exceeded bool) {
+	buckets := [2]*rateLimitBuckets{l.total, l.byMsgType[msgType]}
+	for _, b := range buckets {
+		if b == nil {
+			continue
+		}
+		d := b.msgs.delay(1, now)
+		if bytesDelay := b.bytes.delay(float64(size), now); bytesDelay > d {
+			d = bytesDelay
+		}
+		if d <= 0 {
+			continue
+		}
+		if !exceeded || b.action.severity() > action.severity() {
+			action = b.action
+		}
+		if d > delay {
+			delay = d
+		}
+		exceeded = true
+	}
+
+	if !exceeded {
+		l.consume(msgType, size, now)
+	}
+	return
+}
+
+func (l *connRateLimiter) consume(msgType uint32, size int, now time.Time) {
+	for _, b := range [2]*rateLimitBuckets{l.total, l.byMsgType[msgType]} {
+		if b != nil {
+			b.msgs.consume(1, now)
+			b.bytes.consume(float64(size), now)
+		}
+	}
+}
+
+// Returns false if the message should be discarded because of the rate limits.
+func (c *Connection) checkRateLimit(mp *channeldpb.MessagePack) bool {
+	if c.IsClosing() {
+		return false
+	}
+
+	action, delay, exceeded := c.rateLimiter.check(mp.MsgType, len(mp.MsgBody), time.Now())
+	if !exceeded {
+		return true
+	}
+
+	if action == RateLimitAction_Throttle && delay > c.rateLimiter.maxThrottle {
+		action = RateLimitAction_Drop
+	}
+	rateLimitedMsgs.WithLabelValues(c.connectionType.String(), string(action)).Inc()
+
+	switch action {
+	case RateLimitAction_Throttle:
+		time.Sleep(delay)
+		c.rateLimiter.consume(mp.MsgType, len(mp.MsgBody), time.Now())
+		return true
+
+	case RateLimitAction_Kick:
+		securityLogger.Info("kicked connection due to exceeding the rate limits",
+			zap.Uint32("connId", uint32(c.id)),
+			zap.Uint32("msgType", mp.MsgType),
+		)
+		c.Close()
+
+	case RateLimitAction_Ban:
+		if addr := c.RemoteAddr(); addr != nil {
+			banIP(GetIP(addr), "exceeding the rate limits")
+		}
+		c.Close()
+
+	default:
+		c.Logger().Debug("dropped message due to exceeding the rate limits",
+			zap.Uint32("msgType", mp.MsgType),
+			zap.Int("size", len(mp.MsgBody)),
+		)
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnRateLimiter(t *testing.T) {
+	oldRateLimits := GlobalSettings.RateLimits
+	defer func() {
+		GlobalSettings.RateLimits = oldRateLimits
+	}()
+
+	updateMsgType := uint32(channeldpb.MessageType_CHANNEL_DATA_UPDATE)
+	subMsgType := uint32(channeldpb.MessageType_SUB_TO_CHANNEL)
+	GlobalSettings.RateLimits = map[channeldpb.ConnectionType]RateLimitSettingsType{
+		channeldpb.ConnectionType_CLIENT: {
+			Total: RateLimitRule{MsgPerSec: 10, BytesPerSec: 1000, Action: RateLimitAction_Throttle},
+			MsgTypes: map[uint32]RateLimitRule{
+				subMsgType: {MsgPerSec: 1, MsgBurst: 2, Action: RateLimitAction_Kick},
+			},
+		},
+	}
+	now := time.Now()
+	assert.Nil(t, newConnRateLimiter(channeldpb.ConnectionType_SERVER, now))
+	l := newConnRateLimiter(channeldpb.ConnectionType_CLIENT, now)
+	assert.NotNil(t, l)
+
+	// The burst of the message type is used up
+	for i := 0; i < 2; i++ {
+		_, _, exceeded := l.check(subMsgType, 10, now)
+		assert.False(t, exceeded)
+	}
+	action, delay, exceeded := l.check(subMsgType, 10, now)
+	assert.True(t, exceeded)
+	assert.Equal(t, RateLimitAction_Kick, action)
+	assert.Equal(t, time.Second, delay)
+
+	// The other message types are still limited by the total only
+	for i := 0; i < 8; i++ {
+		_, _, exceeded := l.check(updateMsgType, 10, now)
+		assert.False(t, exceeded)
+	}
+	action, delay, exceeded = l.check(updateMsgType, 10, now)
+	assert.True(t, exceeded)
+	assert.Equal(t, RateLimitAction_Throttle, action)
+	assert.Equal(t, 100*time.Millisecond, delay)
+
+	// The tokens are refilled over time
+	now = now.Add(100 * time.Millisecond)
+	_, _, exceeded = l.check(updateMsgType, 10, now)
+	assert.False(t, exceeded)
+
+	// The byte rate: 1000 bytes per second
+	now = now.Add(time.Second)
+	_, _, exceeded = l.check(updateMsgType, 900, now)
+	assert.False(t, exceeded)
+	_, delay, exceeded = l.check(updateMsgType, 200, now)
+	assert.True(t, exceeded)
+	assert.Equal(t, 100*time.Millisecond, delay)
+	// The message larger than the burst only needs the full burst
+	now = now.Add(time.Second)
+	_, _, exceeded = l.check(updateMsgType, 5000, now)
+	assert.False(t, exceeded)
+
+	// The most severe action of the exceeded limits applies
+	now = now.Add(time.Second)
+	l.consume(subMsgType, 0, now)
+	l.consume(subMsgType, 0, now)
+	for i := 0; i < 8; i++ {
+		l.check(updateMsgType, 0, now)
+	}
+	action, _, exceeded = l.check(updateMsgType, 0, now)
+	assert.True(t, exceeded)
+	assert.Equal(t, RateLimitAction_Throttle, action)
+	action, _, exceeded = l.check(subMsgType, 0, now)
+	assert.True(t, exceeded)
+	assert.Equal(t, RateLimitAction_Kick, action)
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	InitLogs()
+	oldRateLimits := GlobalSettings.RateLimits
+	defer func() {
+		GlobalSettings.RateLimits = oldRateLimits
+	}()
+
+	GlobalSettings.RateLimits = map[channeldpb.ConnectionType]RateLimitSettingsType{
+		channeldpb.ConnectionType_CLIENT: {
+			Total: RateLimitRule{MsgPerSec: 20, MsgBurst: 1},
+			MsgTypes: map[uint32]RateLimitRule{
+				100: {MsgPerSec: 20, MsgBurst: 1, BytesPerSec: 1000, BytesBurst: 500, Action: RateLimitAction_Throttle},
+			},
+			MaxThrottleMs: 200,
+		},
+	}
+	c := &Connection{
+		connectionType: channeldpb.ConnectionType_CLIENT,
+		logger:         rootLogger,
+		rateLimiter:    newConnRateLimiter(channeldpb.ConnectionType_CLIENT, time.Now()),
+	}
+
+	// Dropped
+	mp := &channeldpb.MessagePack{MsgType: uint32(channeldpb.MessageType_CHANNEL_DATA_UPDATE)}
+	assert.True(t, c.checkRateLimit(mp))
+	assert.False(t, c.checkRateLimit(mp))
+
+	// Throttled
+	time.Sleep(50 * time.Millisecond)
+	mp = &channeldpb.MessagePack{MsgType: 100}
+	assert.True(t, c.checkRateLimit(mp))
+	startTime := time.Now()
+	assert.True(t, c.checkRateLimit(mp))
+	assert.GreaterOrEqual(t, time.Since(startTime), 40*time.Millisecond)
+
+	// Dropped as the throttle delay is too long
+	mp.MsgBody = make([]byte, 500)
+	assert.True(t, c.checkRateLimit(mp))
+	assert.False(t, c.checkRateLimit(mp))
+}
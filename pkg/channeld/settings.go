@@ -41,6 +41,35 @@ type GlobalSettingsType struct {
 	ConnectionAuthTimeoutMs int64
 	MaxFailedAuthAttempts   int
 	MaxFsmDisallowed        int
+	// Optional. The path of the file where the IP/PIT bans are persisted. Empty = the bans are lost on restart. See InitBanList().
+	BanListFile string
+	// Optional. The duration of the first ban of an IP/PIT. Each repeated ban doubles the duration. 0 = 600s.
+	BanBaseDurationSec uint
+	// Optional. The max duration of the repeated bans. 0 = 7 days.
+	BanMaxDurationSec uint
+	// Optional. The bearer token of the ban list admin API. Empty = the API is disabled. See BanListHandler().
+	BanAdminToken string
+
+	// Optional. The built-in AuthProvider: "jwt", "hmac" or "webhook". Empty = the one set by SetAuthProvider(). See InitAuthProvider().
+	AuthProviderType string
+	// The comma-separated paths of the key files to verify the login tokens (PEM public keys or certificates, or HMAC secrets).
+	AuthKeyFiles string
+	// Optional. The path of the JSON Web Key Set file to verify the login tokens.
+	AuthJwksFile string
+	// Optional. The expected audience of the login tokens. Empty = not checked.
+	AuthAudience string
+	// Optional. The comma-separated claims of the login tokens that become the auth tags of the connection. Empty = all the custom claims.
+	AuthTagClaims string
+	// The URL of the auth webhook. Only works for the "webhook" AuthProvider.
+	AuthWebhookUrl string
+	// Optional. The timeout of the auth webhook requests. 0 = 3000ms. Only works for the "webhook" AuthProvider.
+	AuthWebhookTimeoutMs uint
+
+	// The max bytes per second of the entity channels' fan-out to a client connection. 0 = no limit.
+	EntityFanOutBudgetBytesPerSec int
+
+	// Optional. The rate limits of the received messages, by the connection type. See rate_limit.go.
+	RateLimits map[channeldpb.ConnectionType]RateLimitSettingsType
 
 	SpatialControllerConfig NullableString
 	SpatialChannelIdStart   common.ChannelId
@@ -57,6 +86,46 @@ type ACLSettingsType struct {
 	Sub    ChannelAccessLevel
 	Unsub  ChannelAccessLevel
 	Remove ChannelAccessLevel
+	// Optional. The role-based rules that take precedence over the access levels above. See channel_acl.go.
+	RoleRules RoleACLSettingsType
+}
+
+type RoleACLRule struct {
+	// AnyRole ("*") matches any connection.
+	Roles []string
+	Allow bool
+}
+
+// For each access, the first rule that matches the connection's roles decides.
+type RoleACLSettingsType struct {
+	Sub       []RoleACLRule
+	Unsub     []RoleACLRule
+	Remove    []RoleACLRule
+	DataWrite []RoleACLRule
+	// By the user-space message type
+	UserMessages map[uint32][]RoleACLRule
+}
+
+type RateLimitRule struct {
+	// The max messages per second. 0 = no limit.
+	MsgPerSec float64
+	// Optional. The max messages in a burst. 0 = MsgPerSec.
+	MsgBurst float64
+	// The max bytes of the message bodies per second. 0 = no limit.
+	BytesPerSec float64
+	// Optional. The max bytes in a burst. 0 = BytesPerSec.
+	BytesBurst float64
+	// Optional. "drop", "throttle", "kick" or "ban". Empty = "drop".
+	Action RateLimitAction
+}
+
+type RateLimitSettingsType struct {
+	// The limit of all the messages of the connection.
+	Total RateLimitRule
+	// The limits by the message type.
+	MsgTypes map[uint32]RateLimitRule
+	// Optional. The max delay of a throttled message before it's dropped instead. 0 = 1000ms.
+	MaxThrottleMs uint
 }
 
 type ChannelSettingsType struct {
@@ -67,6 +136,26 @@ type ChannelSettingsType struct {
 	ACLSettings                    ACLSettingsType
 	// Optinal. The full name of the Protobuf message type for the channel data (including the package name)
 	DataMsgFullName string
+	// Optional. How many channel data updates are kept in the history, so the past states of the channel data can be retrieved. 0 = no history.
+	DataHistorySize int
+	// Optional. The minimum interval between two full snapshots of the channel data in the history. 0 = snapshot on every update.
+	DataHistorySnapshotIntervalMs uint32
+	// Optional. The distance-based fan-out intervals and priorities, ordered by MaxDistance. Only works for the ENTITY channel type.
+	EntityFanOutPriorities []EntityFanOutPrioritySettings
+	// Optional. How often the entity-level interest of the client connections is updated. 0 = 200ms. Only works for the ENTITY channel type.
+	EntityInterestUpdateIntervalMs uint32
+	// Optional. The extra distance an entity needs to move out of a client's interest area before the client is unsubscribed from it,
+	// so the entities at the edge of the area won't be subscribed and unsubscribed repeatedly. Only works for the ENTITY channel type.
+	EntityInterestHysteresis float64
+	// Optional. The registered type of the EntityGroupController of the entity channels. Empty = FlatEntityGroupController.
+	// Only works for the ENTITY channel type.
+	EntityGroupControllerType string
+	// Optional. How long the handover of the locked entities can be deferred. After that, the entities are removed from the LOCK group
+	// and the handover is forced. 0 = no limit. Only works for the ENTITY channel type.
+	EntityHandoverMaxLockMs uint32
+	// Optional. How long the entity channel can go without any data update before it becomes dormant. 0 = never.
+	// Only works for the ENTITY channel type.
+	EntityDormancyTimeoutMs uint32
 }
 
 var GlobalSettings = GlobalSettingsType{
@@ -179,8 +268,21 @@ func (s *GlobalSettingsType) ParseFlag() error {
 	cat := flag.Uint("cat", uint(s.ConnectionAuthTimeoutMs), "the duration to allow a connection stay unauthenticated before closing it. Default is 5000. (0 = no limit)")
 	mfaa := flag.Int("mfaa", s.MaxFailedAuthAttempts, "the max number of failed authentication attempts before closing the connection. Default is 5. (0 = no limit)")
 	mfd := flag.Int("mfd", s.MaxFsmDisallowed, "the max number of disallowed FSM transitions before closing the connection. Default is 10. (0 = no limit)")
+	flag.StringVar(&s.BanListFile, "blf", s.BanListFile, "the path of the file where the IP/PIT bans are persisted. Empty = the bans are lost on restart")
+	flag.UintVar(&s.BanBaseDurationSec, "bbd", s.BanBaseDurationSec, "the duration in seconds of the first ban of an IP/PIT, doubled for each repeated ban. Default is 600")
+	flag.UintVar(&s.BanMaxDurationSec, "bmd", s.BanMaxDurationSec, "the max duration in seconds of the repeated bans. Default is 604800")
+	flag.StringVar(&s.BanAdminToken, "bat", s.BanAdminToken, "the bearer token of the ban list admin API (/bans). Empty = the API is disabled")
+	flag.StringVar(&s.AuthProviderType, "ap", s.AuthProviderType, "the built-in auth provider: jwt, hmac or webhook. Empty = no built-in auth provider")
+	flag.StringVar(&s.AuthKeyFiles, "akf", s.AuthKeyFiles, "the comma-separated paths of the key files (PEM public keys or certificates, or HMAC secrets) to verify the login tokens")
+	flag.StringVar(&s.AuthJwksFile, "ajwks", s.AuthJwksFile, "the path of the JWKS file to verify the login tokens")
+	flag.StringVar(&s.AuthAudience, "aaud", s.AuthAudience, "the expected audience of the login tokens. Empty = not checked")
+	flag.StringVar(&s.AuthTagClaims, "atc", s.AuthTagClaims, "the comma-separated claims of the login tokens exposed as the auth tags of the connection. Empty = all the custom claims")
+	flag.StringVar(&s.AuthWebhookUrl, "awu", s.AuthWebhookUrl, "the URL of the auth webhook")
+	flag.UintVar(&s.AuthWebhookTimeoutMs, "awt", s.AuthWebhookTimeoutMs, "the timeout of the auth webhook requests. Default is 3000. Should be less than the auth timeout of the connection (-cat)")
+	flag.IntVar(&s.EntityFanOutBudgetBytesPerSec, "efob", s.EntityFanOutBudgetBytesPerSec, "the max bytes per second of the entity channels' fan-out to a client connection. Default is 0. (0 = no limit)")
 
 	chs := flag.String("chs", "config/channel_settings_hifi.json", "the path to the channel settings file")
+	rls := flag.String("rls", "", "the path to the rate limit settings file. Empty = no rate limit")
 
 	flag.Parse()
 
@@ -221,6 +323,16 @@ func (s *GlobalSettingsType) ParseFlag() error {
 		return fmt.Errorf("failed to read channel settings: %v", err)
 	}
 
+	if *rls != "" {
+		rlsData, err := os.ReadFile(*rls)
+		if err != nil {
+			return fmt.Errorf("failed to read rate limit settings: %v", err)
+		}
+		if err := json.Unmarshal(rlsData, &GlobalSettings.RateLimits); err != nil {
+			return fmt.Errorf("failed to unmarshall rate limit settings: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"time"
 
 	"github.com/metaworking/channeld/pkg/channeldpb"
 	"github.com/metaworking/channeld/pkg/common"
@@ -34,43 +35,106 @@ type SpatialController interface {
 	Tick()
 }
 
-// A channeld instance should have only one SpatialController
+// The SpatialController of the default world. See SpatialWorld for the named worlds.
 var spatialController SpatialController
 
-func InitSpatialController() {
+// Creates a SpatialController instance. The parameters are set by SpatialController.LoadConfig() afterwards.
+type SpatialControllerFactory func() SpatialController
+
+var spatialControllerTypeRegistry = map[string]SpatialControllerFactory{
+	"StaticGrid2DSpatialController": func() SpatialController { return &StaticGrid2DSpatialController{} },
+	// Alias of StaticGrid2DSpatialController, used by the existing config files
+	"Static2DSpatialController":      func() SpatialController { return &StaticGrid2DSpatialController{} },
+	"StaticGrid3DSpatialController":  func() SpatialController { return &StaticGrid3DSpatialController{} },
+	"DynamicGrid2DSpatialController": func() SpatialController { return &DynamicGrid2DSpatialController{} },
+	"QuadtreeSpatialController":      func() SpatialController { return &QuadtreeSpatialController{} },
+}
+
+// The type that is used when 'SpatialControllerType' is not set in the spatial controller config
+const DefaultSpatialControllerType = "StaticGrid2DSpatialController"
+
+// Registers a custom SpatialController type, so it can be selected by 'SpatialControllerType' in the spatial controller config.
+// Should be called before InitSpatialController(). Registering an existing name replaces the previous factory.
+func RegisterSpatialControllerType(name string, factory SpatialControllerFactory) {
+	if _, exists := spatialControllerTypeRegistry[name]; exists && rootLogger != nil {
+		rootLogger.Warn("spatial controller type already exists, will be replaced", zap.String("name", name))
+	}
+	spatialControllerTypeRegistry[name] = factory
+}
+
+// Creates a SpatialController of the registered type, and loads the config into it.
+func CreateSpatialController(typeName string, config []byte) (SpatialController, error) {
+	factory, exists := spatialControllerTypeRegistry[typeName]
+	if !exists {
+		return nil, fmt.Errorf("unknown spatial controller type: %s", typeName)
+	}
+	ctl := factory()
+	if err := ctl.LoadConfig(config); err != nil {
+		return nil, fmt.Errorf("failed to load the config of %s: %w", typeName, err)
+	}
+	return ctl, nil
+}
+
+// Creates the SpatialController from the spatial controller config file (set by the '-scc' flag).
+// The type of the controller is selected by 'SpatialControllerType', and the parameters are loaded from 'Config'.
+// The named worlds are created from 'Worlds', if exists.
+func InitSpatialController() error {
 	if !GlobalSettings.SpatialControllerConfig.HasValue {
 		rootLogger.Info("spatial controller config is not set, spatial controller will not be created")
-		return
+		return nil
 	}
 
 	cfgPath := GlobalSettings.SpatialControllerConfig.Value
 	sccData, err := os.ReadFile(cfgPath)
-
 	if err != nil {
-		rootLogger.Panic("failed to read spatial controller config", zap.Error(err), zap.String("cfgPath", cfgPath))
+		return fmt.Errorf("failed to read spatial controller config %s: %w", cfgPath, err)
 	}
 
 	// Unmarshal the spatial controller config to a map[string]string
 	var sccMap map[string]json.RawMessage
 	if err := json.Unmarshal(sccData, &sccMap); err != nil {
-		rootLogger.Panic("failed to unmarshall spatial controller config", zap.Error(err), zap.String("cfgPath", cfgPath))
+		return fmt.Errorf("failed to unmarshall spatial controller config %s: %w", cfgPath, err)
 	}
 	// Unmarshal the spatial controller type to a string
-	// spatialControllerType := strings.Trim(string(sccMap["SpatialControllerType"]), "\"\\")
+	spatialControllerType := DefaultSpatialControllerType
+	if typeData, exists := sccMap["SpatialControllerType"]; exists {
+		if err := json.Unmarshal(typeData, &spatialControllerType); err != nil {
+			return fmt.Errorf("failed to unmarshall 'SpatialControllerType' in %s: %w", cfgPath, err)
+		}
+	}
 
 	config, exists := sccMap["Config"]
 	if !exists {
-		rootLogger.Panic("'Config' does not exist in json", zap.String("cfgPath", cfgPath))
+		return fmt.Errorf("'Config' does not exist in %s", cfgPath)
+	}
+	ctl, err := CreateSpatialController(spatialControllerType, config)
+	if err != nil {
+		return err
 	}
-	// TODO instance of spatialController should be created from the SpatialControllerConfig.SpatialControllerType
-	// current implementation only supports StaticGrid2DSpatialController
-	ctl := &StaticGrid2DSpatialController{}
-	ctl.LoadConfig(config)
+
+	if profiles, exists := sccMap["DampingProfiles"]; exists {
+		if err := LoadSpatialDampingProfiles(profiles); err != nil {
+			return fmt.Errorf("failed to unmarshall spatial damping profiles in %s: %w", cfgPath, err)
+		}
+	}
+	prevController := spatialController
 	spatialController = ctl
+	worlds := make(map[string]*SpatialWorld)
+	if worldsData, exists := sccMap["Worlds"]; exists {
+		worlds, err = loadSpatialWorlds(worldsData)
+		if err != nil {
+			spatialController = prevController
+			return fmt.Errorf("failed to load the spatial worlds in %s: %w", cfgPath, err)
+		}
+	}
+	spatialWorlds = worlds
+
 	rootLogger.Info("created spatial controller",
 		zap.String("cfgPath", cfgPath),
-		// zap.String("spatialControllerType", spatialControllerType),
+		zap.String("spatialControllerType", spatialControllerType),
+		zap.Int("namedWorlds", len(spatialWorlds)),
 	)
+	return nil
 }
 
 func GetSpatialController() SpatialController {
@@ -88,6 +152,7 @@ const (
 // By default, we support up to 2^32-2^16 grid-based spatial channels.
 type StaticGrid2DSpatialController struct {
 	SpatialController
+	SpatialChannelIdRange
 
 	/* Defines how the world is divided into grids */
 	// The width of a grid in simulation/engine units
@@ -114,12 +179,24 @@ type StaticGrid2DSpatialController struct {
 	/* Defines the extra interest area a spatial server has, adjacent to the authority area */
 	// For each side of a server's grids (authority area), how many grids(spatial channels) the server subscribes to, as the extend of its interest area.
 	// For example, ServerInterestBorderSize = 1 means a spatial server of 3x3 grids has interest area of 4x4 grids.
+	// 0 = the server only subscribes to its own grids.
 	// Remarks: the value should always be less than the size of the authority area (=Min(GridCols/ServerCols, GridRows/ServerRows))
 	ServerInterestBorderSize uint32
 
+	/* Defines the hysteresis of the handover, to prevent the entities moving around the grid border from being handed over back and forth */
+	// How far (in the simulation/engine units) an entity should move out of its grid before the handover happens. 0 = no margin.
+	HandoverBorderMargin float64
+	// How long (in milliseconds) an entity should stay in a grid since its last handover before it can be handed over again. 0 = no cooldown.
+	HandoverMinDwellMs uint32
+
+	// If true, the spatial channels of a disconnected spatial server are kept, and the next spatial server that creates the spatial channels takes them over.
+	EnableServerFailover bool
+
 	//serverIndex       uint32
 	serverConnections []ConnectionInChannel
 
+	handoverStates handoverHysteresis
+
 	gridSize float64
 }
 
@@ -152,9 +229,6 @@ func (ctl *StaticGrid2DSpatialController) LoadConfig(config []byte) error {
 	if ctl.ServerCols <= 0 || ctl.ServerRows <= 0 {
 		return errors.New("ServerCols and ServerRows should be positive")
 	}
-	if ctl.ServerInterestBorderSize <= 0 {
-		return errors.New("ServerInterestBorderSize should be positive")
-	}
 	return nil
 }
 
@@ -176,7 +250,7 @@ func (ctl *StaticGrid2DSpatialController) GetChannelIdWithOffset(info common.Spa
 		return 0, fmt.Errorf("gridY=%d when Z=%f. GridY should be in [0,%d)", gridY, info.Z, ctl.GridRows)
 	}
 	index := uint32(gridX) + uint32(gridY)*ctl.GridCols
-	return common.ChannelId(index) + GlobalSettings.SpatialChannelIdStart, nil
+	return common.ChannelId(index) + ctl.ChannelIdStart(), nil
 }
 
 func (ctl *StaticGrid2DSpatialController) QueryChannelIds(query *channeldpb.SpatialInterestQuery) (map[common.ChannelId]uint, error) {
@@ -347,7 +421,7 @@ func (ctl *StaticGrid2DSpatialController) GetRegions() ([]*channeldpb.SpatialReg
 					Y: MaxY,
 					Z: ctl.WorldOffsetZ + ctl.GridHeight*float64(y+1),
 				},
-				ChannelId:   uint32(GlobalSettings.SpatialChannelIdStart) + index,
+				ChannelId:   uint32(ctl.ChannelIdStart()) + index,
 				ServerIndex: serverX + serverY*ctl.ServerCols,
 			}
 		}
@@ -356,7 +430,7 @@ func (ctl *StaticGrid2DSpatialController) GetRegions() ([]*channeldpb.SpatialReg
 }
 
 func (ctl *StaticGrid2DSpatialController) GetAdjacentChannels(spatialChannelId common.ChannelId) ([]common.ChannelId, error) {
-	index := uint32(spatialChannelId - GlobalSettings.SpatialChannelIdStart)
+	index := uint32(spatialChannelId - ctl.ChannelIdStart())
 	gridX := int32(index % ctl.GridCols)
 	gridY := int32(index / ctl.GridCols)
 	channelIds := make([]common.ChannelId, 0)
@@ -374,7 +448,7 @@ func (ctl *StaticGrid2DSpatialController) GetAdjacentChannels(spatialChannelId c
 			}
 
 			channelIndex := uint32(x) + uint32(y)*ctl.GridCols
-			channelIds = append(channelIds, common.ChannelId(channelIndex)+GlobalSettings.SpatialChannelIdStart)
+			channelIds = append(channelIds, common.ChannelId(channelIndex)+ctl.ChannelIdStart())
 		}
 	}
 	return channelIds, nil
@@ -423,6 +497,28 @@ func (ctl *StaticGrid2DSpatialController) CreateChannels(ctx MessageContext) ([]
 		}
 	}
 
+	if ctl.EnableServerFailover {
+		if orphanedChannels := getOrphanedSpatialChannels(channelIds); orphanedChannels != nil {
+			ctl.serverConnections[serverIndex] = ctx.Connection
+			ctl.takeOverSpatialChannels(serverIndex, orphanedChannels, ctx.Connection)
+			// The other servers have been subscribed to the adjacent grids of the serverIndex, so only the new server needs the subscription.
+			if ctl.nextServerIndex() == ctl.ServerCols*ctl.ServerRows {
+				err := ctl.subToAdjacentChannels(serverIndex, serverGridCols, serverGridRows, msg.SubOptions)
+				if err != nil {
+					return orphanedChannels, fmt.Errorf("failed to sub to adjacent channels of server connection %d, err: %v", ctx.Connection.Id(), err)
+				}
+				ctx.Connection.Send(MessageContext{
+					MsgType: channeldpb.MessageType_SPATIAL_CHANNELS_READY,
+					Msg: &channeldpb.SpatialChannelsReadyMessage{
+						ServerIndex: serverIndex,
+						ServerCount: uint32(len(ctl.serverConnections)),
+					},
+				})
+			}
+			return orphanedChannels, nil
+		}
+	}
+
 	channels := make([]*Channel, len(channelIds))
 	for index, channelId := range channelIds {
 		channel := createChannelWithId(channelId, channeldpb.ChannelType_SPATIAL, ctx.Connection)
@@ -455,27 +551,61 @@ func (ctl *StaticGrid2DSpatialController) CreateChannels(ctx MessageContext) ([]
 		}
 
 		// ...and send the SpatialChannelsReadyMessage to all the spatial servers.
-		readyMsg := &channeldpb.SpatialChannelsReadyMessage{
-			ServerIndex: serverIndex,
-			ServerCount: ctl.ServerCols * ctl.ServerRows,
+		sendSpatialChannelsReady(ctl.serverConnections)
+	}
+
+	return channels, nil
+}
+
+// Sends the updated regions to all the spatial servers and the master server.
+func broadcastSpatialRegions(ctl SpatialController, serverConnections []ConnectionInChannel) {
+	regions, err := ctl.GetRegions()
+	if err != nil {
+		rootLogger.Error("failed to get the spatial regions", zap.Error(err))
+		return
+	}
+
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_SPATIAL_REGIONS_UPDATE,
+		Msg: &channeldpb.SpatialRegionsUpdateMessage{
+			Regions: regions,
+		},
+	}
+	masterServerConn := globalChannel.GetOwner()
+	for _, serverConn := range serverConnections {
+		if serverConn == nil || serverConn.IsClosing() {
+			continue
 		}
-		for _, serverConn := range ctl.serverConnections {
-			serverConn.Send(MessageContext{
-				MsgType: channeldpb.MessageType_SPATIAL_CHANNELS_READY,
-				Msg:     readyMsg,
-			})
+		serverConn.Send(ctx)
+		if serverConn == masterServerConn {
+			masterServerConn = nil
 		}
+	}
+	if masterServerConn != nil && !masterServerConn.IsClosing() {
+		masterServerConn.Send(ctx)
+	}
+}
 
-		// ...and the master server too.
-		if globalChannel.ownerConnection != nil {
-			globalChannel.ownerConnection.Send(MessageContext{
-				MsgType: channeldpb.MessageType_SPATIAL_CHANNELS_READY,
-				Msg:     readyMsg,
-			})
-		}
+// Sends the SpatialChannelsReadyMessage to all the spatial servers, and the master server.
+func sendSpatialChannelsReady(serverConnections []ConnectionInChannel) {
+	readyMsg := &channeldpb.SpatialChannelsReadyMessage{
+		ServerIndex: uint32(len(serverConnections)),
+		ServerCount: uint32(len(serverConnections)),
+	}
+	for _, serverConn := range serverConnections {
+		serverConn.Send(MessageContext{
+			MsgType: channeldpb.MessageType_SPATIAL_CHANNELS_READY,
+			Msg:     readyMsg,
+		})
 	}
 
-	return channels, nil
+	// ...and the master server too.
+	if globalChannel.ownerConnection != nil {
+		globalChannel.ownerConnection.Send(MessageContext{
+			MsgType: channeldpb.MessageType_SPATIAL_CHANNELS_READY,
+			Msg:     readyMsg,
+		})
+	}
 }
 
 func (ctl *StaticGrid2DSpatialController) subToAdjacentChannels(serverIndex uint32, serverGridCols uint32, serverGridRows uint32, subOptions *channeldpb.ChannelSubscriptionOptions) error {
@@ -608,7 +738,8 @@ type SpatialChannelEntityUpdater interface {
 	RemoveEntity(EntityId) error
 }
 
-// Runs in the source spatial(V1)/entity(V2) channel (shared instance)
+// Runs in the source spatial(V1)/entity(V2) channel (shared instance).
+// The handover hysteresis doesn't apply, as the entity is unknown. The entity channels call NotifyEntity instead.
 func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) {
 	srcChannelId, err := ctl.GetChannelId(oldInfo)
 	if err != nil {
@@ -625,10 +756,23 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 		return
 	}
 
+	handoverSpatialEntities(srcChannelId, dstChannelId, handoverDataProvider)
+}
+
+// Hands over the entities provided by the handoverDataProvider from the src spatial channel to the dst spatial channel.
+// Returns the ids of the entities that have been handed over.
+func handoverSpatialEntities(srcChannelId common.ChannelId, dstChannelId common.ChannelId, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) []EntityId {
+	return handoverSpatialEntitiesFrom(srcChannelId, dstChannelId, nil, handoverDataProvider)
+}
+
+// Same as handoverSpatialEntities, but the entities are handed over from the srcOwner instead of the owner of the src spatial channel.
+// It's used when a spatial channel is migrated to another spatial server: the src and dst spatial channels are the same, and the srcOwner is the previous owner.
+func handoverSpatialEntitiesFrom(srcChannelId common.ChannelId, dstChannelId common.ChannelId, srcOwner ConnectionInChannel, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) []EntityId {
+	migrating := srcOwner != nil
 	srcChannel := GetChannel(srcChannelId)
 	if srcChannel == nil {
 		rootLogger.Error("channel doesn't exist, failed to handover channel data", zap.Uint32("srcChannelId", uint32(srcChannelId)))
-		return
+		return nil
 	}
 	if !srcChannel.HasOwner() {
 		rootLogger.Error("channel doesn't have owner, failed to handover channel data", zap.Uint32("srcChannelId", uint32(srcChannelId)))
@@ -637,7 +781,7 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 	dstChannel := GetChannel(dstChannelId)
 	if dstChannel == nil {
 		rootLogger.Error("channel doesn't exist, failed to handover channel data", zap.Uint32("dstChannelId", uint32(dstChannelId)))
-		return
+		return nil
 	}
 	if !srcChannel.HasOwner() {
 		rootLogger.Error("channel doesn't have owner, failed to handover channel data", zap.Uint32("dstChannelId", uint32(dstChannelId)))
@@ -648,10 +792,52 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 
 	rootLogger.Debug("handover group", zap.Uint32("entityId", uint32(handoverEntityId)))
 
+	entityChannel := GetChannel(common.ChannelId(handoverEntityId))
+	if entityChannel == nil {
+		rootLogger.Warn("failed to handover entity as the channel doesn't exist", zap.Uint32("entityId", uint32(handoverEntityId)))
+		pendingHandovers.remove(handoverEntityId)
+		return nil
+	}
+
+	// The entity may have moved on while its handover was deferred, so the source is the spatial channel that the entity is actually in.
+	if handover, exists := pendingHandovers.get(handoverEntityId); exists && !migrating && handover.srcChannelId != srcChannelId {
+		if pendingSrcChannel := GetChannel(handover.srcChannelId); pendingSrcChannel != nil {
+			srcChannelId = handover.srcChannelId
+			srcChannel = pendingSrcChannel
+		}
+	}
+	// The entity has moved back to the spatial channel it's in
+	if srcChannelId == dstChannelId && !migrating {
+		pendingHandovers.remove(handoverEntityId)
+		return nil
+	}
+
+	handoverEntities := entityChannel.GetHandoverEntities(handoverEntityId)
+	// No handover happens
+	if len(handoverEntities) == 0 {
+		// Defer the handover until the entities are unlocked. The locked entities stay with the previous owner of the migrated spatial channel,
+		// until they are handed over to another spatial channel.
+		if !migrating && entityChannel.entityController != nil && len(entityChannel.entityController.GetLockedEntities()) > 0 {
+			pendingHandovers.put(handoverEntityId, srcChannelId, dstChannelId, handoverDataProvider, time.Now())
+			handoverSuppressed.WithLabelValues(getHandoverType(srcChannelId, dstChannelId), handoverSuppressedByLock).Inc()
+			rootLogger.Debug("deferred the handover of the locked entity", zap.Uint32("entityId", uint32(handoverEntityId)),
+				zap.Uint32("srcChannelId", uint32(srcChannelId)), zap.Uint32("dstChannelId", uint32(dstChannelId)))
+		}
+		return nil
+	}
+
+	handoverEntityIds := make([]EntityId, 0, len(handoverEntities))
+	for entityId := range handoverEntities {
+		handoverEntityIds = append(handoverEntityIds, entityId)
+	}
+	// The entities handed over along don't need to be handed over by themselves
+	pendingHandovers.remove(handoverEntityIds...)
+	pendingHandovers.remove(handoverEntityId)
+
 	spatialDataMsg, err := ReflectChannelDataMessage(channeldpb.ChannelType_SPATIAL)
 	if err != nil {
 		rootLogger.Error("failed to create handover data message for spatial channel", zap.Error(err))
-		return
+		return nil
 	}
 
 	if initializer, ok := spatialDataMsg.(ChannelDataInitializer); ok {
@@ -666,74 +852,63 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 		}
 	*/
 
-	entityChannel := GetChannel(common.ChannelId(handoverEntityId))
-	if entityChannel == nil {
-		rootLogger.Warn("failed to handover entity as the channel doesn't exist", zap.Uint32("entityId", uint32(handoverEntityId)))
-		return
-	}
-
-	handoverEntities := entityChannel.GetHandoverEntities(handoverEntityId)
-	// No handover happens
-	if len(handoverEntities) == 0 {
-		return
-	}
-
 	// Step 1: Handle the cross-server handover
 	// Should be done as soon as possible to prevent the src spatial server from sending the entity channel data update.
-	if !srcChannel.IsSameOwner(dstChannel) {
+	if migrating || !srcChannel.IsSameOwner(dstChannel) {
+		handoverTotal.WithLabelValues(handoverTypeCrossServer).Inc()
+		if !migrating {
+			srcOwner = srcChannel.GetOwner()
+		}
 		for entityId := range handoverEntities {
 			entityCh := GetChannel(common.ChannelId(entityId))
 			if entityCh == nil {
 				continue
 			}
 
-			if ownerConn := srcChannel.GetOwner(); ownerConn != nil && !ownerConn.IsClosing() && !ownerConn.HasInterestIn(dstChannelId) {
-				// Unsub the src spatial server from the entity channel if the server has no interest in the dst spatial channel
-				ownerConn.UnsubscribeFromChannel(entityCh)
-				ownerConn.sendUnsubscribed(MessageContext{}, entityCh, nil, 0)
-			}
-
-			// Set the owner of the entity channel to the dst spatial server, so the src spatial server's residual update will be ignored.
-			// Otherwise repeating handover may happen!
-			entityCh.SetOwner(dstChannel.GetOwner())
+			handoverEntityOwner(entityCh, srcOwner, dstChannel.GetOwner(), dstChannelId)
 		}
+	} else {
+		handoverTotal.WithLabelValues(handoverTypeSameServer).Inc()
 	}
 
-	// Step 2-1: Remove the entities from the src spatial channel's data
-	srcChannel.Execute(func(ch *Channel) {
-		updater, ok := ch.GetDataMessage().(SpatialChannelEntityUpdater)
-		if !ok {
-			ch.Logger().Warn("spatial channel data doesn't implement SpatialChannelEntityUpdater")
-			return
-		}
-		for entityId := range handoverEntities {
-			if err := updater.RemoveEntity(entityId); err != nil {
-				ch.Logger().Warn("failed to remove entity from spatial channel data", zap.Error(err))
-			} else {
-				ch.Logger().Debug("removed entity from spatial channel data", zap.Uint32("entityId", uint32(entityId)))
+	// Step 2: The migrated spatial channel keeps the entities in its data
+	if !migrating {
+		// Step 2-1: Remove the entities from the src spatial channel's data
+		srcChannel.Execute(func(ch *Channel) {
+			updater, ok := ch.GetDataMessage().(SpatialChannelEntityUpdater)
+			if !ok {
+				ch.Logger().Warn("spatial channel data doesn't implement SpatialChannelEntityUpdater")
+				return
 			}
-		}
-	})
-
-	// Step 2-2: Add the entities to the dst spatial channel's data
-	dstChannel.Execute(func(ch *Channel) {
-		updater, ok := ch.GetDataMessage().(SpatialChannelEntityUpdater)
-		if !ok {
-			ch.Logger().Warn("spatial channel data doesn't implement SpatialChannelEntityUpdater")
-			return
-		}
-		for entityId, entityData := range handoverEntities {
-			if entityData == nil {
-				ch.Logger().Warn("failed to add entity to spatial channel as it doesn't have data", zap.Uint32("entityId", uint32(entityId)))
-				continue
+			for entityId := range handoverEntities {
+				if err := updater.RemoveEntity(entityId); err != nil {
+					ch.Logger().Warn("failed to remove entity from spatial channel data", zap.Error(err))
+				} else {
+					ch.Logger().Debug("removed entity from spatial channel data", zap.Uint32("entityId", uint32(entityId)))
+				}
 			}
-			if err := updater.AddEntity(entityId, entityData); err != nil {
-				ch.Logger().Warn("failed to add entity to spatial channel data", zap.Error(err))
-			} else {
-				ch.Logger().Debug("added entity to spatial channel data", zap.Uint32("entityId", uint32(entityId)))
+		})
+
+		// Step 2-2: Add the entities to the dst spatial channel's data
+		dstChannel.Execute(func(ch *Channel) {
+			updater, ok := ch.GetDataMessage().(SpatialChannelEntityUpdater)
+			if !ok {
+				ch.Logger().Warn("spatial channel data doesn't implement SpatialChannelEntityUpdater")
+				return
 			}
-		}
-	})
+			for entityId, entityData := range handoverEntities {
+				if entityData == nil {
+					ch.Logger().Warn("failed to add entity to spatial channel as it doesn't have data", zap.Uint32("entityId", uint32(entityId)))
+					continue
+				}
+				if err := updater.AddEntity(entityId, entityData); err != nil {
+					ch.Logger().Warn("failed to add entity to spatial channel data", zap.Error(err))
+				} else {
+					ch.Logger().Debug("added entity to spatial channel data", zap.Uint32("entityId", uint32(entityId)))
+				}
+			}
+		})
+	}
 
 	// Step 3-1: Merge the entities to the spatial data message
 	for entityId, entityData := range handoverEntities {
@@ -756,7 +931,7 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 	handoverAnyData, err := anypb.New(spatialDataMsg)
 	if err != nil {
 		rootLogger.Error("failed to marshal spatial handover data", zap.Error(err))
-		return
+		return nil
 	}
 
 	handoverMsgCtx := MessageContext{
@@ -855,6 +1030,21 @@ func (ctl *StaticGrid2DSpatialController) Notify(oldInfo common.SpatialInfo, new
 		}
 		conn.Send(handoverMsgCtx)
 	}
+
+	return handoverEntityIds
+}
+
+// Transfers the ownership of the entity channel from the src spatial server to the dst spatial server.
+func handoverEntityOwner(entityCh *Channel, srcOwner ConnectionInChannel, dstOwner ConnectionInChannel, dstChannelId common.ChannelId) {
+	if srcOwner != nil && !srcOwner.IsClosing() && !srcOwner.HasInterestIn(dstChannelId) {
+		// Unsub the src spatial server from the entity channel if the server has no interest in the dst spatial channel
+		srcOwner.UnsubscribeFromChannel(entityCh)
+		srcOwner.sendUnsubscribed(MessageContext{}, entityCh, nil, 0)
+	}
+
+	// Set the owner of the entity channel to the dst spatial server, so the src spatial server's residual update will be ignored.
+	// Otherwise repeating handover may happen!
+	entityCh.SetOwner(dstOwner)
 }
 
 func (ctl *StaticGrid2DSpatialController) initServerConnections() {
@@ -878,9 +1068,13 @@ func (ctl *StaticGrid2DSpatialController) Tick() {
 	for i := 0; i < len(ctl.serverConnections); i++ {
 		if ctl.serverConnections[i] != nil && ctl.serverConnections[i].IsClosing() {
 			ctl.serverConnections[i] = nil
-			rootLogger.Info("reset spatial server connection", zap.Int("serverIndex", i))
+			rootLogger.Info("reset spatial server connection", zap.Int("serverIndex", i), zap.Bool("failover", ctl.EnableServerFailover))
 		}
 	}
+
+	if ctl.hasHandoverHysteresis() {
+		ctl.handoverStates.removeStaleStates()
+	}
 }
 
 /*
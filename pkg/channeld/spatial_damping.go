@@ -0,0 +1,210 @@
+package channeld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+)
+
+// Defines how a spatial channel is fanned out to a client connection, based on the distance between the spatial channel and the center of the client's spatial interest.
+type SpatialDampingSettings struct {
+	// The max distance that the settings apply to. The distance is calculated by SpatialController.QueryChannelIds(), e.g. the number of grids.
+	MaxDistance      uint
+	FanOutIntervalMs uint32
+	DataFieldMasks   []string
+	// Optional. The max number of entities in all the spatial channels that use the settings. 0 = no limit.
+	// The spatial channels that exceed the limit use the settings of the next distance band.
+	MaxEntities int
+}
+
+// The name of the profile that is used when the connection doesn't specify one, or the specified one doesn't exist.
+const DefaultSpatialDampingProfile = "default"
+
+var defaultSpatialDampingSettings = []*SpatialDampingSettings{
+	{
+		MaxDistance:      0,
+		FanOutIntervalMs: 20,
+	},
+	{
+		MaxDistance:      1,
+		FanOutIntervalMs: 50,
+	},
+	{
+		MaxDistance:      2,
+		FanOutIntervalMs: 100,
+	},
+}
+
+var spatialDampingProfiles = map[string][]*SpatialDampingSettings{
+	DefaultSpatialDampingProfile: defaultSpatialDampingSettings,
+}
+var spatialDampingProfilesLock sync.RWMutex
+
+// Replaces all the spatial damping profiles. The settings of each profile are sorted by MaxDistance.
+// If the default profile is not in the profiles, the built-in one is used.
+func SetSpatialDampingProfiles(profiles map[string][]*SpatialDampingSettings) {
+	newProfiles := make(map[string][]*SpatialDampingSettings, len(profiles)+1)
+	for name, settings := range profiles {
+		sorted := make([]*SpatialDampingSettings, 0, len(settings))
+		for _, s := range settings {
+			if s != nil {
+				sorted = append(sorted, s)
+			}
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].MaxDistance < sorted[j].MaxDistance
+		})
+		newProfiles[name] = sorted
+	}
+	if _, exists := newProfiles[DefaultSpatialDampingProfile]; !exists {
+		newProfiles[DefaultSpatialDampingProfile] = defaultSpatialDampingSettings
+	}
+
+	spatialDampingProfilesLock.Lock()
+	defer spatialDampingProfilesLock.Unlock()
+	spatialDampingProfiles = newProfiles
+}
+
+// Loads the spatial damping profiles from the json, e.g. {"player": [{"MaxDistance": 0, "FanOutIntervalMs": 20}], "spectator": [...]}
+func LoadSpatialDampingProfiles(data []byte) error {
+	var profiles map[string][]*SpatialDampingSettings
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return err
+	}
+	SetSpatialDampingProfiles(profiles)
+	return nil
+}
+
+// Reloads the spatial damping profiles from the 'DampingProfiles' of the spatial controller config file.
+// Only the profiles are reloaded; the spatial controller itself can't be changed at runtime.
+// The spatial interest of the client connections is updated again, so the reloaded profiles apply to the existing spatial subscriptions.
+func ReloadSpatialDampingProfiles() error {
+	if !GlobalSettings.SpatialControllerConfig.HasValue {
+		return errors.New("spatial controller config is not set")
+	}
+
+	cfgPath := GlobalSettings.SpatialControllerConfig.Value
+	sccData, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spatial controller config: %w", err)
+	}
+
+	var sccMap map[string]json.RawMessage
+	if err := json.Unmarshal(sccData, &sccMap); err != nil {
+		return fmt.Errorf("failed to unmarshall spatial controller config: %w", err)
+	}
+
+	if profiles, exists := sccMap["DampingProfiles"]; !exists {
+		SetSpatialDampingProfiles(nil)
+	} else if err := LoadSpatialDampingProfiles(profiles); err != nil {
+		return fmt.Errorf("failed to unmarshall spatial damping profiles: %w", err)
+	}
+	reapplySpatialDampingProfiles()
+	return nil
+}
+
+// Handles the last spatial interest update of each client connection again in the GLOBAL channel.
+func reapplySpatialDampingProfiles() {
+	if allConnections == nil || globalChannel == nil {
+		return
+	}
+	allConnections.Range(func(_ ConnectionId, conn *Connection) bool {
+		msg, ok := conn.spatialInterestMsg.Load().(*channeldpb.UpdateSpatialInterestMessage)
+		if ok && msg != nil && !conn.IsClosing() {
+			globalChannel.PutMessageContext(MessageContext{
+				MsgType:    channeldpb.MessageType_UPDATE_SPATIAL_INTEREST,
+				Msg:        msg,
+				Connection: conn,
+				Channel:    globalChannel,
+				ChannelId:  uint32(globalChannel.id),
+			}, handleUpdateSpatialInterest)
+		}
+		return true
+	})
+}
+
+// Returns the settings of the profile. If the profile doesn't exist, the default profile is returned.
+func getSpatialDampingProfile(name string) []*SpatialDampingSettings {
+	spatialDampingProfilesLock.RLock()
+	defer spatialDampingProfilesLock.RUnlock()
+	if settings, exists := spatialDampingProfiles[name]; exists {
+		return settings
+	}
+	return spatialDampingProfiles[DefaultSpatialDampingProfile]
+}
+
+// Returns the damping settings of each spatial channel, by the distance and the number of entities in the channel.
+// The channels out of the range of all the settings are not in the result.
+func assignSpatialDampingSettings(profile []*SpatialDampingSettings, spatialChIds map[common.ChannelId]uint, getEntityCount func(common.ChannelId) int) map[common.ChannelId]*SpatialDampingSettings {
+	// The nearer channels take the entity quota first.
+	chIds := make([]common.ChannelId, 0, len(spatialChIds))
+	for chId := range spatialChIds {
+		chIds = append(chIds, chId)
+	}
+	sort.Slice(chIds, func(i, j int) bool {
+		if spatialChIds[chIds[i]] != spatialChIds[chIds[j]] {
+			return spatialChIds[chIds[i]] < spatialChIds[chIds[j]]
+		}
+		return chIds[i] < chIds[j]
+	})
+
+	result := make(map[common.ChannelId]*SpatialDampingSettings, len(chIds))
+	entityCounts := make([]int, len(profile))
+	for _, chId := range chIds {
+		dist := spatialChIds[chId]
+		entityCount := getEntityCount(chId)
+		for i, s := range profile {
+			if dist > s.MaxDistance {
+				continue
+			}
+			if s.MaxEntities > 0 && entityCounts[i]+entityCount > s.MaxEntities {
+				// Falls back to the next band
+				continue
+			}
+			entityCounts[i] += entityCount
+			result[chId] = s
+			break
+		}
+	}
+	return result
+}
+
+// Spatial channel data can implement this interface to support SpatialDampingSettings.MaxEntities
+type SpatialChannelEntityCounter interface {
+	GetEntityCount() int
+}
+
+// Called in the spatial channel's goroutine
+func (ch *Channel) updateEntityCount() {
+	counter, ok := ch.GetDataMessage().(SpatialChannelEntityCounter)
+	if !ok {
+		return
+	}
+	atomic.StoreInt32(&ch.entityCount, int32(counter.GetEntityCount()))
+}
+
+// Returns the number of entities in the spatial channel data, as of the channel's last tick.
+// Returns 0 if the data doesn't implement SpatialChannelEntityCounter.
+func (ch *Channel) GetEntityCount() int {
+	return int(atomic.LoadInt32(&ch.entityCount))
+}
+
+// Sets the spatial damping profile for the (client) connection. The profile is applied in the next spatial interest update.
+func (c *Connection) SetSpatialDampingProfile(name string) {
+	c.spatialDampingProfile.Store(name)
+}
+
+// Returns the name of the connection's spatial damping profile, or DefaultSpatialDampingProfile if not set.
+func (c *Connection) GetSpatialDampingProfile() string {
+	if name, ok := c.spatialDampingProfile.Load().(string); ok && name != "" {
+		return name
+	}
+	return DefaultSpatialDampingProfile
+}
@@ -0,0 +1,151 @@
+package channeld
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignSpatialDampingSettings(t *testing.T) {
+	profile := []*SpatialDampingSettings{
+		{MaxDistance: 0, FanOutIntervalMs: 20},
+		{MaxDistance: 1, FanOutIntervalMs: 50, MaxEntities: 10},
+		{MaxDistance: 2, FanOutIntervalMs: 100},
+	}
+	spatialChIds := map[common.ChannelId]uint{
+		1: 0,
+		2: 1,
+		3: 1,
+		4: 1,
+		5: 2,
+		6: 3,
+	}
+	entityCounts := map[common.ChannelId]int{1: 100, 2: 5, 3: 6, 4: 5}
+
+	result := assignSpatialDampingSettings(profile, spatialChIds, func(chId common.ChannelId) int {
+		return entityCounts[chId]
+	})
+	// No limit for the nearest band
+	assert.Equal(t, profile[0], result[1])
+	assert.Equal(t, profile[1], result[2])
+	// Exceeds the limit of the band, falls back to the next band
+	assert.Equal(t, profile[2], result[3])
+	assert.Equal(t, profile[1], result[4])
+	assert.Equal(t, profile[2], result[5])
+	// Out of range
+	assert.NotContains(t, result, common.ChannelId(6))
+}
+
+func TestSpatialDampingProfiles(t *testing.T) {
+	defer SetSpatialDampingProfiles(nil)
+
+	assert.NoError(t, LoadSpatialDampingProfiles([]byte(`{
+		"spectator": [
+			{"MaxDistance": 2, "FanOutIntervalMs": 200},
+			{"MaxDistance": 0, "FanOutIntervalMs": 100, "DataFieldMasks": ["entities"], "MaxEntities": 50}
+		]
+	}`)))
+
+	spectator := getSpatialDampingProfile("spectator")
+	assert.Equal(t, 2, len(spectator))
+	// Sorted by MaxDistance
+	assert.EqualValues(t, 100, spectator[0].FanOutIntervalMs)
+	assert.Equal(t, []string{"entities"}, spectator[0].DataFieldMasks)
+	assert.Equal(t, 50, spectator[0].MaxEntities)
+	assert.EqualValues(t, 200, spectator[1].FanOutIntervalMs)
+
+	// The built-in default profile is used for the unknown profile
+	assert.Equal(t, defaultSpatialDampingSettings, getSpatialDampingProfile("player"))
+
+	c := &Connection{}
+	assert.Equal(t, DefaultSpatialDampingProfile, c.GetSpatialDampingProfile())
+	c.SetSpatialDampingProfile("spectator")
+	assert.Equal(t, "spectator", c.GetSpatialDampingProfile())
+
+	assert.Error(t, LoadSpatialDampingProfiles([]byte(`{"spectator": {}}`)))
+}
+
+func TestReloadSpatialDampingProfiles(t *testing.T) {
+	oldConfig := GlobalSettings.SpatialControllerConfig
+	defer func() {
+		GlobalSettings.SpatialControllerConfig = oldConfig
+		SetSpatialDampingProfiles(nil)
+	}()
+
+	cfgPath := filepath.Join(t.TempDir(), "spatial.json")
+	GlobalSettings.SpatialControllerConfig.Set(cfgPath)
+	assert.Error(t, ReloadSpatialDampingProfiles())
+
+	assert.NoError(t, os.WriteFile(cfgPath, []byte(`{"Config": {}, "DampingProfiles": {"player": [{"MaxDistance": 1, "FanOutIntervalMs": 30}]}}`), 0644))
+	assert.NoError(t, ReloadSpatialDampingProfiles())
+	assert.EqualValues(t, 30, getSpatialDampingProfile("player")[0].FanOutIntervalMs)
+
+	// The profiles are removed from the config
+	assert.NoError(t, os.WriteFile(cfgPath, []byte(`{"Config": {}}`), 0644))
+	assert.NoError(t, ReloadSpatialDampingProfiles())
+	assert.Equal(t, defaultSpatialDampingSettings, getSpatialDampingProfile("player"))
+}
+
+func TestReloadSpatialDampingProfilesOfLiveChannels(t *testing.T) {
+	isolateChannelTest(t)
+	oldConfig := GlobalSettings.SpatialControllerConfig
+	oldSpatialController := spatialController
+	defer func() {
+		GlobalSettings.SpatialControllerConfig = oldConfig
+		spatialController = oldSpatialController
+		SetSpatialDampingProfiles(nil)
+	}()
+	spatialController = &StaticGrid2DSpatialController{
+		GridWidth:  100,
+		GridHeight: 100,
+		GridCols:   2,
+		GridRows:   1,
+		ServerCols: 1,
+		ServerRows: 1,
+	}
+
+	cfgPath := filepath.Join(t.TempDir(), "spatial.json")
+	GlobalSettings.SpatialControllerConfig.Set(cfgPath)
+	writeProfiles := func(fanOutIntervalMs string) {
+		assert.NoError(t, os.WriteFile(cfgPath, []byte(`{"Config": {}, "DampingProfiles": {"default": [{"MaxDistance": 1, "FanOutIntervalMs": `+fanOutIntervalMs+`}]}}`), 0644))
+	}
+	writeProfiles("30")
+	assert.NoError(t, ReloadSpatialDampingProfiles())
+
+	serverConn := addTestConnection(channeldpb.ConnectionType_SERVER)
+	clientConn := addTestConnection(channeldpb.ConnectionType_CLIENT)
+	spatialCh := createChannelWithId(GlobalSettings.SpatialChannelIdStart, channeldpb.ChannelType_SPATIAL, serverConn)
+	getFanOutInterval := func() uint32 {
+		subOptions, exists := clientConn.spatialSubscriptions.Load(spatialCh.id)
+		if !exists {
+			return 0
+		}
+		return subOptions.GetFanOutIntervalMs()
+	}
+
+	handleUpdateSpatialInterest(MessageContext{
+		MsgType: channeldpb.MessageType_UPDATE_SPATIAL_INTEREST,
+		Msg: &channeldpb.UpdateSpatialInterestMessage{
+			ConnId: uint32(clientConn.Id()),
+			Query: &channeldpb.SpatialInterestQuery{
+				SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{Center: &channeldpb.SpatialInfo{X: 50, Z: 50}, Radius: 10},
+			},
+		},
+		Connection: serverConn,
+		Channel:    spatialCh,
+		ChannelId:  uint32(spatialCh.id),
+	})
+	assert.EqualValues(t, 30, getFanOutInterval())
+
+	// The reloaded profile applies to the existing subscription
+	writeProfiles("60")
+	assert.NoError(t, ReloadSpatialDampingProfiles())
+	assert.Eventually(t, func() bool {
+		return getFanOutInterval() == 60
+	}, time.Second, 10*time.Millisecond)
+}
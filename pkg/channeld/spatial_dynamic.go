@@ -0,0 +1,307 @@
+package channeld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+// Divides the world in the same way as StaticGrid2DSpatialController, but the grids(spatial channels) are reassigned between the spatial servers at runtime.
+// The load of each grid is measured in every RebalanceIntervalMs. If the most loaded server is overloaded comparing to its neighbour,
+// its border grid is migrated to the neighbour, i.e. the authority area of the overloaded server splits, and the split part merges into the neighbour's.
+type DynamicGrid2DSpatialController struct {
+	StaticGrid2DSpatialController
+
+	// How often the load is measured and the grids are rebalanced. 0 = never rebalance.
+	RebalanceIntervalMs uint32
+	// A grid is migrated only if the load of the src server is more than LoadImbalanceRatio x the load of the dst server.
+	LoadImbalanceRatio float64
+	// The servers with less load than this value never migrate their grids.
+	MinLoadToRebalance float64
+	// The max number of grids to migrate in each rebalance.
+	MaxGridsPerRebalance int
+
+	/* The load of a grid = EntityCount x EntityLoadWeight + UpdatesPerSec x UpdateRateLoadWeight + TickDurationMs x TickDurationLoadWeight */
+	// The entity count is only available if the spatial channel data implements SpatialChannelEntityCounter.
+	EntityLoadWeight float64
+	// The number of channel data updates per second, mostly sent by the owning spatial server.
+	UpdateRateLoadWeight float64
+	// The tick duration of the spatial channel.
+	TickDurationLoadWeight float64
+
+	lastRebalanceTime time.Time
+	lastUpdateCounts  map[common.ChannelId]uint64
+}
+
+func (ctl *DynamicGrid2DSpatialController) LoadConfig(config []byte) error {
+	ctl.RebalanceIntervalMs = 5000
+	ctl.LoadImbalanceRatio = 1.5
+	ctl.MinLoadToRebalance = 100
+	ctl.MaxGridsPerRebalance = 1
+	ctl.EntityLoadWeight = 1
+	ctl.UpdateRateLoadWeight = 0.1
+	ctl.TickDurationLoadWeight = 10
+
+	if err := json.Unmarshal(config, ctl); err != nil {
+		return err
+	}
+	if ctl.LoadImbalanceRatio < 1 {
+		return errors.New("LoadImbalanceRatio should be no less than 1")
+	}
+	return ctl.StaticGrid2DSpatialController.LoadConfig(config)
+}
+
+// Same as StaticGrid2DSpatialController.GetRegions(), but the ServerIndex is the current owner of the grid.
+func (ctl *DynamicGrid2DSpatialController) GetRegions() ([]*channeldpb.SpatialRegion, error) {
+	regions, err := ctl.StaticGrid2DSpatialController.GetRegions()
+	if err != nil {
+		return nil, err
+	}
+	for _, region := range regions {
+		if serverIndex, ok := ctl.getGridServerIndex(common.ChannelId(region.ChannelId)); ok {
+			region.ServerIndex = serverIndex
+		}
+	}
+	return regions, nil
+}
+
+// Returns the index of the spatial server that owns the grid, or false if the grid has no owner.
+func (ctl *DynamicGrid2DSpatialController) getGridServerIndex(chId common.ChannelId) (uint32, bool) {
+	ch := GetChannel(chId)
+	if ch == nil {
+		return 0, false
+	}
+	ownerConn := ch.GetOwner()
+	if ownerConn == nil {
+		return 0, false
+	}
+	for i, serverConn := range ctl.serverConnections {
+		if serverConn == ownerConn {
+			return uint32(i), true
+		}
+	}
+	return 0, false
+}
+
+func (ctl *DynamicGrid2DSpatialController) Tick() {
+	ctl.StaticGrid2DSpatialController.Tick()
+
+	if ctl.RebalanceIntervalMs == 0 {
+		return
+	}
+
+	now := time.Now()
+	if ctl.lastRebalanceTime.IsZero() {
+		ctl.lastRebalanceTime = now
+		return
+	}
+	elapsed := now.Sub(ctl.lastRebalanceTime)
+	if elapsed < time.Duration(ctl.RebalanceIntervalMs)*time.Millisecond {
+		return
+	}
+	ctl.lastRebalanceTime = now
+
+	loads := ctl.measureLoads(elapsed)
+	// Only rebalance when all the spatial servers are connected
+	if ctl.nextServerIndex() < uint32(len(ctl.serverConnections)) {
+		return
+	}
+	ctl.rebalance(loads)
+}
+
+// Returns the load of each grid since the last measurement.
+func (ctl *DynamicGrid2DSpatialController) measureLoads(elapsed time.Duration) map[common.ChannelId]float64 {
+	if ctl.lastUpdateCounts == nil {
+		ctl.lastUpdateCounts = make(map[common.ChannelId]uint64)
+	}
+
+	loads := make(map[common.ChannelId]float64)
+	for index := uint32(0); index < ctl.GridCols*ctl.GridRows; index++ {
+		chId := ctl.ChannelIdStart() + common.ChannelId(index)
+		ch := GetChannel(chId)
+		if ch == nil {
+			continue
+		}
+
+		updatesPerSec := 0.0
+		updateCount := atomic.LoadUint64(&ch.dataUpdateCount)
+		if lastCount, exists := ctl.lastUpdateCounts[chId]; exists && updateCount >= lastCount && elapsed > 0 {
+			updatesPerSec = float64(updateCount-lastCount) / elapsed.Seconds()
+		}
+		ctl.lastUpdateCounts[chId] = updateCount
+
+		tickDurationMs := float64(atomic.LoadInt64(&ch.lastTickDuration)) / float64(time.Millisecond)
+
+		loads[chId] = float64(ch.GetEntityCount())*ctl.EntityLoadWeight +
+			updatesPerSec*ctl.UpdateRateLoadWeight +
+			tickDurationMs*ctl.TickDurationLoadWeight
+	}
+	return loads
+}
+
+// Migrates the grids from the most loaded server to its less loaded neighbours. Returns the number of the migrated grids.
+func (ctl *DynamicGrid2DSpatialController) rebalance(loads map[common.ChannelId]float64) int {
+	gridOwners := make(map[common.ChannelId]uint32, len(loads))
+	serverLoads := make([]float64, len(ctl.serverConnections))
+	serverGridCounts := make([]int, len(ctl.serverConnections))
+	for chId, load := range loads {
+		serverIndex, ok := ctl.getGridServerIndex(chId)
+		if !ok {
+			continue
+		}
+		gridOwners[chId] = serverIndex
+		serverLoads[serverIndex] += load
+		serverGridCounts[serverIndex]++
+	}
+
+	migrated := 0
+	for migrated < ctl.MaxGridsPerRebalance {
+		srcServerIndex := 0
+		for i := range serverLoads {
+			if serverLoads[i] > serverLoads[srcServerIndex] {
+				srcServerIndex = i
+			}
+		}
+		// A server should have at least one grid
+		if serverLoads[srcServerIndex] < ctl.MinLoadToRebalance || serverGridCounts[srcServerIndex] <= 1 {
+			break
+		}
+
+		chId, dstServerIndex, found := ctl.findGridToMigrate(uint32(srcServerIndex), gridOwners, loads, serverLoads)
+		if !found {
+			break
+		}
+
+		if err := ctl.migrateGrid(chId, dstServerIndex); err != nil {
+			rootLogger.Error("failed to migrate spatial channel", zap.Error(err), zap.Uint32("channelId", uint32(chId)))
+			break
+		}
+
+		gridOwners[chId] = dstServerIndex
+		serverLoads[srcServerIndex] -= loads[chId]
+		serverLoads[dstServerIndex] += loads[chId]
+		serverGridCounts[srcServerIndex]--
+		serverGridCounts[dstServerIndex]++
+		migrated++
+	}
+
+	if migrated > 0 {
+		broadcastSpatialRegions(ctl, ctl.serverConnections)
+	}
+	return migrated
+}
+
+// Finds the grid of the src server that is adjacent to a less loaded server, and the migration reduces the load difference the most.
+func (ctl *DynamicGrid2DSpatialController) findGridToMigrate(srcServerIndex uint32, gridOwners map[common.ChannelId]uint32, loads map[common.ChannelId]float64, serverLoads []float64) (common.ChannelId, uint32, bool) {
+	var bestChId common.ChannelId
+	var bestDstServerIndex uint32
+	found := false
+	bestMaxLoad := serverLoads[srcServerIndex]
+
+	// Iterate in the order of the channel id, so the result is deterministic.
+	for index := uint32(0); index < ctl.GridCols*ctl.GridRows; index++ {
+		chId := ctl.ChannelIdStart() + common.ChannelId(index)
+		if owner, exists := gridOwners[chId]; !exists || owner != srcServerIndex {
+			continue
+		}
+
+		adjacentChIds, err := ctl.GetAdjacentChannels(chId)
+		if err != nil {
+			continue
+		}
+		for _, adjacentChId := range adjacentChIds {
+			dstServerIndex, exists := gridOwners[adjacentChId]
+			if !exists || dstServerIndex == srcServerIndex {
+				continue
+			}
+			if serverLoads[srcServerIndex] <= serverLoads[dstServerIndex]*ctl.LoadImbalanceRatio {
+				continue
+			}
+
+			// The higher load of the two servers after the migration
+			maxLoad := serverLoads[srcServerIndex] - loads[chId]
+			if dstLoad := serverLoads[dstServerIndex] + loads[chId]; dstLoad > maxLoad {
+				maxLoad = dstLoad
+			}
+			if maxLoad < bestMaxLoad {
+				bestChId = chId
+				bestDstServerIndex = dstServerIndex
+				bestMaxLoad = maxLoad
+				found = true
+			}
+		}
+	}
+	return bestChId, bestDstServerIndex, found
+}
+
+// Sets the owner of the spatial channel to the dst server, and hands over the entity channels in the grid.
+// The src server keeps the read access to the spatial channel, as it may still be in its interest area.
+func (ctl *DynamicGrid2DSpatialController) migrateGrid(chId common.ChannelId, dstServerIndex uint32) error {
+	ch := GetChannel(chId)
+	if ch == nil {
+		return fmt.Errorf("spatial channel %d doesn't exist", chId)
+	}
+	dstOwner := ctl.serverConnections[dstServerIndex]
+	if dstOwner == nil || dstOwner.IsClosing() {
+		return fmt.Errorf("spatial server %d is not connected", dstServerIndex)
+	}
+	srcOwner := ch.GetOwner()
+
+	ch.SetOwner(dstOwner)
+	cs, shouldSend := dstOwner.SubscribeToChannel(ch, &channeldpb.ChannelSubscriptionOptions{
+		DataAccess: Pointer(channeldpb.ChannelDataAccess_WRITE_ACCESS),
+	})
+	if cs != nil && shouldSend {
+		dstOwner.sendSubscribed(MessageContext{}, ch, dstOwner, 0, &cs.options)
+	}
+
+	if srcOwner != nil && !srcOwner.IsClosing() {
+		cs, shouldSend := srcOwner.SubscribeToChannel(ch, &channeldpb.ChannelSubscriptionOptions{
+			DataAccess: Pointer(channeldpb.ChannelDataAccess_READ_ACCESS),
+		})
+		if cs != nil && shouldSend {
+			srcOwner.sendSubscribed(MessageContext{}, ch, srcOwner, 0, &cs.options)
+		}
+	}
+
+	// Hand over the entity channels in the grid, along with their handover data. The spatial info of the entity should be read in the entity channel's goroutine.
+	allChannels.Range(func(_ common.ChannelId, entityCh *Channel) bool {
+		if srcOwner == nil || entityCh.channelType != channeldpb.ChannelType_ENTITY || entityCh.GetOwner() != srcOwner {
+			return true
+		}
+
+		entityCh.Execute(func(entityCh *Channel) {
+			dataMsg, ok := entityCh.GetDataMessage().(EntityChannelDataWithSpatialInfo)
+			if !ok || dataMsg.GetSpatialInfo() == nil {
+				return
+			}
+			if entityChId, err := ctl.GetChannelId(*dataMsg.GetSpatialInfo()); err != nil || entityChId != chId {
+				return
+			}
+			// The entity may have been handed over before the execution
+			if entityCh.GetOwner() != srcOwner {
+				return
+			}
+
+			handoverSpatialEntitiesFrom(chId, chId, srcOwner, func(_ common.ChannelId, _ common.ChannelId, data interface{}) {
+				if entityId, ok := data.(*EntityId); ok {
+					*entityId = EntityId(entityCh.id)
+				}
+			})
+		})
+		return true
+	})
+
+	rootLogger.Info("migrated spatial channel",
+		zap.Uint32("channelId", uint32(chId)),
+		zap.Uint32("dstServerIndex", dstServerIndex),
+		zap.Uint32("dstConnId", uint32(dstOwner.Id())),
+	)
+	return nil
+}
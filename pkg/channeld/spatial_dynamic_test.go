@@ -0,0 +1,155 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicGrid2DLoadConfig(t *testing.T) {
+	ctl := &DynamicGrid2DSpatialController{}
+	assert.NoError(t, ctl.LoadConfig([]byte(`{"GridWidth": 10, "GridHeight": 10, "GridCols": 4, "GridRows": 1, "ServerCols": 2, "ServerRows": 1, "ServerInterestBorderSize": 1, "LoadImbalanceRatio": 2}`)))
+	assert.EqualValues(t, 4, ctl.GridCols)
+	assert.EqualValues(t, 2, ctl.LoadImbalanceRatio)
+	// Default values
+	assert.EqualValues(t, 5000, ctl.RebalanceIntervalMs)
+	assert.EqualValues(t, 1, ctl.MaxGridsPerRebalance)
+
+	assert.Error(t, ctl.LoadConfig([]byte(`{"GridWidth": 10, "GridHeight": 10, "GridCols": 4, "GridRows": 1, "ServerCols": 2, "ServerRows": 1, "ServerInterestBorderSize": 1, "LoadImbalanceRatio": 0.5}`)))
+}
+
+func TestDynamicGrid2DRebalance(t *testing.T) {
+	InitChannels()
+
+	// 4-by-1-grid world consists of 2-by-1-grid servers
+	ctl := &DynamicGrid2DSpatialController{
+		StaticGrid2DSpatialController: StaticGrid2DSpatialController{
+			GridWidth:                10,
+			GridHeight:               10,
+			GridCols:                 4,
+			GridRows:                 1,
+			ServerCols:               2,
+			ServerRows:               1,
+			ServerInterestBorderSize: 1,
+		},
+		LoadImbalanceRatio:   1.5,
+		MinLoadToRebalance:   100,
+		MaxGridsPerRebalance: 2,
+	}
+
+	conns := []*testConnection{createTestConnection(), createTestConnection()}
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_CREATE_CHANNEL,
+		Msg:     &channeldpb.CreateChannelMessage{},
+	}
+	for _, conn := range conns {
+		ctx.Connection = conn
+		_, err := ctl.CreateChannels(ctx)
+		assert.NoError(t, err)
+	}
+
+	/* Grids and Servers:
+	0  1  |  2  3
+	*/
+	regions, err := ctl.GetRegions()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, regions[1].ServerIndex)
+	assert.EqualValues(t, 1, regions[2].ServerIndex)
+
+	chId := func(index uint32) common.ChannelId {
+		return GlobalSettings.SpatialChannelIdStart + common.ChannelId(index)
+	}
+
+	// Not overloaded
+	assert.Equal(t, 0, ctl.rebalance(map[common.ChannelId]float64{chId(0): 40, chId(1): 40, chId(2): 10, chId(3): 10}))
+
+	// Only the grid adjacent to server 1 can be migrated, and the second migration is not necessary.
+	assert.Equal(t, 1, ctl.rebalance(map[common.ChannelId]float64{chId(0): 100, chId(1): 100, chId(2): 10, chId(3): 10}))
+	assert.Equal(t, conns[1], GetChannel(chId(1)).GetOwner())
+	assert.Contains(t, conns[1].subscribedChannels, chId(1))
+	regions, err = ctl.GetRegions()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, regions[0].ServerIndex)
+	assert.EqualValues(t, 1, regions[1].ServerIndex)
+
+	// A server should keep at least one grid
+	assert.Equal(t, 0, ctl.rebalance(map[common.ChannelId]float64{chId(0): 1000, chId(1): 10, chId(2): 10, chId(3): 10}))
+	assert.Equal(t, conns[0], GetChannel(chId(0)).GetOwner())
+}
+
+// The entity data that merges its text to the spatial channel data for handover
+type testHandoverEntityData struct {
+	*testEntityDataWithSpatialInfo
+}
+
+func (d *testHandoverEntityData) MergeTo(spatialChannelData common.Message, fullData bool) error {
+	if spatialData, ok := spatialChannelData.(*testpb.TestChannelDataMessage); ok {
+		spatialData.Text = d.Text
+	}
+	return nil
+}
+
+func TestDynamicGrid2DMigrateGridWithHandoverData(t *testing.T) {
+	isolateChannelTest(t)
+	if _, exists := channelDataTypeRegistery[channeldpb.ChannelType_SPATIAL]; !exists {
+		RegisterChannelDataType(channeldpb.ChannelType_SPATIAL, &testpb.TestChannelDataMessage{})
+		defer delete(channelDataTypeRegistery, channeldpb.ChannelType_SPATIAL)
+	}
+
+	// 4-by-1-grid world consists of 2-by-1-grid servers
+	ctl := &DynamicGrid2DSpatialController{
+		StaticGrid2DSpatialController: StaticGrid2DSpatialController{
+			GridWidth:                10,
+			GridHeight:               10,
+			GridCols:                 4,
+			GridRows:                 1,
+			ServerCols:               2,
+			ServerRows:               1,
+			ServerInterestBorderSize: 1,
+		},
+	}
+	srcServer := addTestConnection(channeldpb.ConnectionType_SERVER)
+	dstServer := addTestConnection(channeldpb.ConnectionType_SERVER)
+	for _, conn := range []*Connection{srcServer, dstServer} {
+		_, err := ctl.CreateChannels(MessageContext{
+			MsgType:    channeldpb.MessageType_CREATE_CHANNEL,
+			Msg:        &channeldpb.CreateChannelMessage{},
+			Connection: conn,
+		})
+		assert.NoError(t, err)
+	}
+	chId := GlobalSettings.SpatialChannelIdStart + 1
+
+	// The entity in grid 1
+	entityCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+1, channeldpb.ChannelType_ENTITY, srcServer)
+	entityCh.Execute(func(ch *Channel) {
+		ch.InitData(&testHandoverEntityData{&testEntityDataWithSpatialInfo{
+			TestChannelDataMessage: &testpb.TestChannelDataMessage{Text: "entity"},
+			spatialInfo:            &common.SpatialInfo{X: 15, Z: 5},
+		}}, nil)
+	})
+
+	assert.NoError(t, ctl.migrateGrid(chId, 1))
+	assert.Equal(t, dstServer, GetChannel(chId).GetOwner())
+
+	// The dst server receives the handover data of the entity, and takes over the entity
+	receivedHandoverData := func() bool {
+		for _, msg := range dstServer.testQueue() {
+			handoverMsg, ok := msg.(*channeldpb.ChannelDataHandoverMessage)
+			if !ok || handoverMsg.SrcChannelId != uint32(chId) || handoverMsg.DstChannelId != uint32(chId) {
+				continue
+			}
+			data := &testpb.TestChannelDataMessage{}
+			if handoverMsg.Data.UnmarshalTo(data) == nil && data.Text == "entity" {
+				return true
+			}
+		}
+		return false
+	}
+	assert.Eventually(t, receivedHandoverData, time.Second, 10*time.Millisecond)
+	assert.Equal(t, dstServer, entityCh.GetOwner())
+}
@@ -0,0 +1,144 @@
+package channeld
+
+import (
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Spatial server failover
+ * Without the failover, the spatial channels of a disconnected spatial server are left ownerless (or removed if RemoveChannelAfterOwnerRemoved is set),
+ * and a new spatial server that takes the same serverIndex creates the spatial channels from scratch.
+ * With the failover enabled, the spatial channels and the entity channels in them are kept along with their data. The next spatial server that sends
+ * CREATE_SPATIAL_CHANNEL takes over the serverIndex, becomes the owner of the orphaned channels, and the ChannelOwnerChangedMessage is sent to the
+ * connections in these channels.
+ */
+
+// Implemented by the spatial controllers that support the spatial server failover.
+type spatialServerFailoverController interface {
+	IsServerFailoverEnabled() bool
+}
+
+func (ctl *StaticGrid2DSpatialController) IsServerFailoverEnabled() bool {
+	return ctl.EnableServerFailover
+}
+
+// Returns true if the channel should not be removed after its owner is removed, so the replacement spatial server can take it over.
+// The entity channels are kept only if they have the spatial info, as it's required to find out which spatial channel they are in.
+func shouldKeepChannelForFailover(ch *Channel) bool {
+	var spatialCtl SpatialController
+	switch ch.channelType {
+	case channeldpb.ChannelType_SPATIAL:
+		spatialCtl = GetSpatialControllerByChannelId(ch.id)
+	case channeldpb.ChannelType_ENTITY:
+		if ch.GetSpatialInfo() == nil {
+			return false
+		}
+		spatialCtl = GetSpatialControllerOfWorld(ch.spatialWorldId)
+	default:
+		return false
+	}
+	failoverCtl, ok := spatialCtl.(spatialServerFailoverController)
+	return ok && failoverCtl.IsServerFailoverEnabled()
+}
+
+func isOrphanedChannel(ch *Channel) bool {
+	owner := ch.GetOwner()
+	return owner == nil || owner.IsClosing()
+}
+
+// Returns the existing spatial channels if all of them are orphaned, or nil if any of them doesn't exist or still has the owner.
+func getOrphanedSpatialChannels(channelIds []common.ChannelId) []*Channel {
+	channels := make([]*Channel, len(channelIds))
+	for i, channelId := range channelIds {
+		ch := GetChannel(channelId)
+		if ch == nil || ch.IsRemoving() || !isOrphanedChannel(ch) {
+			return nil
+		}
+		channels[i] = ch
+	}
+	return channels
+}
+
+// Makes the connection the owner of the orphaned spatial channels of the serverIndex, as well as the orphaned entity channels in them.
+// Runs in the GLOBAL channel's goroutine.
+func (ctl *StaticGrid2DSpatialController) takeOverSpatialChannels(serverIndex uint32, channels []*Channel, serverConn ConnectionInChannel) {
+	worldId := GetSpatialWorldIdByChannelId(channels[0].id)
+	spatialChIds := make(map[common.ChannelId]struct{}, len(channels))
+	changedChannels := make([]*Channel, 0, len(channels))
+	for _, ch := range channels {
+		ch.SetOwner(serverConn)
+		spatialChIds[ch.id] = struct{}{}
+		changedChannels = append(changedChannels, ch)
+	}
+
+	subOptions := &channeldpb.ChannelSubscriptionOptions{
+		DataAccess: Pointer(channeldpb.ChannelDataAccess_WRITE_ACCESS),
+	}
+	allChannels.Range(func(_ common.ChannelId, entityCh *Channel) bool {
+		if entityCh.channelType != channeldpb.ChannelType_ENTITY || entityCh.IsRemoving() || entityCh.spatialWorldId != worldId || !isOrphanedChannel(entityCh) {
+			return true
+		}
+		info := entityCh.GetSpatialInfo()
+		if info == nil {
+			return true
+		}
+		spatialChId, err := ctl.GetChannelId(*info)
+		if err != nil {
+			return true
+		}
+		if _, exists := spatialChIds[spatialChId]; !exists {
+			return true
+		}
+
+		// Set the owner immediately, so the entity channel won't be taken over twice.
+		entityCh.SetOwner(serverConn)
+		changedChannels = append(changedChannels, entityCh)
+		entityCh.Execute(func(ch *Channel) {
+			cs, shouldSend := serverConn.SubscribeToChannel(ch, subOptions)
+			if cs != nil && shouldSend {
+				serverConn.sendSubscribed(MessageContext{}, ch, serverConn, 0, &cs.options)
+			}
+		})
+		return true
+	})
+
+	rootLogger.Info("spatial server took over the orphaned channels",
+		zap.Uint32("serverIndex", serverIndex),
+		zap.Uint32("connId", uint32(serverConn.Id())),
+		zap.Int("spatialChannels", len(channels)),
+		zap.Int("entityChannels", len(changedChannels)-len(channels)),
+	)
+
+	sendChannelOwnerChanged(serverIndex, changedChannels, serverConn)
+}
+
+// Sends the ChannelOwnerChangedMessage to all the connections in the channels (once for each connection), and the GLOBAL channel owner.
+func sendChannelOwnerChanged(serverIndex uint32, channels []*Channel, ownerConn ConnectionInChannel) {
+	msg := &channeldpb.ChannelOwnerChangedMessage{
+		ChannelIds:  make([]uint32, len(channels)),
+		OwnerConnId: uint32(ownerConn.Id()),
+		ServerIndex: serverIndex,
+	}
+	conns := make(map[ConnectionInChannel]struct{})
+	for i, ch := range channels {
+		msg.ChannelIds[i] = uint32(ch.id)
+		for conn := range ch.GetAllConnections() {
+			conns[conn] = struct{}{}
+		}
+	}
+	if globalOwner := globalChannel.GetOwner(); globalOwner != nil {
+		conns[globalOwner] = struct{}{}
+	}
+
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_CHANNEL_OWNER_CHANGED,
+		Msg:     msg,
+	}
+	for conn := range conns {
+		if conn == ownerConn || conn.IsClosing() {
+			continue
+		}
+		conn.Send(ctx)
+	}
+}
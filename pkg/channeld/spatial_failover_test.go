@@ -0,0 +1,93 @@
+package channeld
+
+import (
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpatialServerFailover(t *testing.T) {
+	InitChannels()
+
+	// 2-by-1-grid world, 1:1 grid:server
+	ctl := &StaticGrid2DSpatialController{
+		GridWidth:            100,
+		GridHeight:           100,
+		GridCols:             2,
+		GridRows:             1,
+		ServerCols:           2,
+		ServerRows:           1,
+		EnableServerFailover: true,
+	}
+
+	savedController := spatialController
+	spatialController = ctl
+	defer func() {
+		spatialController = savedController
+	}()
+
+	conns := []*testConnection{createTestConnection(), createTestConnection()}
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_CREATE_CHANNEL,
+		Msg:     &channeldpb.CreateChannelMessage{},
+	}
+	serverChannels := make([][]*Channel, len(conns))
+	for i, conn := range conns {
+		ctx.Connection = conn
+		channels, err := ctl.CreateChannels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, channels, 1)
+		serverChannels[i] = channels
+	}
+	server0Data := serverChannels[0][0].Data()
+
+	// The entities in grid 0 and grid 1
+	entityCh0 := createChannelWithId(GlobalSettings.EntityChannelIdStart+1, channeldpb.ChannelType_ENTITY, conns[0])
+	entityCh0.spatialInfo.Store(&common.SpatialInfo{X: 50, Z: 50})
+	entityCh1 := createChannelWithId(GlobalSettings.EntityChannelIdStart+2, channeldpb.ChannelType_ENTITY, conns[1])
+	entityCh1.spatialInfo.Store(&common.SpatialInfo{X: 150, Z: 50})
+	// The entity without the spatial info
+	entityCh2 := createChannelWithId(GlobalSettings.EntityChannelIdStart+3, channeldpb.ChannelType_ENTITY, conns[0])
+	defer func() {
+		entityCh0.removing = 1
+		entityCh1.removing = 1
+		entityCh2.removing = 1
+	}()
+
+	assert.True(t, shouldKeepChannelForFailover(serverChannels[0][0]))
+	assert.True(t, shouldKeepChannelForFailover(entityCh0))
+	assert.False(t, shouldKeepChannelForFailover(entityCh2))
+
+	// Server 0 is disconnected
+	conns[0].closing = true
+	ctl.Tick()
+	assert.EqualValues(t, 0, ctl.nextServerIndex())
+
+	// The replacement server takes over the spatial channel and its data
+	replacementConn := createTestConnection()
+	ctx.Connection = replacementConn
+	channels, err := ctl.CreateChannels(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, channels, 1)
+	assert.Same(t, serverChannels[0][0], channels[0])
+	assert.Same(t, server0Data, channels[0].Data())
+	assert.Equal(t, replacementConn, channels[0].GetOwner())
+	assert.EqualValues(t, 2, ctl.nextServerIndex())
+
+	// Only the orphaned entity in the grid is taken over
+	assert.Equal(t, replacementConn, entityCh0.GetOwner())
+	assert.Equal(t, conns[1], entityCh1.GetOwner())
+	assert.Equal(t, conns[0], entityCh2.GetOwner())
+
+	// Without the failover, the spatial channel is created again
+	ctl.EnableServerFailover = false
+	assert.False(t, shouldKeepChannelForFailover(serverChannels[0][0]))
+	replacementConn.closing = true
+	ctl.Tick()
+	ctx.Connection = createTestConnection()
+	channels, err = ctl.CreateChannels(ctx)
+	assert.NoError(t, err)
+	assert.NotSame(t, serverChannels[0][0], channels[0])
+}
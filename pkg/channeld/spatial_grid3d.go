@@ -0,0 +1,567 @@
+package channeld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+// Divides the world into GridCols x GridLayers x GridRows static cubes(grids). Each grid represents a spatial channel.
+// Unlike StaticGrid2DSpatialController, the Y axis is also divided, so the vertically stacked areas (e.g. the decks of a spaceship)
+// are in different spatial channels. The grid at (x, y, z) has the index of x + z*GridCols + y*GridCols*GridRows.
+type StaticGrid3DSpatialController struct {
+	SpatialController
+	SpatialChannelIdRange
+
+	/* Defines how the world is divided into grids */
+	// The size of a grid in X axis, in the simulation/engine units
+	GridWidth float64
+	// The size of a grid in Z axis, in the simulation/engine units
+	GridHeight float64
+	// The size of a grid in Y axis (the vertical axis), in the simulation/engine units
+	GridLayerHeight float64
+	// How many grids the world has in X axis.
+	GridCols uint32
+	// How many grids the world has in Z axis.
+	GridRows uint32
+	// How many grids the world has in Y axis.
+	GridLayers uint32
+
+	// The difference between the world origin and the bottom-left-near corner of the first grid, in the simulation/engine units.
+	WorldOffsetX float64
+	WorldOffsetY float64
+	WorldOffsetZ float64
+
+	/* Defines the authority area of a spatial server, as well as the number of the servers (= ServerCols * ServerRows * ServerLayers) */
+	// How many servers the world has in X axis.
+	ServerCols uint32
+	// How many servers the world has in Z axis.
+	ServerRows uint32
+	// How many servers the world has in Y axis.
+	ServerLayers uint32
+
+	// For each side of a server's grids (authority area), how many grids(spatial channels) the server subscribes to, as the extend of its interest area.
+	// 0 = the server only subscribes to its own grids.
+	ServerInterestBorderSize uint32
+
+	serverConnections []ConnectionInChannel
+}
+
+type gridCoord3D struct {
+	x, y, z int
+}
+
+func (ctl *StaticGrid3DSpatialController) gridCount() uint32 {
+	return ctl.GridCols * ctl.GridRows * ctl.GridLayers
+}
+
+func (ctl *StaticGrid3DSpatialController) serverCount() uint32 {
+	return ctl.ServerCols * ctl.ServerRows * ctl.ServerLayers
+}
+
+// Returns how many grids a server has in X, Y, and Z axis. The grids are evenly divided, see LoadConfig.
+func (ctl *StaticGrid3DSpatialController) serverGridSize() (cols, layers, rows uint32) {
+	cols = ctl.GridCols / ctl.ServerCols
+	layers = ctl.GridLayers / ctl.ServerLayers
+	rows = ctl.GridRows / ctl.ServerRows
+	return
+}
+
+func (ctl *StaticGrid3DSpatialController) LoadConfig(config []byte) error {
+	err := json.Unmarshal(config, ctl)
+	if err != nil {
+		return err
+	}
+	if ctl.GridWidth <= 0 || ctl.GridHeight <= 0 || ctl.GridLayerHeight <= 0 {
+		return errors.New("GridWidth, GridHeight and GridLayerHeight should be positive")
+	}
+	if ctl.GridCols <= 0 || ctl.GridRows <= 0 || ctl.GridLayers <= 0 {
+		return errors.New("GridCols, GridRows and GridLayers should be positive")
+	}
+	if ctl.ServerCols <= 0 || ctl.ServerRows <= 0 || ctl.ServerLayers <= 0 {
+		return errors.New("ServerCols, ServerRows and ServerLayers should be positive")
+	}
+	if ctl.ServerCols > ctl.GridCols || ctl.ServerRows > ctl.GridRows || ctl.ServerLayers > ctl.GridLayers {
+		return errors.New("a server should have at least one grid in each axis")
+	}
+	// Otherwise the last servers in an axis would have fewer grids, or even none.
+	if ctl.GridCols%ctl.ServerCols != 0 || ctl.GridRows%ctl.ServerRows != 0 || ctl.GridLayers%ctl.ServerLayers != 0 {
+		return errors.New("GridCols, GridRows and GridLayers should be divisible by ServerCols, ServerRows and ServerLayers")
+	}
+	gridCount := uint64(ctl.GridCols) * uint64(ctl.GridRows) * uint64(ctl.GridLayers)
+	if gridCount > uint64(GlobalSettings.EntityChannelIdStart-GlobalSettings.SpatialChannelIdStart) {
+		return fmt.Errorf("%d grids exceed the range of the spatial channel ids", gridCount)
+	}
+	return nil
+}
+
+func (ctl *StaticGrid3DSpatialController) getGridCoord(x, y, z float64) gridCoord3D {
+	return gridCoord3D{
+		x: int(math.Floor((x - ctl.WorldOffsetX) / ctl.GridWidth)),
+		y: int(math.Floor((y - ctl.WorldOffsetY) / ctl.GridLayerHeight)),
+		z: int(math.Floor((z - ctl.WorldOffsetZ) / ctl.GridHeight)),
+	}
+}
+
+func (ctl *StaticGrid3DSpatialController) isInWorld(c gridCoord3D) bool {
+	return c.x >= 0 && c.x < int(ctl.GridCols) &&
+		c.y >= 0 && c.y < int(ctl.GridLayers) &&
+		c.z >= 0 && c.z < int(ctl.GridRows)
+}
+
+func (ctl *StaticGrid3DSpatialController) getChannelIdByCoord(c gridCoord3D) common.ChannelId {
+	index := uint32(c.x) + uint32(c.z)*ctl.GridCols + uint32(c.y)*ctl.GridCols*ctl.GridRows
+	return common.ChannelId(index) + ctl.ChannelIdStart()
+}
+
+func (ctl *StaticGrid3DSpatialController) getCoordByChannelId(spatialChannelId common.ChannelId) (gridCoord3D, error) {
+	if spatialChannelId < ctl.ChannelIdStart() {
+		return gridCoord3D{}, fmt.Errorf("channel %d is not a spatial channel", spatialChannelId)
+	}
+	index := uint32(spatialChannelId - ctl.ChannelIdStart())
+	if index >= ctl.gridCount() {
+		return gridCoord3D{}, fmt.Errorf("spatial channel %d is out of the %d grids", spatialChannelId, ctl.gridCount())
+	}
+	return gridCoord3D{
+		x: int(index % ctl.GridCols),
+		y: int(index / (ctl.GridCols * ctl.GridRows)),
+		z: int(index / ctl.GridCols % ctl.GridRows),
+	}, nil
+}
+
+func (ctl *StaticGrid3DSpatialController) getServerIndex(c gridCoord3D) uint32 {
+	serverGridCols, serverGridLayers, serverGridRows := ctl.serverGridSize()
+	serverX := uint32(c.x) / serverGridCols
+	serverY := uint32(c.y) / serverGridLayers
+	serverZ := uint32(c.z) / serverGridRows
+	return serverX + serverZ*ctl.ServerCols + serverY*ctl.ServerCols*ctl.ServerRows
+}
+
+func (ctl *StaticGrid3DSpatialController) getGridMin(c gridCoord3D) common.SpatialInfo {
+	return common.SpatialInfo{
+		X: ctl.WorldOffsetX + ctl.GridWidth*float64(c.x),
+		Y: ctl.WorldOffsetY + ctl.GridLayerHeight*float64(c.y),
+		Z: ctl.WorldOffsetZ + ctl.GridHeight*float64(c.z),
+	}
+}
+
+// Returns the distance between the point and the nearest point of the grid. 0 if the point is inside the grid.
+func (ctl *StaticGrid3DSpatialController) distToGrid(c gridCoord3D, p *common.SpatialInfo) float64 {
+	min := ctl.getGridMin(c)
+	dx := math.Max(0, math.Max(min.X-p.X, p.X-(min.X+ctl.GridWidth)))
+	dy := math.Max(0, math.Max(min.Y-p.Y, p.Y-(min.Y+ctl.GridLayerHeight)))
+	dz := math.Max(0, math.Max(min.Z-p.Z, p.Z-(min.Z+ctl.GridHeight)))
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Calls the callback for each grid in the world that overlaps with the axis-aligned box.
+func (ctl *StaticGrid3DSpatialController) forEachGridInBox(min, max common.SpatialInfo, callback func(gridCoord3D)) {
+	from := ctl.getGridCoord(min.X, min.Y, min.Z)
+	to := ctl.getGridCoord(max.X, max.Y, max.Z)
+	for y := maxInt(from.y, 0); y <= minInt(to.y, int(ctl.GridLayers)-1); y++ {
+		for z := maxInt(from.z, 0); z <= minInt(to.z, int(ctl.GridRows)-1); z++ {
+			for x := maxInt(from.x, 0); x <= minInt(to.x, int(ctl.GridCols)-1); x++ {
+				callback(gridCoord3D{x, y, z})
+			}
+		}
+	}
+}
+
+// The distance is the number of grids between the two grids in any of the axises, so the 26 neighbours are all at the distance of 1.
+func gridDist3D(c1, c2 gridCoord3D) uint {
+	dx := c1.x - c2.x
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := c1.y - c2.y
+	if dy < 0 {
+		dy = -dy
+	}
+	dz := c1.z - c2.z
+	if dz < 0 {
+		dz = -dz
+	}
+	return uint(maxInt(dx, maxInt(dy, dz)))
+}
+
+func (ctl *StaticGrid3DSpatialController) GetChannelId(info common.SpatialInfo) (common.ChannelId, error) {
+	c := ctl.getGridCoord(info.X, info.Y, info.Z)
+	if !ctl.isInWorld(c) {
+		return 0, fmt.Errorf("grid (%d, %d, %d) of %s is out of the world (%d, %d, %d)", c.x, c.y, c.z, info.String(), ctl.GridCols, ctl.GridLayers, ctl.GridRows)
+	}
+	return ctl.getChannelIdByCoord(c), nil
+}
+
+// All the AOIs are evaluated in 3D. The distance in the result is the number of grids from the grid of the AOI's center.
+func (ctl *StaticGrid3DSpatialController) QueryChannelIds(query *channeldpb.SpatialInterestQuery) (map[common.ChannelId]uint, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query is nil")
+	}
+
+	result := make(map[common.ChannelId]uint)
+
+	if query.SpotsAOI != nil {
+		for i, spot := range query.SpotsAOI.Spots {
+			chId, err := ctl.GetChannelId(common.SpatialInfo{X: spot.X, Y: spot.Y, Z: spot.Z})
+			if err != nil {
+				continue
+			}
+			if i < len(query.SpotsAOI.Dists) {
+				setNearestDist(result, chId, uint(query.SpotsAOI.Dists[i]))
+			} else {
+				// If distance is not specified, the spot will be considered as always at the nearest distance.
+				result[chId] = 0
+			}
+		}
+	}
+
+	if query.BoxAOI != nil {
+		center := &common.SpatialInfo{X: query.BoxAOI.Center.X, Y: query.BoxAOI.Center.Y, Z: query.BoxAOI.Center.Z}
+		extent := query.BoxAOI.Extent
+		if extent.X < 0 || extent.Y < 0 || extent.Z < 0 {
+			return nil, fmt.Errorf("invalid box extent=(%f, %f, %f)", extent.X, extent.Y, extent.Z)
+		}
+		centerCoord := ctl.getGridCoord(center.X, center.Y, center.Z)
+		if !ctl.isInWorld(centerCoord) {
+			return nil, fmt.Errorf("the center of the box %s is out of the world", center.String())
+		}
+
+		ctl.forEachGridInBox(
+			common.SpatialInfo{X: center.X - extent.X, Y: center.Y - extent.Y, Z: center.Z - extent.Z},
+			common.SpatialInfo{X: center.X + extent.X, Y: center.Y + extent.Y, Z: center.Z + extent.Z},
+			func(c gridCoord3D) {
+				setNearestDist(result, ctl.getChannelIdByCoord(c), gridDist3D(c, centerCoord))
+			})
+	}
+
+	if query.SphereAOI != nil {
+		r := query.SphereAOI.Radius
+		center := &common.SpatialInfo{X: query.SphereAOI.Center.X, Y: query.SphereAOI.Center.Y, Z: query.SphereAOI.Center.Z}
+		if r <= 0 {
+			return nil, fmt.Errorf("invalid radius=%f", r)
+		}
+		centerCoord := ctl.getGridCoord(center.X, center.Y, center.Z)
+		if !ctl.isInWorld(centerCoord) {
+			return nil, fmt.Errorf("the center of the sphere %s is out of the world", center.String())
+		}
+
+		ctl.forEachGridInBox(
+			common.SpatialInfo{X: center.X - r, Y: center.Y - r, Z: center.Z - r},
+			common.SpatialInfo{X: center.X + r, Y: center.Y + r, Z: center.Z + r},
+			func(c gridCoord3D) {
+				if ctl.distToGrid(c, center) > r {
+					return
+				}
+				setNearestDist(result, ctl.getChannelIdByCoord(c), gridDist3D(c, centerCoord))
+			})
+	}
+
+	if query.ConeAOI != nil {
+		r := query.ConeAOI.Radius
+		center := &common.SpatialInfo{X: query.ConeAOI.Center.X, Y: query.ConeAOI.Center.Y, Z: query.ConeAOI.Center.Z}
+		coneDir := &common.SpatialInfo{X: query.ConeAOI.Direction.X, Y: query.ConeAOI.Direction.Y, Z: query.ConeAOI.Direction.Z}
+		if r <= 0 {
+			return nil, fmt.Errorf("invalid radius=%f", r)
+		}
+		if coneDir.Magnitude3D() == 0 {
+			return nil, errors.New("the direction of the cone is zero")
+		}
+		coneDir.Normalize3D()
+		centerCoord := ctl.getGridCoord(center.X, center.Y, center.Z)
+		if !ctl.isInWorld(centerCoord) {
+			return nil, fmt.Errorf("the center of the cone %s is out of the world", center.String())
+		}
+
+		ctl.forEachGridInBox(
+			common.SpatialInfo{X: center.X - r, Y: center.Y - r, Z: center.Z - r},
+			common.SpatialInfo{X: center.X + r, Y: center.Y + r, Z: center.Z + r},
+			func(c gridCoord3D) {
+				if c != centerCoord && !ctl.coneIntersectsGrid(c, center, coneDir, query.ConeAOI.Angle, r) {
+					return
+				}
+				setNearestDist(result, ctl.getChannelIdByCoord(c), gridDist3D(c, centerCoord))
+			})
+	}
+
+	return result, nil
+}
+
+// Returns true if the cone (truncated by the radius) intersects with the grid. The angle is between the (normalized) direction and the edge of the cone.
+// The check is approximate: the grid intersects if the axis of the cone passes through it, or any of the 27 sample points
+// (the corners, the centers of the edges and the faces, and the center) of the grid is inside the cone.
+func (ctl *StaticGrid3DSpatialController) coneIntersectsGrid(c gridCoord3D, apex *common.SpatialInfo, dir *common.SpatialInfo, angle float64, r float64) bool {
+	min := ctl.getGridMin(c)
+	max := common.SpatialInfo{X: min.X + ctl.GridWidth, Y: min.Y + ctl.GridLayerHeight, Z: min.Z + ctl.GridHeight}
+	end := common.SpatialInfo{X: apex.X + dir.X*r, Y: apex.Y + dir.Y*r, Z: apex.Z + dir.Z*r}
+	if segmentIntersectsBox(apex, &end, &min, &max) {
+		return true
+	}
+
+	cos := math.Cos(angle)
+	for i := 0; i <= 2; i++ {
+		for j := 0; j <= 2; j++ {
+			for k := 0; k <= 2; k++ {
+				d := common.SpatialInfo{
+					X: min.X + ctl.GridWidth*float64(i)*0.5 - apex.X,
+					Y: min.Y + ctl.GridLayerHeight*float64(j)*0.5 - apex.Y,
+					Z: min.Z + ctl.GridHeight*float64(k)*0.5 - apex.Z,
+				}
+				dist := d.Magnitude3D()
+				if dist > r {
+					continue
+				}
+				if dist == 0 || d.Dot3D(dir) >= dist*cos {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Slab method
+func segmentIntersectsBox(p0, p1, min, max *common.SpatialInfo) bool {
+	tMin, tMax := 0.0, 1.0
+	for _, axis := range [][4]float64{
+		{p0.X, p1.X - p0.X, min.X, max.X},
+		{p0.Y, p1.Y - p0.Y, min.Y, max.Y},
+		{p0.Z, p1.Z - p0.Z, min.Z, max.Z},
+	} {
+		start, delta, lo, hi := axis[0], axis[1], axis[2], axis[3]
+		if delta == 0 {
+			if start < lo || start > hi {
+				return false
+			}
+			continue
+		}
+		t0, t1 := (lo-start)/delta, (hi-start)/delta
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		tMin = math.Max(tMin, t0)
+		tMax = math.Min(tMax, t1)
+		if tMin > tMax {
+			return false
+		}
+	}
+	return true
+}
+
+func (ctl *StaticGrid3DSpatialController) GetRegions() ([]*channeldpb.SpatialRegion, error) {
+	regions := make([]*channeldpb.SpatialRegion, ctl.gridCount())
+	for y := 0; y < int(ctl.GridLayers); y++ {
+		for z := 0; z < int(ctl.GridRows); z++ {
+			for x := 0; x < int(ctl.GridCols); x++ {
+				c := gridCoord3D{x, y, z}
+				chId := ctl.getChannelIdByCoord(c)
+				min := ctl.getGridMin(c)
+				regions[chId-ctl.ChannelIdStart()] = &channeldpb.SpatialRegion{
+					Min: &channeldpb.SpatialInfo{
+						X: min.X,
+						Y: min.Y,
+						Z: min.Z,
+					},
+					Max: &channeldpb.SpatialInfo{
+						X: min.X + ctl.GridWidth,
+						Y: min.Y + ctl.GridLayerHeight,
+						Z: min.Z + ctl.GridHeight,
+					},
+					ChannelId:   uint32(chId),
+					ServerIndex: ctl.getServerIndex(c),
+				}
+			}
+		}
+	}
+	return regions, nil
+}
+
+// Returns the (up to 26) grids that share a face, an edge or a corner with the spatial channel's grid.
+func (ctl *StaticGrid3DSpatialController) GetAdjacentChannels(spatialChannelId common.ChannelId) ([]common.ChannelId, error) {
+	center, err := ctl.getCoordByChannelId(spatialChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIds := make([]common.ChannelId, 0, 26)
+	for y := center.y - 1; y <= center.y+1; y++ {
+		for z := center.z - 1; z <= center.z+1; z++ {
+			for x := center.x - 1; x <= center.x+1; x++ {
+				c := gridCoord3D{x, y, z}
+				if c == center || !ctl.isInWorld(c) {
+					continue
+				}
+				channelIds = append(channelIds, ctl.getChannelIdByCoord(c))
+			}
+		}
+	}
+	return channelIds, nil
+}
+
+// Runs in the source spatial(V1)/entity(V2) channel (shared instance)
+func (ctl *StaticGrid3DSpatialController) Notify(oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) {
+	srcChannelId, err := ctl.GetChannelId(oldInfo)
+	if err != nil {
+		rootLogger.Error("failed to calculate srcChannelId", zap.Error(err), zap.String("oldInfo", oldInfo.String()))
+		return
+	}
+	dstChannelId, err := ctl.GetChannelId(newInfo)
+	if err != nil {
+		rootLogger.Error("failed to calculate dstChannelId", zap.Error(err), zap.String("newInfo", newInfo.String()))
+		return
+	}
+	// No migration between channels
+	if dstChannelId == srcChannelId {
+		return
+	}
+
+	handoverSpatialEntities(srcChannelId, dstChannelId, handoverDataProvider)
+}
+
+func (ctl *StaticGrid3DSpatialController) initServerConnections() {
+	if ctl.serverConnections == nil {
+		ctl.serverConnections = make([]ConnectionInChannel, ctl.serverCount())
+	}
+}
+
+func (ctl *StaticGrid3DSpatialController) nextServerIndex() uint32 {
+	var i int = 0
+	for i = 0; i < len(ctl.serverConnections); i++ {
+		if ctl.serverConnections[i] == nil || ctl.serverConnections[i].IsClosing() {
+			break
+		}
+	}
+	return uint32(i)
+}
+
+// Returns the first and the last grid of the server's authority area.
+func (ctl *StaticGrid3DSpatialController) getServerArea(serverIndex uint32) (from gridCoord3D, to gridCoord3D) {
+	serverGridCols, serverGridLayers, serverGridRows := ctl.serverGridSize()
+	serverX := serverIndex % ctl.ServerCols
+	serverZ := serverIndex / ctl.ServerCols % ctl.ServerRows
+	serverY := serverIndex / (ctl.ServerCols * ctl.ServerRows)
+	from = gridCoord3D{
+		x: int(serverX * serverGridCols),
+		y: int(serverY * serverGridLayers),
+		z: int(serverZ * serverGridRows),
+	}
+	to = gridCoord3D{
+		x: minInt(from.x+int(serverGridCols), int(ctl.GridCols)) - 1,
+		y: minInt(from.y+int(serverGridLayers), int(ctl.GridLayers)) - 1,
+		z: minInt(from.z+int(serverGridRows), int(ctl.GridRows)) - 1,
+	}
+	return
+}
+
+func (ctl *StaticGrid3DSpatialController) CreateChannels(ctx MessageContext) ([]*Channel, error) {
+	ctl.initServerConnections()
+	serverIndex := ctl.nextServerIndex()
+	if serverIndex >= ctl.serverCount() {
+		return nil, fmt.Errorf("failed to create spatail channel as all %d grids are allocated to %d servers", ctl.gridCount(), ctl.serverCount())
+	}
+
+	msg, ok := ctx.Msg.(*channeldpb.CreateChannelMessage)
+	if !ok {
+		return nil, errors.New("ctx.Msg is not a CreateChannelMessage, will not be handled")
+	}
+
+	from, to := ctl.getServerArea(serverIndex)
+	channels := make([]*Channel, 0, (to.x-from.x+1)*(to.y-from.y+1)*(to.z-from.z+1))
+	for y := from.y; y <= to.y; y++ {
+		for z := from.z; z <= to.z; z++ {
+			for x := from.x; x <= to.x; x++ {
+				channelId := ctl.getChannelIdByCoord(gridCoord3D{x, y, z})
+				channel := createChannelWithId(channelId, channeldpb.ChannelType_SPATIAL, ctx.Connection)
+				if msg.Data != nil {
+					dataMsg, err := msg.Data.UnmarshalNew()
+					if err != nil {
+						return nil, fmt.Errorf("failed to unmarshal data message for the new channel: %v", err)
+					} else {
+						channel.InitData(dataMsg, msg.MergeOptions)
+					}
+				} else {
+					// Channel data should always be initialized
+					channel.InitData(nil, msg.MergeOptions)
+				}
+				channels = append(channels, channel)
+			}
+		}
+	}
+
+	// Save the connection for later use
+	ctl.serverConnections[serverIndex] = ctx.Connection
+	serverIndex = ctl.nextServerIndex()
+	// When all spatial channels are created, subscribe each server to its adjacent grids(channels) if exists.
+	if serverIndex == ctl.serverCount() {
+		for i := uint32(0); i < serverIndex; i++ {
+			err := ctl.subToAdjacentChannels(i, msg.SubOptions)
+			if err != nil {
+				return channels, fmt.Errorf("failed to sub to adjacent channels of server connection %d, err: %v", ctl.serverConnections[i].Id(), err)
+			}
+		}
+
+		// ...and send the SpatialChannelsReadyMessage to all the spatial servers.
+		sendSpatialChannelsReady(ctl.serverConnections)
+	}
+
+	return channels, nil
+}
+
+// Subscribes the server to the other servers' grids within ServerInterestBorderSize of its authority area, in all the three axises.
+func (ctl *StaticGrid3DSpatialController) subToAdjacentChannels(serverIndex uint32, subOptions *channeldpb.ChannelSubscriptionOptions) error {
+	if ctl.ServerInterestBorderSize == 0 {
+		return nil
+	}
+
+	serverConn := ctl.serverConnections[serverIndex]
+	from, to := ctl.getServerArea(serverIndex)
+	border := int(ctl.ServerInterestBorderSize)
+	for y := maxInt(from.y-border, 0); y <= minInt(to.y+border, int(ctl.GridLayers)-1); y++ {
+		for z := maxInt(from.z-border, 0); z <= minInt(to.z+border, int(ctl.GridRows)-1); z++ {
+			for x := maxInt(from.x-border, 0); x <= minInt(to.x+border, int(ctl.GridCols)-1); x++ {
+				c := gridCoord3D{x, y, z}
+				if ctl.getServerIndex(c) == serverIndex {
+					continue
+				}
+				channelId := ctl.getChannelIdByCoord(c)
+				channelToSub := GetChannel(channelId)
+				if channelToSub == nil {
+					return fmt.Errorf("failed to subscribe border channel %d as it doesn't exist", channelId)
+				}
+				cs, shouldSend := serverConn.SubscribeToChannel(channelToSub, subOptions)
+				if shouldSend {
+					serverConn.sendSubscribed(MessageContext{}, channelToSub, serverConn, 0, &cs.options)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (ctl *StaticGrid3DSpatialController) Tick() {
+	ctl.initServerConnections()
+	for i := 0; i < len(ctl.serverConnections); i++ {
+		if ctl.serverConnections[i] != nil && ctl.serverConnections[i].IsClosing() {
+			ctl.serverConnections[i] = nil
+			rootLogger.Info("reset spatial server connection", zap.Int("serverIndex", i))
+		}
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,201 @@
+package channeld
+
+import (
+	"math"
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// 4x4x4 grids of 10x10x10, 2x2x2 servers
+func createTestGrid3DController(t *testing.T, borderSize uint32) *StaticGrid3DSpatialController {
+	ctl, err := CreateSpatialController("StaticGrid3DSpatialController", []byte(`{
+		"GridWidth": 10,
+		"GridHeight": 10,
+		"GridLayerHeight": 10,
+		"GridCols": 4,
+		"GridRows": 4,
+		"GridLayers": 4,
+		"ServerCols": 2,
+		"ServerRows": 2,
+		"ServerLayers": 2
+	}`))
+	assert.NoError(t, err)
+	ctl3D := ctl.(*StaticGrid3DSpatialController)
+	ctl3D.ServerInterestBorderSize = borderSize
+	return ctl3D
+}
+
+func grid3DTestChId(x, y, z int) common.ChannelId {
+	return GlobalSettings.SpatialChannelIdStart + common.ChannelId(x+z*4+y*16)
+}
+
+func TestGrid3DGetChannelId(t *testing.T) {
+	ctl := createTestGrid3DController(t, 0)
+
+	chId, err := ctl.GetChannelId(common.SpatialInfo{X: 15, Y: 25, Z: 35})
+	assert.NoError(t, err)
+	assert.Equal(t, grid3DTestChId(1, 2, 3), chId)
+
+	// The stacked grids are different channels
+	chId2, err := ctl.GetChannelId(common.SpatialInfo{X: 15, Y: 5, Z: 35})
+	assert.NoError(t, err)
+	assert.NotEqual(t, chId, chId2)
+
+	_, err = ctl.GetChannelId(common.SpatialInfo{X: 15, Y: 40, Z: 35})
+	assert.Error(t, err)
+	_, err = ctl.GetChannelId(common.SpatialInfo{X: 15, Y: -1, Z: 35})
+	assert.Error(t, err)
+
+	regions, err := ctl.GetRegions()
+	assert.NoError(t, err)
+	assert.Len(t, regions, 64)
+	region := regions[grid3DTestChId(1, 2, 3)-GlobalSettings.SpatialChannelIdStart]
+	assert.EqualValues(t, grid3DTestChId(1, 2, 3), region.ChannelId)
+	assert.EqualValues(t, 20, region.Min.Y)
+	assert.EqualValues(t, 30, region.Max.Y)
+	// serverX=0, serverZ=1, serverY=1
+	assert.EqualValues(t, 0+1*2+1*4, region.ServerIndex)
+
+	assert.Error(t, (&StaticGrid3DSpatialController{}).LoadConfig([]byte(`{"GridWidth": 10, "GridHeight": 10, "GridCols": 4, "GridRows": 4, "GridLayers": 4, "ServerCols": 1, "ServerRows": 1, "ServerLayers": 1}`)))
+	// 3 grids can't be evenly divided by 2 servers
+	assert.Error(t, (&StaticGrid3DSpatialController{}).LoadConfig([]byte(`{"GridWidth": 10, "GridHeight": 10, "GridLayerHeight": 10, "GridCols": 3, "GridRows": 4, "GridLayers": 4, "ServerCols": 2, "ServerRows": 1, "ServerLayers": 1}`)))
+}
+
+func TestGrid3DGetAdjacentChannels(t *testing.T) {
+	ctl := createTestGrid3DController(t, 0)
+
+	chIds, err := ctl.GetAdjacentChannels(grid3DTestChId(1, 1, 1))
+	assert.NoError(t, err)
+	assert.Len(t, chIds, 26)
+	assert.NotContains(t, chIds, grid3DTestChId(1, 1, 1))
+	assert.Contains(t, chIds, grid3DTestChId(0, 0, 0))
+	assert.Contains(t, chIds, grid3DTestChId(1, 2, 1))
+	assert.Contains(t, chIds, grid3DTestChId(2, 2, 2))
+
+	// The corner
+	chIds, err = ctl.GetAdjacentChannels(grid3DTestChId(0, 0, 0))
+	assert.NoError(t, err)
+	assert.Len(t, chIds, 7)
+
+	_, err = ctl.GetAdjacentChannels(grid3DTestChId(0, 4, 0))
+	assert.Error(t, err)
+}
+
+func TestGrid3DQueryChannelIds(t *testing.T) {
+	ctl := createTestGrid3DController(t, 0)
+
+	// The sphere doesn't reach the layers above or below
+	result, err := ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 15, Y: 15, Z: 15},
+			Radius: 4,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{grid3DTestChId(1, 1, 1): 0}, result)
+
+	// Reaches the 6 neighbours that share a face, but not the ones that share an edge (dist = 7.07)
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 15, Y: 15, Z: 15},
+			Radius: 6,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 7)
+	assert.EqualValues(t, 1, result[grid3DTestChId(1, 2, 1)])
+	assert.EqualValues(t, 1, result[grid3DTestChId(1, 0, 1)])
+	assert.NotContains(t, result, grid3DTestChId(2, 2, 1))
+
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		BoxAOI: &channeldpb.SpatialInterestQuery_BoxAOI{
+			Center: &channeldpb.SpatialInfo{X: 15, Y: 15, Z: 15},
+			Extent: &channeldpb.SpatialInfo{X: 10, Y: 0, Z: 0},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{
+		grid3DTestChId(0, 1, 1): 1,
+		grid3DTestChId(1, 1, 1): 0,
+		grid3DTestChId(2, 1, 1): 1,
+	}, result)
+
+	// Looks upwards
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		ConeAOI: &channeldpb.SpatialInterestQuery_ConeAOI{
+			Center:    &channeldpb.SpatialInfo{X: 15, Y: 15, Z: 15},
+			Direction: &channeldpb.SpatialInfo{X: 0, Y: 1, Z: 0},
+			Angle:     math.Pi / 12,
+			Radius:    20,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{
+		grid3DTestChId(1, 1, 1): 0,
+		grid3DTestChId(1, 2, 1): 1,
+		grid3DTestChId(1, 3, 1): 2,
+	}, result)
+
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SpotsAOI: &channeldpb.SpatialInterestQuery_SpotsAOI{
+			Spots: []*channeldpb.SpatialInfo{{X: 15, Y: 35, Z: 15}, {X: 15, Y: 45, Z: 15}},
+			Dists: []uint32{3, 0},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{grid3DTestChId(1, 3, 1): 3}, result)
+
+	_, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 15, Y: 45, Z: 15},
+			Radius: 10,
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestSegmentIntersectsBox(t *testing.T) {
+	min := &common.SpatialInfo{X: 0, Y: 0, Z: 0}
+	max := &common.SpatialInfo{X: 10, Y: 10, Z: 10}
+
+	assert.True(t, segmentIntersectsBox(&common.SpatialInfo{X: -5, Y: 5, Z: 5}, &common.SpatialInfo{X: 5, Y: 5, Z: 5}, min, max))
+	assert.True(t, segmentIntersectsBox(&common.SpatialInfo{X: -5, Y: -5, Z: -5}, &common.SpatialInfo{X: 15, Y: 15, Z: 15}, min, max))
+	// Too short
+	assert.False(t, segmentIntersectsBox(&common.SpatialInfo{X: -5, Y: 5, Z: 5}, &common.SpatialInfo{X: -1, Y: 5, Z: 5}, min, max))
+	// Passes by
+	assert.False(t, segmentIntersectsBox(&common.SpatialInfo{X: -5, Y: 11, Z: 5}, &common.SpatialInfo{X: 15, Y: 11, Z: 5}, min, max))
+	assert.False(t, segmentIntersectsBox(&common.SpatialInfo{X: -5, Y: 5, Z: 5}, &common.SpatialInfo{X: 5, Y: 25, Z: 5}, min, max))
+}
+
+func TestGrid3DCreateChannels(t *testing.T) {
+	InitChannels()
+
+	ctl := createTestGrid3DController(t, 1)
+	conns := make([]*testConnection, 8)
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_CREATE_CHANNEL,
+		Msg:     &channeldpb.CreateChannelMessage{},
+	}
+	for i := range conns {
+		conns[i] = createTestConnection()
+		ctx.Connection = conns[i]
+		channels, err := ctl.CreateChannels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, channels, 8)
+	}
+	_, err := ctl.CreateChannels(ctx)
+	assert.Error(t, err)
+
+	// Server 0 owns the 2x2x2 grids at the corner
+	assert.Equal(t, conns[0], GetChannel(grid3DTestChId(1, 1, 1)).GetOwner())
+	// Server 4 is above server 0
+	assert.Equal(t, conns[4], GetChannel(grid3DTestChId(0, 2, 0)).GetOwner())
+
+	// Server 0 subscribes to the border grids of the other servers: 3x3x3 - 2x2x2
+	assert.Len(t, conns[0].subscribedChannels, 27-8)
+	assert.Contains(t, conns[0].subscribedChannels, grid3DTestChId(2, 2, 2))
+	assert.NotContains(t, conns[0].subscribedChannels, grid3DTestChId(3, 2, 2))
+}
@@ -0,0 +1,168 @@
+package channeld
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Handover hysteresis
+ * An entity moving back and forth on the border of two grids would cause repeated handovers (and the ownership flips, if the grids
+ * belong to different servers). To prevent that, the StaticGrid2DSpatialController only commits a handover when:
+ * 1. the entity has moved further than HandoverBorderMargin from the grid it's currently in, and
+ * 2. the entity has stayed in the current grid for at least HandoverMinDwellMs since its last handover.
+ * A suppressed handover is re-evaluated the next time the entity moves.
+ * The hysteresis only applies to the entity channels, which notify the spatial info changes with the entity ID (see NotifyEntity).
+ */
+
+const (
+	handoverTypeCrossServer = "cross_server"
+	handoverTypeSameServer  = "same_server"
+
+	handoverSuppressedByBorder = "border"
+	handoverSuppressedByDwell  = "dwell"
+	handoverSuppressedByLock   = "lock"
+)
+
+type entityHandoverState struct {
+	// The spatial channel that the entity is committed to
+	channelId common.ChannelId
+	// When the entity was handed over to the channel
+	enterTime time.Time
+}
+
+// Tracks the spatial channel of the entities, as the channel calculated by the position is not committed until the handover happens.
+// Notify is called in the entity channels' goroutines, so the states are protected by the mutex.
+type handoverHysteresis struct {
+	lock   sync.Mutex
+	states map[EntityId]*entityHandoverState
+}
+
+// Returns the committed spatial channel of the entity, or false if the entity is not tracked yet.
+func (h *handoverHysteresis) getState(entityId EntityId) (entityHandoverState, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	state, exists := h.states[entityId]
+	if !exists {
+		return entityHandoverState{}, false
+	}
+	return *state, true
+}
+
+func (h *handoverHysteresis) commit(entityIds []EntityId, channelId common.ChannelId, now time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.states == nil {
+		h.states = make(map[EntityId]*entityHandoverState)
+	}
+	for _, entityId := range entityIds {
+		h.states[entityId] = &entityHandoverState{channelId: channelId, enterTime: now}
+	}
+}
+
+// Removes the states of the entities whose channel has been removed.
+func (h *handoverHysteresis) removeStaleStates() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for entityId := range h.states {
+		if ch := GetChannel(common.ChannelId(entityId)); ch == nil || ch.IsRemoving() {
+			delete(h.states, entityId)
+		}
+	}
+}
+
+func (ctl *StaticGrid2DSpatialController) hasHandoverHysteresis() bool {
+	return ctl.HandoverBorderMargin > 0 || ctl.HandoverMinDwellMs > 0
+}
+
+// Returns the distance on the XZ plane from the position to the rect of the grid. 0 = the position is inside the grid.
+func (ctl *StaticGrid2DSpatialController) distToGrid(info common.SpatialInfo, channelId common.ChannelId) float64 {
+	index := uint32(channelId - ctl.ChannelIdStart())
+	minX := ctl.WorldOffsetX + float64(index%ctl.GridCols)*ctl.GridWidth
+	minZ := ctl.WorldOffsetZ + float64(index/ctl.GridCols)*ctl.GridHeight
+	dx := math.Max(0, math.Max(minX-info.X, info.X-(minX+ctl.GridWidth)))
+	dz := math.Max(0, math.Max(minZ-info.Z, info.Z-(minZ+ctl.GridHeight)))
+	return math.Sqrt(dx*dx + dz*dz)
+}
+
+// Implemented by the SpatialControllers that need to know which entity the spatial info belongs to, e.g. for the handover hysteresis.
+type entitySpatialInfoChangedNotifier interface {
+	NotifyEntity(entityId EntityId, oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{}))
+}
+
+// The SpatialInfoChangedNotifier of an entity channel, which passes the entity ID to the SpatialController.
+type entitySpatialNotifier struct {
+	entityId EntityId
+	ctl      SpatialController
+}
+
+func newEntitySpatialNotifier(entityId EntityId, ctl SpatialController) common.SpatialInfoChangedNotifier {
+	if ctl == nil {
+		return nil
+	}
+	return &entitySpatialNotifier{entityId: entityId, ctl: ctl}
+}
+
+func (n *entitySpatialNotifier) Notify(oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) {
+	if entityNotifier, ok := n.ctl.(entitySpatialInfoChangedNotifier); ok {
+		entityNotifier.NotifyEntity(n.entityId, oldInfo, newInfo, handoverDataProvider)
+	} else {
+		n.ctl.Notify(oldInfo, newInfo, handoverDataProvider)
+	}
+}
+
+// Same as Notify, but the handover hysteresis applies to the entity. Runs in the entity channel's goroutine.
+func (ctl *StaticGrid2DSpatialController) NotifyEntity(entityId EntityId, oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) {
+	if !ctl.hasHandoverHysteresis() {
+		ctl.Notify(oldInfo, newInfo, handoverDataProvider)
+		return
+	}
+
+	dstChannelId, err := ctl.GetChannelId(newInfo)
+	if err != nil {
+		rootLogger.Error("failed to calculate dstChannelId", zap.Error(err), zap.String("newInfo", newInfo.String()))
+		return
+	}
+
+	now := time.Now()
+	state, tracked := ctl.handoverStates.getState(entityId)
+	if !tracked {
+		state.channelId, err = ctl.GetChannelId(oldInfo)
+		if err != nil {
+			rootLogger.Error("failed to calculate srcChannelId", zap.Error(err), zap.String("oldInfo", oldInfo.String()))
+			return
+		}
+	}
+	srcChannelId := state.channelId
+	// No migration between channels
+	if dstChannelId == srcChannelId {
+		return
+	}
+
+	if ctl.HandoverBorderMargin > 0 && ctl.distToGrid(newInfo, srcChannelId) <= ctl.HandoverBorderMargin {
+		handoverSuppressed.WithLabelValues(getHandoverType(srcChannelId, dstChannelId), handoverSuppressedByBorder).Inc()
+		return
+	}
+
+	if tracked && now.Sub(state.enterTime) < time.Duration(ctl.HandoverMinDwellMs)*time.Millisecond {
+		handoverSuppressed.WithLabelValues(getHandoverType(srcChannelId, dstChannelId), handoverSuppressedByDwell).Inc()
+		return
+	}
+
+	handoverEntityIds := handoverSpatialEntities(srcChannelId, dstChannelId, handoverDataProvider)
+	if len(handoverEntityIds) > 0 {
+		ctl.handoverStates.commit(handoverEntityIds, dstChannelId, now)
+	}
+}
+
+func getHandoverType(srcChannelId common.ChannelId, dstChannelId common.ChannelId) string {
+	srcChannel := GetChannel(srcChannelId)
+	dstChannel := GetChannel(dstChannelId)
+	if srcChannel != nil && dstChannel != nil && !srcChannel.IsSameOwner(dstChannel) {
+		return handoverTypeCrossServer
+	}
+	return handoverTypeSameServer
+}
@@ -0,0 +1,189 @@
+package channeld
+
+import (
+	"sync"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Deferred handover of the locked entities
+ * An entity in the LOCK group (e.g. in a transaction or a cutscene) is not handed over to another spatial channel. Instead of being dropped,
+ * the handover is deferred: the pending handover keeps the spatial channel that the entity is actually in, and the destination channel is
+ * updated as the entity keeps moving. The pending handover is retried when the lock is released by ENTITY_GROUP_REMOVE.
+ * If the entity stays locked longer than EntityHandoverMaxLockMs in the ENTITY channel settings, the entities are removed from the LOCK group
+ * and the handover is forced.
+ */
+
+type pendingHandover struct {
+	srcChannelId         common.ChannelId
+	dstChannelId         common.ChannelId
+	handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})
+	// When the handover was deferred for the first time
+	deferredTime time.Time
+	// The forced handover has been scheduled in the entity channel
+	forcing bool
+}
+
+// The pending handovers of the entities, keyed by the entity that notified the handover.
+// Accessed from the entity channels' goroutines and the GLOBAL channel's goroutine, so it's protected by the mutex.
+type pendingHandoverQueue struct {
+	lock      sync.Mutex
+	handovers map[EntityId]*pendingHandover
+}
+
+var pendingHandovers = &pendingHandoverQueue{handovers: make(map[EntityId]*pendingHandover)}
+
+func (q *pendingHandoverQueue) get(entityId EntityId) (pendingHandover, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	handover, exists := q.handovers[entityId]
+	if !exists {
+		return pendingHandover{}, false
+	}
+	return *handover, true
+}
+
+// Adds or updates the pending handover of the entity. The source channel of an existing pending handover is kept.
+func (q *pendingHandoverQueue) put(entityId EntityId, srcChannelId common.ChannelId, dstChannelId common.ChannelId, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{}), now time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if handover, exists := q.handovers[entityId]; exists {
+		handover.dstChannelId = dstChannelId
+		handover.handoverDataProvider = handoverDataProvider
+		return
+	}
+	q.handovers[entityId] = &pendingHandover{
+		srcChannelId:         srcChannelId,
+		dstChannelId:         dstChannelId,
+		handoverDataProvider: handoverDataProvider,
+		deferredTime:         now,
+	}
+}
+
+func (q *pendingHandoverQueue) remove(entityIds ...EntityId) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for _, entityId := range entityIds {
+		delete(q.handovers, entityId)
+	}
+}
+
+// Returns the entities whose pending handovers have been deferred longer than maxLockDuration, and marks them as being forced.
+func (q *pendingHandoverQueue) takeExpired(now time.Time, maxLockDuration time.Duration) []EntityId {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var entityIds []EntityId
+	for entityId, handover := range q.handovers {
+		if !handover.forcing && now.Sub(handover.deferredTime) >= maxLockDuration {
+			handover.forcing = true
+			entityIds = append(entityIds, entityId)
+		}
+	}
+	return entityIds
+}
+
+func (q *pendingHandoverQueue) entityIds() []EntityId {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	entityIds := make([]EntityId, 0, len(q.handovers))
+	for entityId := range q.handovers {
+		entityIds = append(entityIds, entityId)
+	}
+	return entityIds
+}
+
+// Implemented by the spatial controllers that track the committed handovers, e.g. for the hysteresis.
+type deferredHandoverCommitter interface {
+	commitDeferredHandover(entityIds []EntityId, dstChannelId common.ChannelId)
+}
+
+func (ctl *StaticGrid2DSpatialController) commitDeferredHandover(entityIds []EntityId, dstChannelId common.ChannelId) {
+	if ctl.hasHandoverHysteresis() {
+		ctl.handoverStates.commit(entityIds, dstChannelId, time.Now())
+	}
+}
+
+// Schedules the pending handovers to be retried in their entity channels. Called after the entities are removed from the LOCK group.
+func retryPendingHandovers() {
+	for _, entityId := range pendingHandovers.entityIds() {
+		entityCh := GetChannel(common.ChannelId(entityId))
+		if entityCh == nil || entityCh.IsRemoving() {
+			pendingHandovers.remove(entityId)
+			continue
+		}
+		entityCh.Execute(retryPendingHandover)
+	}
+}
+
+// Runs in the entity channel's goroutine. If the entity is still locked, the handover is deferred again.
+func retryPendingHandover(entityCh *Channel) {
+	handover, exists := pendingHandovers.get(EntityId(entityCh.id))
+	if !exists {
+		return
+	}
+	// The notifications of the entity may have been suppressed (e.g. by the hysteresis) while it's locked, so the destination
+	// is re-calculated from the latest spatial info if possible.
+	dstChannelId := handover.dstChannelId
+	spatialCtl := GetSpatialControllerByChannelId(handover.srcChannelId)
+	if info := entityCh.GetSpatialInfo(); info != nil && spatialCtl != nil {
+		if chId, err := spatialCtl.GetChannelId(*info); err == nil {
+			dstChannelId = chId
+		}
+	}
+	if dstChannelId == handover.srcChannelId {
+		pendingHandovers.remove(EntityId(entityCh.id))
+		return
+	}
+
+	handoverEntityIds := handoverSpatialEntities(handover.srcChannelId, dstChannelId, handover.handoverDataProvider)
+	if len(handoverEntityIds) == 0 {
+		return
+	}
+	if committer, ok := spatialCtl.(deferredHandoverCommitter); ok {
+		committer.commitDeferredHandover(handoverEntityIds, dstChannelId)
+	}
+}
+
+// Runs in the entity channel's goroutine. Removes the locked entities from the LOCK group, then hands over.
+func forcePendingHandover(entityCh *Channel) {
+	handover, exists := pendingHandovers.get(EntityId(entityCh.id))
+	if !exists {
+		return
+	}
+	if entityCh.entityController != nil {
+		lockedEntities := entityCh.entityController.GetLockedEntities()
+		if len(lockedEntities) > 0 {
+			entityCh.Logger().Warn("entities are locked for too long, forcing the handover",
+				zap.Uint32s("lockedEntities", CopyArray[EntityId, uint32](lockedEntities)),
+				zap.Uint32("srcChannelId", uint32(handover.srcChannelId)),
+				zap.Uint32("dstChannelId", uint32(handover.dstChannelId)),
+				zap.Duration("lockDuration", time.Since(handover.deferredTime)),
+			)
+			if err := entityCh.entityController.RemoveFromGroup(channeldpb.EntityGroupType_LOCK, lockedEntities); err != nil {
+				entityCh.Logger().Error("failed to remove the locked entities from the group", zap.Error(err))
+			}
+		}
+	}
+	retryPendingHandover(entityCh)
+	// Don't keep the pending handover if the forced handover failed, otherwise it would be forced again and again.
+	pendingHandovers.remove(EntityId(entityCh.id))
+}
+
+// Forces the pending handovers that have exceeded EntityHandoverMaxLockMs. Called in the GLOBAL channel.
+func tickPendingHandovers(now time.Time) {
+	maxLockMs := GlobalSettings.GetChannelSettings(channeldpb.ChannelType_ENTITY).EntityHandoverMaxLockMs
+	if maxLockMs == 0 {
+		return
+	}
+	for _, entityId := range pendingHandovers.takeExpired(now, time.Duration(maxLockMs)*time.Millisecond) {
+		entityCh := GetChannel(common.ChannelId(entityId))
+		if entityCh == nil || entityCh.IsRemoving() {
+			pendingHandovers.remove(entityId)
+			continue
+		}
+		entityCh.Execute(forcePendingHandover)
+	}
+}
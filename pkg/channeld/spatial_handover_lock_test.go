@@ -0,0 +1,135 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatEntityGroupLockedEntities(t *testing.T) {
+	InitChannels()
+
+	entityA := EntityId(201)
+	entityB := EntityId(202)
+	chA := createChannelWithId(common.ChannelId(entityA), channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		chA.removing = 1
+		allChannels.Delete(chA.id)
+	}()
+	assert.Empty(t, chA.entityController.GetLockedEntities())
+
+	// Locked without any handover group
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityA}))
+	assert.Equal(t, []EntityId{entityA}, chA.entityController.GetLockedEntities())
+	assert.Empty(t, chA.entityController.GetHandoverEntities())
+
+	assert.NoError(t, chA.entityController.RemoveFromGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityA}))
+	assert.Empty(t, chA.entityController.GetLockedEntities())
+	assert.Equal(t, []EntityId{entityA}, chA.entityController.GetHandoverEntities())
+
+	// Any locked entity in the handover group locks the handover
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_HANDOVER, []EntityId{entityA, entityB}))
+	assert.NoError(t, chA.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityB}))
+	assert.Equal(t, []EntityId{entityB}, chA.entityController.GetLockedEntities())
+}
+
+func TestDeferredHandoverOfLockedEntity(t *testing.T) {
+	isolateChannelTest(t)
+
+	// The flat entity group controller, without the limit of the lock duration
+	settings := ChannelSettingsType{}
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = settings
+
+	// 3 grids in a row: 0 | 1 | 2
+	ctl := &StaticGrid2DSpatialController{
+		GridWidth:  100,
+		GridHeight: 100,
+		GridCols:   3,
+		GridRows:   1,
+		ServerCols: 1,
+		ServerRows: 1,
+	}
+	ch0 := GlobalSettings.SpatialChannelIdStart
+	ch1 := ch0 + 1
+	ch2 := ch0 + 2
+	for _, chId := range []common.ChannelId{ch0, ch1, ch2} {
+		spatialCh := createChannelWithId(chId, channeldpb.ChannelType_SPATIAL, nil)
+		defer func() {
+			spatialCh.removing = 1
+			allChannels.Delete(spatialCh.id)
+		}()
+	}
+
+	entityId := EntityId(301)
+	entityCh := createChannelWithId(common.ChannelId(entityId), channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		entityCh.removing = 1
+		allChannels.Delete(entityCh.id)
+	}()
+	provider := func(_ common.ChannelId, _ common.ChannelId, data interface{}) {
+		*data.(*EntityId) = entityId
+	}
+	suppressedByLock := handoverSuppressed.WithLabelValues(getHandoverType(ch0, ch1), handoverSuppressedByLock)
+	lockCount := testutil.ToFloat64(suppressedByLock)
+
+	// The handover of the locked entity is deferred
+	assert.NoError(t, entityCh.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityId}))
+	ctl.Notify(common.SpatialInfo{X: 90, Z: 50}, common.SpatialInfo{X: 110, Z: 50}, provider)
+	assert.EqualValues(t, lockCount+1, testutil.ToFloat64(suppressedByLock))
+	handover, pending := pendingHandovers.get(entityId)
+	assert.True(t, pending)
+	assert.Equal(t, ch0, handover.srcChannelId)
+	assert.Equal(t, ch1, handover.dstChannelId)
+
+	// The entity keeps moving. The source channel is kept.
+	ctl.Notify(common.SpatialInfo{X: 190, Z: 50}, common.SpatialInfo{X: 210, Z: 50}, provider)
+	handover, pending = pendingHandovers.get(entityId)
+	assert.True(t, pending)
+	assert.Equal(t, ch0, handover.srcChannelId)
+	assert.Equal(t, ch2, handover.dstChannelId)
+	assert.EqualValues(t, lockCount+2, testutil.ToFloat64(suppressedByLock))
+
+	// The entity moves back to the channel it's in
+	ctl.Notify(common.SpatialInfo{X: 110, Z: 50}, common.SpatialInfo{X: 90, Z: 50}, provider)
+	_, pending = pendingHandovers.get(entityId)
+	assert.False(t, pending)
+	assert.EqualValues(t, lockCount+2, testutil.ToFloat64(suppressedByLock))
+
+	// Retrying while the entity is still locked keeps the pending handover
+	ctl.Notify(common.SpatialInfo{X: 90, Z: 50}, common.SpatialInfo{X: 110, Z: 50}, provider)
+	retryPendingHandover(entityCh)
+	_, pending = pendingHandovers.get(entityId)
+	assert.True(t, pending)
+
+	// The handover is retried once the lock is released
+	assert.NoError(t, entityCh.entityController.RemoveFromGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityId}))
+	retryPendingHandovers()
+	assert.Eventually(t, func() bool {
+		_, pending := pendingHandovers.get(entityId)
+		return !pending
+	}, time.Second, 10*time.Millisecond)
+
+	// No limit of the lock duration by default
+	assert.NoError(t, entityCh.entityController.AddToGroup(channeldpb.EntityGroupType_LOCK, []EntityId{entityId}))
+	ctl.Notify(common.SpatialInfo{X: 90, Z: 50}, common.SpatialInfo{X: 110, Z: 50}, provider)
+	tickPendingHandovers(time.Now().Add(time.Hour))
+	_, pending = pendingHandovers.get(entityId)
+	assert.True(t, pending)
+
+	// The handover is forced after the max lock duration
+	settings.EntityHandoverMaxLockMs = 100
+	GlobalSettings.ChannelSettings[channeldpb.ChannelType_ENTITY] = settings
+	tickPendingHandovers(time.Now())
+	_, pending = pendingHandovers.get(entityId)
+	assert.True(t, pending)
+	tickPendingHandovers(time.Now().Add(200 * time.Millisecond))
+	assert.Eventually(t, func() bool {
+		_, pending := pendingHandovers.get(entityId)
+		return !pending
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, entityCh.entityController.GetLockedEntities())
+}
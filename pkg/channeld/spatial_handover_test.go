@@ -0,0 +1,96 @@
+package channeld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	The grids of the test world (x: 0-200, z: 0-100):
+
+0  |  1
+*/
+func createTestHandoverController(t *testing.T) *StaticGrid2DSpatialController {
+	ctl, err := CreateSpatialController("StaticGrid2DSpatialController", []byte(`{
+		"GridWidth": 100,
+		"GridHeight": 100,
+		"GridCols": 2,
+		"GridRows": 1,
+		"ServerCols": 2,
+		"ServerRows": 1,
+		"HandoverBorderMargin": 10,
+		"HandoverMinDwellMs": 100
+	}`))
+	assert.NoError(t, err)
+	return ctl.(*StaticGrid2DSpatialController)
+}
+
+func TestHandoverDistToGrid(t *testing.T) {
+	ctl := createTestHandoverController(t)
+	ch0 := GlobalSettings.SpatialChannelIdStart
+	ch1 := GlobalSettings.SpatialChannelIdStart + 1
+
+	assert.EqualValues(t, 0, ctl.distToGrid(common.SpatialInfo{X: 50, Z: 50}, ch0))
+	assert.EqualValues(t, 5, ctl.distToGrid(common.SpatialInfo{X: 105, Z: 50}, ch0))
+	assert.EqualValues(t, 5, ctl.distToGrid(common.SpatialInfo{X: 95, Z: 50}, ch1))
+	assert.EqualValues(t, 5, ctl.distToGrid(common.SpatialInfo{X: 150, Z: 105}, ch1))
+	assert.EqualValues(t, 5, ctl.distToGrid(common.SpatialInfo{X: 203, Z: -4}, ch1))
+}
+
+func TestHandoverHysteresis(t *testing.T) {
+	ctl := createTestHandoverController(t)
+	assert.True(t, ctl.hasHandoverHysteresis())
+	ch0 := GlobalSettings.SpatialChannelIdStart
+
+	var notifyingEntityId EntityId
+	provider := func(srcChannelId common.ChannelId, _ common.ChannelId, data interface{}) {
+		assert.NotZero(t, srcChannelId)
+		*data.(*EntityId) = notifyingEntityId
+	}
+	suppressedByBorder := handoverSuppressed.WithLabelValues(handoverTypeSameServer, handoverSuppressedByBorder)
+	suppressedByDwell := handoverSuppressed.WithLabelValues(handoverTypeSameServer, handoverSuppressedByDwell)
+	borderCount := testutil.ToFloat64(suppressedByBorder)
+	dwellCount := testutil.ToFloat64(suppressedByDwell)
+
+	// The entity that was just handed over to grid 0
+	notifyingEntityId = 1001
+	ctl.handoverStates.commit([]EntityId{notifyingEntityId}, ch0, time.Now())
+
+	// Within the border margin. The entity channel's notifier passes the entity ID.
+	newEntitySpatialNotifier(notifyingEntityId, ctl).Notify(common.SpatialInfo{X: 95, Z: 50}, common.SpatialInfo{X: 105, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+1, testutil.ToFloat64(suppressedByBorder))
+	assert.EqualValues(t, dwellCount, testutil.ToFloat64(suppressedByDwell))
+
+	// Out of the border margin, but hasn't stayed long enough in grid 0
+	ctl.NotifyEntity(notifyingEntityId, common.SpatialInfo{X: 105, Z: 50}, common.SpatialInfo{X: 120, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+1, testutil.ToFloat64(suppressedByBorder))
+	assert.EqualValues(t, dwellCount+1, testutil.ToFloat64(suppressedByDwell))
+
+	// The entity is still considered in grid 0, so moving back doesn't cause any handover
+	ctl.NotifyEntity(notifyingEntityId, common.SpatialInfo{X: 120, Z: 50}, common.SpatialInfo{X: 90, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+1, testutil.ToFloat64(suppressedByBorder))
+	assert.EqualValues(t, dwellCount+1, testutil.ToFloat64(suppressedByDwell))
+
+	// The dwell time has passed
+	ctl.handoverStates.commit([]EntityId{notifyingEntityId}, ch0, time.Now().Add(-200*time.Millisecond))
+	ctl.NotifyEntity(notifyingEntityId, common.SpatialInfo{X: 105, Z: 50}, common.SpatialInfo{X: 120, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+1, testutil.ToFloat64(suppressedByBorder))
+	assert.EqualValues(t, dwellCount+1, testutil.ToFloat64(suppressedByDwell))
+
+	// The untracked entity uses the old position as the committed grid, and has no dwell time
+	notifyingEntityId = 1002
+	ctl.NotifyEntity(notifyingEntityId, common.SpatialInfo{X: 95, Z: 50}, common.SpatialInfo{X: 105, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+2, testutil.ToFloat64(suppressedByBorder))
+	ctl.NotifyEntity(notifyingEntityId, common.SpatialInfo{X: 95, Z: 50}, common.SpatialInfo{X: 120, Z: 50}, provider)
+	assert.EqualValues(t, borderCount+2, testutil.ToFloat64(suppressedByBorder))
+	assert.EqualValues(t, dwellCount+1, testutil.ToFloat64(suppressedByDwell))
+
+	// The states of the entities without a channel are removed
+	ctl.Tick()
+	_, tracked := ctl.handoverStates.getState(1001)
+	assert.False(t, tracked)
+}
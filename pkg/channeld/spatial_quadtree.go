@@ -0,0 +1,946 @@
+package channeld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+// Divides the world into cells with quadtrees. Each leaf cell is a spatial channel.
+// The world is first divided into ServerCols x ServerRows root cells, and each root cell is the authority area of a spatial server.
+// The root cells are split until the cells are no larger than MaxCellSize. At runtime, a leaf cell is split into four when it has
+// more than SplitEntityCount entities, and four sibling leaf cells are merged when they have less than MergeEntityCount entities in total.
+// The entities in the split or merged cells are re-homed to the new cells through the handover.
+type QuadtreeSpatialController struct {
+	SpatialController
+	SpatialChannelIdRange
+
+	// The world's bottom-left corner, in the simulation/engine units.
+	WorldOffsetX float64
+	WorldOffsetZ float64
+	WorldWidth   float64
+	WorldHeight  float64
+
+	// How many servers (root cells) the world has in X axis.
+	ServerCols uint32
+	// How many servers (root cells) the world has in Z axis.
+	ServerRows uint32
+
+	// The min width/height of a cell. A cell won't be split if its children would be smaller than this.
+	MinCellSize float64
+	// The max width/height of a cell. The root cells are split until the cells are no larger than this.
+	MaxCellSize float64
+
+	// A leaf cell is split when it has more entities than this. 0 = never split.
+	// The entity count is only available if the spatial channel data implements SpatialChannelEntityCounter.
+	SplitEntityCount int
+	// Four sibling leaf cells are merged when they have less entities than this in total. 0 = never merge.
+	MergeEntityCount int
+	// How often the cells are checked for splitting and merging. 0 = the cells never change after created.
+	RestructureIntervalMs uint32
+
+	// The distance unit of the result of QueryChannelIds(). Default is MaxCellSize.
+	DistanceUnit float64
+	// Should a spatial server subscribe to the other servers' cells that are adjacent to its authority area?
+	ServerInterestInAdjacentCells bool
+
+	// Write lock is only acquired in the GLOBAL channel. Read lock should be acquired when reading the tree outside the GLOBAL channel.
+	treeLock       sync.RWMutex
+	roots          []*quadCell
+	leaves         map[common.ChannelId]*quadCell
+	nextCellIndex  uint32
+	freeChannelIds []common.ChannelId
+
+	serverConnections []ConnectionInChannel
+	// The first CreateChannelMessage from the spatial servers. Its data and options are used for creating the channels of the new cells.
+	createChannelMsg *channeldpb.CreateChannelMessage
+
+	lastRestructureTime time.Time
+	// The channels of the split or merged cells. They are removed after the entities in the cells are re-homed.
+	retiredChannels []*retiredCellChannels
+}
+
+// How long to wait for the entities in the retired cells to be re-homed.
+// An entity channel removed before the re-home runs never reports back, so the retired channels are removed anyway after the timeout.
+const rehomeEntitiesTimeout = 5 * time.Second
+
+// The channels retired by a split or merge.
+type retiredCellChannels struct {
+	channels []*Channel
+	// The number of the entity channels that haven't finished re-homing. Accessed atomically.
+	pendingRehomes int32
+	retiredTime    time.Time
+}
+
+type quadCell struct {
+	minX   float64
+	minZ   float64
+	width  float64
+	height float64
+	// The index of the server that owns the cell. All the cells in a root cell belong to the same server.
+	serverIndex uint32
+	// Nil for the leaf cell; otherwise ordered as [minX-minZ, maxX-minZ, minX-maxZ, maxX-maxZ]
+	children []*quadCell
+	// Only valid for the leaf cell
+	channelId   common.ChannelId
+	createdTime time.Time
+}
+
+func (cell *quadCell) maxX() float64 {
+	return cell.minX + cell.width
+}
+
+func (cell *quadCell) maxZ() float64 {
+	return cell.minZ + cell.height
+}
+
+func (cell *quadCell) isLeaf() bool {
+	return cell.children == nil
+}
+
+func (cell *quadCell) contains(x, z float64) bool {
+	return x >= cell.minX && x < cell.maxX() && z >= cell.minZ && z < cell.maxZ()
+}
+
+// Returns the distance between the point and the nearest point of the cell. 0 if the point is inside the cell.
+func (cell *quadCell) distTo(x, z float64) float64 {
+	dx := math.Max(0, math.Max(cell.minX-x, x-cell.maxX()))
+	dz := math.Max(0, math.Max(cell.minZ-z, z-cell.maxZ()))
+	return math.Sqrt(dx*dx + dz*dz)
+}
+
+// Returns true if the cell overlaps or shares an edge or a corner with the rectangle.
+func (cell *quadCell) touches(minX, minZ, maxX, maxZ float64) bool {
+	const epsilon = 1e-9
+	return cell.minX <= maxX+epsilon && minX <= cell.maxX()+epsilon &&
+		cell.minZ <= maxZ+epsilon && minZ <= cell.maxZ()+epsilon
+}
+
+// Returns true if the cell overlaps with the rectangle.
+func (cell *quadCell) overlaps(minX, minZ, maxX, maxZ float64) bool {
+	return cell.minX <= maxX && minX < cell.maxX() && cell.minZ <= maxZ && minZ < cell.maxZ()
+}
+
+func (cell *quadCell) split() {
+	halfWidth := cell.width * 0.5
+	halfHeight := cell.height * 0.5
+	cell.children = make([]*quadCell, 4)
+	for i := range cell.children {
+		cell.children[i] = &quadCell{
+			minX:        cell.minX + halfWidth*float64(i%2),
+			minZ:        cell.minZ + halfHeight*float64(i/2),
+			width:       halfWidth,
+			height:      halfHeight,
+			serverIndex: cell.serverIndex,
+		}
+	}
+}
+
+func (cell *quadCell) childAt(x, z float64) *quadCell {
+	i := 0
+	if x >= cell.minX+cell.width*0.5 {
+		i++
+	}
+	if z >= cell.minZ+cell.height*0.5 {
+		i += 2
+	}
+	return cell.children[i]
+}
+
+// Calls the callback for each leaf cell under the cell that matches the filter. The filter is also used to skip the non-leaf cells.
+func (cell *quadCell) forEachLeaf(filter func(*quadCell) bool, callback func(*quadCell)) {
+	if !filter(cell) {
+		return
+	}
+	if cell.isLeaf() {
+		callback(cell)
+		return
+	}
+	for _, child := range cell.children {
+		child.forEachLeaf(filter, callback)
+	}
+}
+
+func (ctl *QuadtreeSpatialController) rootWidth() float64 {
+	return ctl.WorldWidth / float64(ctl.ServerCols)
+}
+
+func (ctl *QuadtreeSpatialController) rootHeight() float64 {
+	return ctl.WorldHeight / float64(ctl.ServerRows)
+}
+
+func (ctl *QuadtreeSpatialController) LoadConfig(config []byte) error {
+	err := json.Unmarshal(config, ctl)
+	if err != nil {
+		return err
+	}
+	if ctl.WorldWidth <= 0 || ctl.WorldHeight <= 0 {
+		return errors.New("WorldWidth and WorldHeight should be positive")
+	}
+	if ctl.ServerCols <= 0 || ctl.ServerRows <= 0 {
+		return errors.New("ServerCols and ServerRows should be positive")
+	}
+	if ctl.MinCellSize <= 0 || ctl.MaxCellSize < ctl.MinCellSize {
+		return errors.New("MinCellSize should be positive and no larger than MaxCellSize")
+	}
+	if ctl.SplitEntityCount > 0 && ctl.MergeEntityCount >= ctl.SplitEntityCount {
+		return errors.New("MergeEntityCount should be less than SplitEntityCount")
+	}
+	if ctl.DistanceUnit <= 0 {
+		ctl.DistanceUnit = ctl.MaxCellSize
+	}
+
+	return ctl.initCells()
+}
+
+// Creates the root cells and splits them until the cells are no larger than MaxCellSize.
+func (ctl *QuadtreeSpatialController) initCells() error {
+	ctl.roots = make([]*quadCell, ctl.ServerCols*ctl.ServerRows)
+	ctl.leaves = make(map[common.ChannelId]*quadCell)
+	ctl.nextCellIndex = 0
+	ctl.freeChannelIds = nil
+
+	for y := uint32(0); y < ctl.ServerRows; y++ {
+		for x := uint32(0); x < ctl.ServerCols; x++ {
+			serverIndex := x + y*ctl.ServerCols
+			root := &quadCell{
+				minX:        ctl.WorldOffsetX + ctl.rootWidth()*float64(x),
+				minZ:        ctl.WorldOffsetZ + ctl.rootHeight()*float64(y),
+				width:       ctl.rootWidth(),
+				height:      ctl.rootHeight(),
+				serverIndex: serverIndex,
+			}
+			ctl.roots[serverIndex] = root
+			if err := ctl.initCell(root); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ctl *QuadtreeSpatialController) initCell(cell *quadCell) error {
+	if (cell.width > ctl.MaxCellSize || cell.height > ctl.MaxCellSize) && ctl.canSplit(cell) {
+		cell.split()
+		for _, child := range cell.children {
+			if err := ctl.initCell(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	chId, err := ctl.allocChannelId()
+	if err != nil {
+		return err
+	}
+	cell.channelId = chId
+	cell.createdTime = time.Now()
+	ctl.leaves[chId] = cell
+	return nil
+}
+
+func (ctl *QuadtreeSpatialController) canSplit(cell *quadCell) bool {
+	return cell.width*0.5 >= ctl.MinCellSize && cell.height*0.5 >= ctl.MinCellSize
+}
+
+func (ctl *QuadtreeSpatialController) allocChannelId() (common.ChannelId, error) {
+	if len(ctl.freeChannelIds) > 0 {
+		chId := ctl.freeChannelIds[len(ctl.freeChannelIds)-1]
+		ctl.freeChannelIds = ctl.freeChannelIds[:len(ctl.freeChannelIds)-1]
+		return chId, nil
+	}
+
+	chId := ctl.ChannelIdStart() + common.ChannelId(ctl.nextCellIndex)
+	if chId > ctl.ChannelIdEnd() {
+		return 0, fmt.Errorf("spatial channel id %d exceeds the end of the spatial channel id range %d", chId, ctl.ChannelIdEnd())
+	}
+	ctl.nextCellIndex++
+	return chId, nil
+}
+
+// Returns the leaf cell that contains the point. Should be called with the read lock.
+func (ctl *QuadtreeSpatialController) findLeaf(x, z float64) (*quadCell, error) {
+	rootX := int(math.Floor((x - ctl.WorldOffsetX) / ctl.rootWidth()))
+	if rootX < 0 || rootX >= int(ctl.ServerCols) {
+		return nil, fmt.Errorf("X=%f is out of the world", x)
+	}
+	rootY := int(math.Floor((z - ctl.WorldOffsetZ) / ctl.rootHeight()))
+	if rootY < 0 || rootY >= int(ctl.ServerRows) {
+		return nil, fmt.Errorf("Z=%f is out of the world", z)
+	}
+
+	cell := ctl.roots[uint32(rootX)+uint32(rootY)*ctl.ServerCols]
+	for !cell.isLeaf() {
+		cell = cell.childAt(x, z)
+	}
+	return cell, nil
+}
+
+func (ctl *QuadtreeSpatialController) GetChannelId(info common.SpatialInfo) (common.ChannelId, error) {
+	ctl.treeLock.RLock()
+	defer ctl.treeLock.RUnlock()
+
+	cell, err := ctl.findLeaf(info.X, info.Z)
+	if err != nil {
+		return 0, err
+	}
+	return cell.channelId, nil
+}
+
+// Calls the callback for each leaf cell in the world that matches the filter. Should be called with the read lock.
+func (ctl *QuadtreeSpatialController) forEachLeaf(filter func(*quadCell) bool, callback func(*quadCell)) {
+	for _, root := range ctl.roots {
+		root.forEachLeaf(filter, callback)
+	}
+}
+
+func (ctl *QuadtreeSpatialController) distance(cell *quadCell, x, z float64) uint {
+	return uint(math.Ceil(cell.distTo(x, z) / ctl.DistanceUnit))
+}
+
+func setNearestDist(result map[common.ChannelId]uint, chId common.ChannelId, dist uint) {
+	if oldDist, exists := result[chId]; !exists || dist < oldDist {
+		result[chId] = dist
+	}
+}
+
+func (ctl *QuadtreeSpatialController) QueryChannelIds(query *channeldpb.SpatialInterestQuery) (map[common.ChannelId]uint, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query is nil")
+	}
+
+	ctl.treeLock.RLock()
+	defer ctl.treeLock.RUnlock()
+
+	result := make(map[common.ChannelId]uint)
+
+	if query.SpotsAOI != nil {
+		for i, spot := range query.SpotsAOI.Spots {
+			cell, err := ctl.findLeaf(spot.X, spot.Z)
+			if err != nil {
+				continue
+			}
+			if i < len(query.SpotsAOI.Dists) {
+				result[cell.channelId] = uint(query.SpotsAOI.Dists[i])
+			} else {
+				// If distance is not specified, the spot will be considered as always at the nearest distance.
+				result[cell.channelId] = 0
+			}
+		}
+	}
+
+	if query.BoxAOI != nil {
+		center, extent := query.BoxAOI.Center, query.BoxAOI.Extent
+		if extent.X <= 0 || extent.Z <= 0 {
+			return nil, fmt.Errorf("invalid box extentX=%f, extentZ=%f", extent.X, extent.Z)
+		}
+		centerCell, err := ctl.findLeaf(center.X, center.Z)
+		if err != nil {
+			return nil, err
+		}
+
+		ctl.forEachLeaf(func(cell *quadCell) bool {
+			return cell.overlaps(center.X-extent.X, center.Z-extent.Z, center.X+extent.X, center.Z+extent.Z)
+		}, func(cell *quadCell) {
+			setNearestDist(result, cell.channelId, ctl.distance(cell, center.X, center.Z))
+		})
+		result[centerCell.channelId] = 0
+	}
+
+	if query.SphereAOI != nil {
+		center, r := query.SphereAOI.Center, query.SphereAOI.Radius
+		if r <= 0 {
+			return nil, fmt.Errorf("invalid radius=%f", r)
+		}
+		centerCell, err := ctl.findLeaf(center.X, center.Z)
+		if err != nil {
+			return nil, err
+		}
+
+		ctl.forEachLeaf(func(cell *quadCell) bool {
+			return cell.distTo(center.X, center.Z) <= r
+		}, func(cell *quadCell) {
+			setNearestDist(result, cell.channelId, ctl.distance(cell, center.X, center.Z))
+		})
+		result[centerCell.channelId] = 0
+	}
+
+	if query.ConeAOI != nil {
+		center, r := query.ConeAOI.Center, query.ConeAOI.Radius
+		if r <= 0 {
+			return nil, fmt.Errorf("invalid radius=%f", r)
+		}
+		centerCell, err := ctl.findLeaf(center.X, center.Z)
+		if err != nil {
+			return nil, err
+		}
+
+		coneDir := &common.SpatialInfo{X: query.ConeAOI.Direction.X, Y: 0, Z: query.ConeAOI.Direction.Z}
+		coneDir.Normalize2D()
+		ctl.forEachLeaf(func(cell *quadCell) bool {
+			// The non-leaf cells are only filtered by the radius
+			if !cell.isLeaf() {
+				return cell.distTo(center.X, center.Z) <= r
+			}
+			return coneIntersectsCell(cell, center.X, center.Z, coneDir, r, query.ConeAOI.Angle)
+		}, func(cell *quadCell) {
+			setNearestDist(result, cell.channelId, ctl.distance(cell, center.X, center.Z))
+		})
+		result[centerCell.channelId] = 0
+	}
+
+	return result, nil
+}
+
+// Returns true if the cone (a circular sector on the XZ plane) intersects with the cell. The angle is between the direction and the edge of the cone.
+func coneIntersectsCell(cell *quadCell, x, z float64, dir *common.SpatialInfo, r float64, angle float64) bool {
+	if cell.contains(x, z) {
+		return true
+	}
+	if cell.distTo(x, z) > r {
+		return false
+	}
+
+	cos := math.Cos(angle)
+	inCone := func(px, pz float64) bool {
+		v := common.SpatialInfo{X: px - x, Z: pz - z}
+		if v.X*v.X+v.Z*v.Z > r*r {
+			return false
+		}
+		v.Normalize2D()
+		return v.Dot2D(dir) >= cos
+	}
+
+	// The corners, the center, and the nearest point of the cell
+	nearestX := math.Max(cell.minX, math.Min(x, cell.maxX()))
+	nearestZ := math.Max(cell.minZ, math.Min(z, cell.maxZ()))
+	points := [][2]float64{
+		{cell.minX, cell.minZ}, {cell.maxX(), cell.minZ}, {cell.minX, cell.maxZ()}, {cell.maxX(), cell.maxZ()},
+		{cell.minX + cell.width*0.5, cell.minZ + cell.height*0.5},
+		{nearestX, nearestZ},
+	}
+	for _, p := range points {
+		if inCone(p[0], p[1]) {
+			return true
+		}
+	}
+
+	// The edges and the axis of the cone
+	for _, a := range []float64{-angle, 0, angle} {
+		sin, cos := math.Sin(a), math.Cos(a)
+		endX := x + (dir.X*cos-dir.Z*sin)*r
+		endZ := z + (dir.X*sin+dir.Z*cos)*r
+		if segmentIntersectsCell(cell, x, z, endX, endZ) {
+			return true
+		}
+	}
+	return false
+}
+
+// Liang-Barsky line clipping
+func segmentIntersectsCell(cell *quadCell, x0, z0, x1, z1 float64) bool {
+	dx, dz := x1-x0, z1-z0
+	t0, t1 := 0.0, 1.0
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
+		}
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return false
+			}
+			if t > t0 {
+				t0 = t
+			}
+		} else {
+			if t < t0 {
+				return false
+			}
+			if t < t1 {
+				t1 = t
+			}
+		}
+		return true
+	}
+	return clip(-dx, x0-cell.minX) && clip(dx, cell.maxX()-x0) &&
+		clip(-dz, z0-cell.minZ) && clip(dz, cell.maxZ()-z0)
+}
+
+// Returns the regions of all the leaf cells, ordered by the channel id.
+func (ctl *QuadtreeSpatialController) GetRegions() ([]*channeldpb.SpatialRegion, error) {
+	ctl.treeLock.RLock()
+	defer ctl.treeLock.RUnlock()
+
+	regions := make([]*channeldpb.SpatialRegion, 0, len(ctl.leaves))
+	for _, cell := range ctl.leaves {
+		regions = append(regions, &channeldpb.SpatialRegion{
+			Min: &channeldpb.SpatialInfo{
+				X: cell.minX,
+				Y: MinY,
+				Z: cell.minZ,
+			},
+			Max: &channeldpb.SpatialInfo{
+				X: cell.maxX(),
+				Y: MaxY,
+				Z: cell.maxZ(),
+			},
+			ChannelId:   uint32(cell.channelId),
+			ServerIndex: cell.serverIndex,
+		})
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i].ChannelId < regions[j].ChannelId
+	})
+	return regions, nil
+}
+
+// Returns the leaf cells that share an edge or a corner with the spatial channel's cell. The neighbours can be in different sizes.
+func (ctl *QuadtreeSpatialController) GetAdjacentChannels(spatialChannelId common.ChannelId) ([]common.ChannelId, error) {
+	ctl.treeLock.RLock()
+	defer ctl.treeLock.RUnlock()
+
+	cell, exists := ctl.leaves[spatialChannelId]
+	if !exists {
+		return nil, fmt.Errorf("spatial channel %d is not a cell", spatialChannelId)
+	}
+
+	channelIds := make([]common.ChannelId, 0)
+	ctl.forEachLeaf(func(c *quadCell) bool {
+		return c.touches(cell.minX, cell.minZ, cell.maxX(), cell.maxZ())
+	}, func(c *quadCell) {
+		if c != cell {
+			channelIds = append(channelIds, c.channelId)
+		}
+	})
+	sort.Slice(channelIds, func(i, j int) bool {
+		return channelIds[i] < channelIds[j]
+	})
+	return channelIds, nil
+}
+
+// Runs in the source spatial(V1)/entity(V2) channel (shared instance)
+func (ctl *QuadtreeSpatialController) Notify(oldInfo common.SpatialInfo, newInfo common.SpatialInfo, handoverDataProvider func(common.ChannelId, common.ChannelId, interface{})) {
+	srcChannelId, err := ctl.GetChannelId(oldInfo)
+	if err != nil {
+		rootLogger.Error("failed to calculate srcChannelId", zap.Error(err), zap.String("oldInfo", oldInfo.String()))
+		return
+	}
+	dstChannelId, err := ctl.GetChannelId(newInfo)
+	if err != nil {
+		rootLogger.Error("failed to calculate dstChannelId", zap.Error(err), zap.String("newInfo", newInfo.String()))
+		return
+	}
+	// No migration between channels
+	if dstChannelId == srcChannelId {
+		return
+	}
+
+	handoverSpatialEntities(srcChannelId, dstChannelId, handoverDataProvider)
+}
+
+func (ctl *QuadtreeSpatialController) initServerConnections() {
+	if ctl.serverConnections == nil {
+		ctl.serverConnections = make([]ConnectionInChannel, ctl.ServerCols*ctl.ServerRows)
+	}
+}
+
+func (ctl *QuadtreeSpatialController) nextServerIndex() uint32 {
+	var i int = 0
+	for i = 0; i < len(ctl.serverConnections); i++ {
+		if ctl.serverConnections[i] == nil || ctl.serverConnections[i].IsClosing() {
+			break
+		}
+	}
+	return uint32(i)
+}
+
+func (ctl *QuadtreeSpatialController) createCellChannel(chId common.ChannelId, ownerConn ConnectionInChannel) (*Channel, error) {
+	channel := createChannelWithId(chId, channeldpb.ChannelType_SPATIAL, ownerConn)
+	if ctl.createChannelMsg != nil && ctl.createChannelMsg.Data != nil {
+		dataMsg, err := ctl.createChannelMsg.Data.UnmarshalNew()
+		if err != nil {
+			return channel, fmt.Errorf("failed to unmarshal data message for the new channel: %v", err)
+		}
+		channel.InitData(dataMsg, ctl.createChannelMsg.MergeOptions)
+	} else {
+		// Channel data should always be initialized
+		channel.InitData(nil, ctl.createChannelMsg.GetMergeOptions())
+	}
+	return channel, nil
+}
+
+func (ctl *QuadtreeSpatialController) CreateChannels(ctx MessageContext) ([]*Channel, error) {
+	ctl.initServerConnections()
+	serverIndex := ctl.nextServerIndex()
+	if serverIndex >= ctl.ServerCols*ctl.ServerRows {
+		return nil, fmt.Errorf("failed to create spatail channel as all %d root cells are allocated to %d servers", len(ctl.roots), len(ctl.roots))
+	}
+
+	msg, ok := ctx.Msg.(*channeldpb.CreateChannelMessage)
+	if !ok {
+		return nil, errors.New("ctx.Msg is not a CreateChannelMessage, will not be handled")
+	}
+	if ctl.createChannelMsg == nil {
+		ctl.createChannelMsg = msg
+	}
+
+	ctl.treeLock.RLock()
+	cells := make([]*quadCell, 0)
+	ctl.roots[serverIndex].forEachLeaf(func(*quadCell) bool { return true }, func(cell *quadCell) {
+		cells = append(cells, cell)
+	})
+	ctl.treeLock.RUnlock()
+
+	channels := make([]*Channel, len(cells))
+	for i, cell := range cells {
+		channel, err := ctl.createCellChannel(cell.channelId, ctx.Connection)
+		if err != nil {
+			return nil, err
+		}
+		channels[i] = channel
+	}
+
+	// Save the connection for later use
+	ctl.serverConnections[serverIndex] = ctx.Connection
+	serverIndex = ctl.nextServerIndex()
+	// When all spatial channels are created, subscribe each server to its adjacent cells if required.
+	if serverIndex == ctl.ServerCols*ctl.ServerRows {
+		if ctl.ServerInterestInAdjacentCells {
+			for i := uint32(0); i < serverIndex; i++ {
+				ctl.subToAdjacentCells(i, msg.SubOptions)
+			}
+		}
+
+		// ...and send the SpatialChannelsReadyMessage to all the spatial servers.
+		sendSpatialChannelsReady(ctl.serverConnections)
+	}
+
+	return channels, nil
+}
+
+// Subscribes the server to the other servers' cells that are adjacent to its authority area
+func (ctl *QuadtreeSpatialController) subToAdjacentCells(serverIndex uint32, subOptions *channeldpb.ChannelSubscriptionOptions) {
+	serverConn := ctl.serverConnections[serverIndex]
+	root := ctl.roots[serverIndex]
+
+	ctl.treeLock.RLock()
+	channelIds := make([]common.ChannelId, 0)
+	ctl.forEachLeaf(func(c *quadCell) bool {
+		return c.touches(root.minX, root.minZ, root.maxX(), root.maxZ())
+	}, func(c *quadCell) {
+		if c.serverIndex != serverIndex {
+			channelIds = append(channelIds, c.channelId)
+		}
+	})
+	ctl.treeLock.RUnlock()
+
+	for _, chId := range channelIds {
+		channelToSub := GetChannel(chId)
+		if channelToSub == nil {
+			continue
+		}
+		cs, shouldSend := serverConn.SubscribeToChannel(channelToSub, subOptions)
+		if shouldSend {
+			serverConn.sendSubscribed(MessageContext{}, channelToSub, serverConn, 0, &cs.options)
+		}
+	}
+}
+
+func (ctl *QuadtreeSpatialController) Tick() {
+	ctl.initServerConnections()
+	for i := 0; i < len(ctl.serverConnections); i++ {
+		if ctl.serverConnections[i] != nil && ctl.serverConnections[i].IsClosing() {
+			ctl.serverConnections[i] = nil
+			rootLogger.Info("reset spatial server connection", zap.Int("serverIndex", i))
+		}
+	}
+
+	if ctl.RestructureIntervalMs == 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(ctl.lastRestructureTime) < time.Duration(ctl.RestructureIntervalMs)*time.Millisecond {
+		return
+	}
+	ctl.lastRestructureTime = now
+
+	ctl.removeRetiredChannels(now)
+
+	// Only restructure when all the spatial servers are connected
+	if ctl.nextServerIndex() < uint32(len(ctl.serverConnections)) {
+		return
+	}
+	if ctl.restructure(now) {
+		broadcastSpatialRegions(ctl, ctl.serverConnections)
+	}
+}
+
+// Splits the crowded cells and merges the sparse cells. Returns true if any cell is changed.
+func (ctl *QuadtreeSpatialController) restructure(now time.Time) bool {
+	changed := false
+
+	// Collect the cells first, as the tree is changed during the iteration.
+	leaves := make([]*quadCell, 0, len(ctl.leaves))
+	parents := make(map[*quadCell]struct{})
+	for _, root := range ctl.roots {
+		collectLeavesAndParents(root, &leaves, parents)
+	}
+
+	if ctl.SplitEntityCount > 0 {
+		for _, cell := range leaves {
+			if !ctl.canSplit(cell) {
+				continue
+			}
+			ch := GetChannel(cell.channelId)
+			if ch == nil || ch.GetEntityCount() <= ctl.SplitEntityCount {
+				continue
+			}
+			if err := ctl.splitCell(cell, now); err != nil {
+				rootLogger.Error("failed to split spatial cell", zap.Error(err), zap.Uint32("channelId", uint32(cell.channelId)))
+				continue
+			}
+			changed = true
+		}
+	}
+
+	if ctl.MergeEntityCount > 0 {
+		for parent := range parents {
+			if !ctl.shouldMerge(parent, now) {
+				continue
+			}
+			if err := ctl.mergeCells(parent, now); err != nil {
+				rootLogger.Error("failed to merge spatial cells", zap.Error(err))
+				continue
+			}
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// Collects the leaf cells, and the cells whose children are all leaf cells.
+func collectLeavesAndParents(cell *quadCell, leaves *[]*quadCell, parents map[*quadCell]struct{}) {
+	if cell.isLeaf() {
+		*leaves = append(*leaves, cell)
+		return
+	}
+	allLeaves := true
+	for _, child := range cell.children {
+		collectLeavesAndParents(child, leaves, parents)
+		if !child.isLeaf() {
+			allLeaves = false
+		}
+	}
+	if allLeaves {
+		parents[cell] = struct{}{}
+	}
+}
+
+func (ctl *QuadtreeSpatialController) shouldMerge(parent *quadCell, now time.Time) bool {
+	if parent.isLeaf() || parent.width > ctl.MaxCellSize || parent.height > ctl.MaxCellSize {
+		return false
+	}
+	entityCount := 0
+	for _, child := range parent.children {
+		// The child may be split in the same restructure
+		if !child.isLeaf() {
+			return false
+		}
+		// Give the new cell some time to get the entities re-homed
+		if now.Sub(child.createdTime) < time.Duration(ctl.RestructureIntervalMs)*time.Millisecond {
+			return false
+		}
+		ch := GetChannel(child.channelId)
+		if ch == nil {
+			return false
+		}
+		entityCount += ch.GetEntityCount()
+	}
+	return entityCount < ctl.MergeEntityCount
+}
+
+func (ctl *QuadtreeSpatialController) splitCell(cell *quadCell, now time.Time) error {
+	oldChannel := GetChannel(cell.channelId)
+	if oldChannel == nil {
+		return fmt.Errorf("spatial channel %d doesn't exist", cell.channelId)
+	}
+
+	chIds := make([]common.ChannelId, 4)
+	for i := range chIds {
+		chId, err := ctl.allocChannelId()
+		if err != nil {
+			ctl.freeChannelIds = append(ctl.freeChannelIds, chIds[:i]...)
+			return err
+		}
+		chIds[i] = chId
+	}
+
+	// Create the channels before they can be found in the tree
+	newChannels := make([]*Channel, len(chIds))
+	for i, chId := range chIds {
+		ch, err := ctl.createCellChannel(chId, oldChannel.GetOwner())
+		if err != nil {
+			rootLogger.Error("failed to initialize the data of the split cell", zap.Error(err), zap.Uint32("channelId", uint32(chId)))
+		}
+		newChannels[i] = ch
+	}
+
+	oldCell := *cell
+	ctl.treeLock.Lock()
+	cell.split()
+	delete(ctl.leaves, cell.channelId)
+	cell.channelId = 0
+	for i, child := range cell.children {
+		child.channelId = chIds[i]
+		child.createdTime = now
+		ctl.leaves[child.channelId] = child
+	}
+	ctl.treeLock.Unlock()
+
+	ctl.replaceCellChannels([]*quadCell{&oldCell}, []*Channel{oldChannel}, newChannels)
+
+	rootLogger.Info("split spatial cell", zap.Uint32("channelId", uint32(oldCell.channelId)), zap.Uint32s("newChannelIds", CopyArray[common.ChannelId, uint32](chIds)))
+	return nil
+}
+
+func (ctl *QuadtreeSpatialController) mergeCells(parent *quadCell, now time.Time) error {
+	oldCells := make([]*quadCell, len(parent.children))
+	oldChannels := make([]*Channel, len(parent.children))
+	for i, child := range parent.children {
+		oldChannels[i] = GetChannel(child.channelId)
+		if oldChannels[i] == nil {
+			return fmt.Errorf("spatial channel %d doesn't exist", child.channelId)
+		}
+		oldCell := *child
+		oldCells[i] = &oldCell
+	}
+
+	chId, err := ctl.allocChannelId()
+	if err != nil {
+		return err
+	}
+
+	// Create the channel before it can be found in the tree
+	newChannel, err := ctl.createCellChannel(chId, oldChannels[0].GetOwner())
+	if err != nil {
+		rootLogger.Error("failed to initialize the data of the merged cell", zap.Error(err), zap.Uint32("channelId", uint32(chId)))
+	}
+
+	ctl.treeLock.Lock()
+	for _, child := range parent.children {
+		delete(ctl.leaves, child.channelId)
+	}
+	parent.children = nil
+	parent.channelId = chId
+	parent.createdTime = now
+	ctl.leaves[chId] = parent
+	ctl.treeLock.Unlock()
+
+	ctl.replaceCellChannels(oldCells, oldChannels, []*Channel{newChannel})
+
+	oldChIds := make([]uint32, len(oldCells))
+	for i, cell := range oldCells {
+		oldChIds[i] = uint32(cell.channelId)
+	}
+	rootLogger.Info("merged spatial cells", zap.Uint32s("channelIds", oldChIds), zap.Uint32("newChannelId", uint32(chId)))
+	return nil
+}
+
+// Subscribes the connections of the old channels to the new channels, re-homes the entities in the old cells,
+// and retires the old channels.
+func (ctl *QuadtreeSpatialController) replaceCellChannels(oldCells []*quadCell, oldChannels []*Channel, newChannels []*Channel) {
+	for _, oldChannel := range oldChannels {
+		oldChannel.subLock.RLock()
+		subs := make(map[ConnectionInChannel]*channeldpb.ChannelSubscriptionOptions, len(oldChannel.subscribedConnections))
+		for conn, cs := range oldChannel.subscribedConnections {
+			subs[conn] = &cs.options
+		}
+		oldChannel.subLock.RUnlock()
+
+		for conn, options := range subs {
+			for _, newChannel := range newChannels {
+				cs, shouldSend := conn.SubscribeToChannel(newChannel, options)
+				if cs != nil && shouldSend {
+					conn.sendSubscribed(MessageContext{}, newChannel, conn, 0, &cs.options)
+				}
+			}
+		}
+	}
+
+	retired := &retiredCellChannels{channels: oldChannels, retiredTime: time.Now()}
+	// Re-home the entities. The spatial info of the entity should be read in the entity channel's goroutine.
+	allChannels.Range(func(_ common.ChannelId, entityCh *Channel) bool {
+		// The removing channel doesn't execute the callback anymore
+		if entityCh.channelType != channeldpb.ChannelType_ENTITY || entityCh.IsRemoving() {
+			return true
+		}
+
+		atomic.AddInt32(&retired.pendingRehomes, 1)
+		entityCh.Execute(func(entityCh *Channel) {
+			defer atomic.AddInt32(&retired.pendingRehomes, -1)
+			dataMsg, ok := entityCh.GetDataMessage().(EntityChannelDataWithSpatialInfo)
+			if !ok || dataMsg.GetSpatialInfo() == nil {
+				return
+			}
+			info := dataMsg.GetSpatialInfo()
+			for _, oldCell := range oldCells {
+				if !oldCell.contains(info.X, info.Z) {
+					continue
+				}
+				dstChannelId, err := ctl.GetChannelId(*info)
+				if err != nil {
+					return
+				}
+				handoverSpatialEntities(oldCell.channelId, dstChannelId, func(_ common.ChannelId, _ common.ChannelId, data interface{}) {
+					if entityId, ok := data.(*EntityId); ok {
+						*entityId = EntityId(entityCh.id)
+					}
+				})
+				return
+			}
+		})
+		return true
+	})
+
+	ctl.retiredChannels = append(ctl.retiredChannels, retired)
+}
+
+// Unsubscribes the connections from the retired channels, then removes the channels.
+// The channels are kept until all the entities in them are re-homed, or the re-homes time out.
+func (ctl *QuadtreeSpatialController) removeRetiredChannels(now time.Time) {
+	remaining := ctl.retiredChannels[:0]
+	for _, retired := range ctl.retiredChannels {
+		if pendingRehomes := atomic.LoadInt32(&retired.pendingRehomes); pendingRehomes > 0 {
+			if now.Sub(retired.retiredTime) < rehomeEntitiesTimeout {
+				remaining = append(remaining, retired)
+				continue
+			}
+			rootLogger.Warn("timed out re-homing the entities of the retired spatial channels", zap.Int32("pendingRehomes", pendingRehomes))
+		}
+
+		for _, ch := range retired.channels {
+			for conn := range ch.GetAllConnections() {
+				if conn.IsClosing() {
+					continue
+				}
+				conn.UnsubscribeFromChannel(ch)
+				conn.sendUnsubscribed(MessageContext{}, ch, nil, 0)
+			}
+			RemoveChannel(ch)
+			ctl.freeChannelIds = append(ctl.freeChannelIds, ch.id)
+		}
+	}
+	ctl.retiredChannels = remaining
+}
@@ -0,0 +1,322 @@
+package channeld
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/metaworking/channeld/internal/testpb"
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	The cells of the test world (x: 0-400, z: 0-200) and the servers:
+
+2  3  |  6  7
+0  1  |  4  5
+*/
+func createTestQuadtreeController(t *testing.T) *QuadtreeSpatialController {
+	ctl, err := CreateSpatialController("QuadtreeSpatialController", []byte(`{
+		"WorldWidth": 400,
+		"WorldHeight": 200,
+		"ServerCols": 2,
+		"ServerRows": 1,
+		"MinCellSize": 25,
+		"MaxCellSize": 100,
+		"SplitEntityCount": 10,
+		"MergeEntityCount": 5,
+		"RestructureIntervalMs": 1000
+	}`))
+	assert.NoError(t, err)
+	return ctl.(*QuadtreeSpatialController)
+}
+
+func quadtreeTestChIds(indexes ...uint32) []common.ChannelId {
+	chIds := make([]common.ChannelId, len(indexes))
+	for i, index := range indexes {
+		chIds[i] = GlobalSettings.SpatialChannelIdStart + common.ChannelId(index)
+	}
+	return chIds
+}
+
+func TestQuadtreeLoadConfig(t *testing.T) {
+	ctl := createTestQuadtreeController(t)
+	assert.Len(t, ctl.leaves, 8)
+	assert.EqualValues(t, 100, ctl.DistanceUnit)
+
+	chId, err := ctl.GetChannelId(common.SpatialInfo{X: 150, Z: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(1)[0], chId)
+	chId, err = ctl.GetChannelId(common.SpatialInfo{X: 399, Z: 199})
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(7)[0], chId)
+	_, err = ctl.GetChannelId(common.SpatialInfo{X: 400, Z: 0})
+	assert.Error(t, err)
+
+	regions, err := ctl.GetRegions()
+	assert.NoError(t, err)
+	assert.Len(t, regions, 8)
+	assert.EqualValues(t, 200, regions[4].Min.X)
+	assert.EqualValues(t, 1, regions[4].ServerIndex)
+
+	assert.Error(t, (&QuadtreeSpatialController{}).LoadConfig([]byte(`{"WorldWidth": 400, "WorldHeight": 200, "ServerCols": 2, "ServerRows": 1, "MinCellSize": 200, "MaxCellSize": 100}`)))
+	assert.Error(t, (&QuadtreeSpatialController{}).LoadConfig([]byte(`{"WorldWidth": 400, "WorldHeight": 200, "ServerCols": 2, "ServerRows": 1, "MinCellSize": 25, "MaxCellSize": 100, "SplitEntityCount": 10, "MergeEntityCount": 10}`)))
+}
+
+func TestQuadtreeQueryChannelIds(t *testing.T) {
+	ctl := createTestQuadtreeController(t)
+	chIds := quadtreeTestChIds(0, 1, 2, 3, 4, 5, 6, 7)
+
+	result, err := ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 150, Z: 50},
+			Radius: 60,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{chIds[0]: 1, chIds[1]: 0, chIds[3]: 1, chIds[4]: 1}, result)
+
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		BoxAOI: &channeldpb.SpatialInterestQuery_BoxAOI{
+			Center: &channeldpb.SpatialInfo{X: 150, Z: 50},
+			Extent: &channeldpb.SpatialInfo{X: 60, Z: 60},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{chIds[0]: 1, chIds[1]: 0, chIds[2]: 1, chIds[3]: 1, chIds[4]: 1, chIds[6]: 1}, result)
+
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		ConeAOI: &channeldpb.SpatialInterestQuery_ConeAOI{
+			Center:    &channeldpb.SpatialInfo{X: 150, Z: 50},
+			Direction: &channeldpb.SpatialInfo{X: 1, Z: 0},
+			Angle:     math.Pi / 8,
+			Radius:    120,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{chIds[1]: 0, chIds[4]: 1}, result)
+
+	result, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SpotsAOI: &channeldpb.SpatialInterestQuery_SpotsAOI{
+			Spots: []*channeldpb.SpatialInfo{{X: 350, Z: 150}, {X: 10, Z: 10}, {X: 1000, Z: 10}},
+			Dists: []uint32{2},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{chIds[7]: 2, chIds[0]: 0}, result)
+
+	_, err = ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: -10, Z: 50},
+			Radius: 60,
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestQuadtreeSplitAndMerge(t *testing.T) {
+	InitChannels()
+
+	ctl := createTestQuadtreeController(t)
+	conns := []*testConnection{createTestConnection(), createTestConnection()}
+	ctx := MessageContext{
+		MsgType: channeldpb.MessageType_CREATE_CHANNEL,
+		Msg:     &channeldpb.CreateChannelMessage{},
+	}
+	for _, conn := range conns {
+		ctx.Connection = conn
+		channels, err := ctl.CreateChannels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, channels, 4)
+	}
+	_, err := ctl.CreateChannels(ctx)
+	assert.Error(t, err)
+
+	adjacentChIds, err := ctl.GetAdjacentChannels(quadtreeTestChIds(1)[0])
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(0, 2, 3, 4, 6), adjacentChIds)
+
+	// Split cell 1 into 8, 9, 10, 11
+	now := time.Now()
+	atomic.StoreInt32(&GetChannel(quadtreeTestChIds(1)[0]).entityCount, 20)
+	assert.True(t, ctl.restructure(now))
+	assert.Len(t, ctl.leaves, 11)
+	assert.Len(t, ctl.retiredChannels, 1)
+	for _, chId := range quadtreeTestChIds(8, 9, 10, 11) {
+		ch := GetChannel(chId)
+		assert.NotNil(t, ch)
+		assert.Equal(t, conns[0], ch.GetOwner())
+	}
+
+	chId, err := ctl.GetChannelId(common.SpatialInfo{X: 160, Z: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(9)[0], chId)
+
+	// The neighbours in different sizes
+	adjacentChIds, err = ctl.GetAdjacentChannels(quadtreeTestChIds(4)[0])
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(3, 5, 6, 7, 9, 11), adjacentChIds)
+	adjacentChIds, err = ctl.GetAdjacentChannels(quadtreeTestChIds(9)[0])
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(4, 8, 10, 11), adjacentChIds)
+
+	result, err := ctl.QueryChannelIds(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 160, Z: 10},
+			Radius: 20,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[common.ChannelId]uint{quadtreeTestChIds(8)[0]: 1, quadtreeTestChIds(9)[0]: 0}, result)
+
+	// The new cells are not merged until they are old enough
+	assert.False(t, ctl.restructure(now))
+
+	// Merge 8, 9, 10, 11 into 12
+	assert.True(t, ctl.restructure(now.Add(time.Second)))
+	assert.Len(t, ctl.leaves, 8)
+	chId, err = ctl.GetChannelId(common.SpatialInfo{X: 160, Z: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(12)[0], chId)
+
+	// The retired channels are removed after the entities are re-homed, and their ids are reused
+	assert.Eventually(t, func() bool {
+		ctl.removeRetiredChannels(now)
+		return len(ctl.retiredChannels) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Nil(t, GetChannel(quadtreeTestChIds(1)[0]))
+	assert.Nil(t, GetChannel(quadtreeTestChIds(9)[0]))
+	assert.Len(t, ctl.freeChannelIds, 5)
+	newChId, err := ctl.allocChannelId()
+	assert.NoError(t, err)
+	assert.Contains(t, quadtreeTestChIds(1, 8, 9, 10, 11), newChId)
+}
+
+// The spatial channel data that tracks the entities in the spatial channel
+type testSpatialEntitiesData struct {
+	*testpb.TestChannelDataMessage
+	entityIds map[EntityId]struct{}
+}
+
+func (d *testSpatialEntitiesData) AddEntity(entityId EntityId, _ common.Message) error {
+	d.entityIds[entityId] = struct{}{}
+	return nil
+}
+
+func (d *testSpatialEntitiesData) RemoveEntity(entityId EntityId) error {
+	delete(d.entityIds, entityId)
+	return nil
+}
+
+func TestQuadtreeRehomeEntitiesBeforeRemovingRetiredChannels(t *testing.T) {
+	isolateChannelTest(t)
+	if _, exists := channelDataTypeRegistery[channeldpb.ChannelType_SPATIAL]; !exists {
+		RegisterChannelDataType(channeldpb.ChannelType_SPATIAL, &testpb.TestChannelDataMessage{})
+		defer delete(channelDataTypeRegistery, channeldpb.ChannelType_SPATIAL)
+	}
+
+	ctl := createTestQuadtreeController(t)
+	for i := 0; i < 2; i++ {
+		_, err := ctl.CreateChannels(MessageContext{
+			MsgType:    channeldpb.MessageType_CREATE_CHANNEL,
+			Msg:        &channeldpb.CreateChannelMessage{},
+			Connection: createTestConnection(),
+		})
+		assert.NoError(t, err)
+	}
+
+	// Returns the entities in the data of the spatial channel. The data is set for the first time.
+	getEntityIds := func(chId common.ChannelId) map[EntityId]struct{} {
+		result := make(chan map[EntityId]struct{}, 1)
+		GetChannel(chId).Execute(func(ch *Channel) {
+			data, ok := ch.GetDataMessage().(*testSpatialEntitiesData)
+			if !ok {
+				data = &testSpatialEntitiesData{&testpb.TestChannelDataMessage{}, make(map[EntityId]struct{})}
+				ch.InitData(data, nil)
+			}
+			entityIds := make(map[EntityId]struct{}, len(data.entityIds))
+			for entityId := range data.entityIds {
+				entityIds[entityId] = struct{}{}
+			}
+			result <- entityIds
+		})
+		return <-result
+	}
+
+	// The entity in cell 1, which will be split into 8, 9, 10, 11
+	entityCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+1, channeldpb.ChannelType_ENTITY, nil)
+	entityId := EntityId(entityCh.id)
+	entityCh.Execute(func(ch *Channel) {
+		ch.InitData(&testEntityDataWithSpatialInfo{
+			TestChannelDataMessage: &testpb.TestChannelDataMessage{},
+			spatialInfo:            &common.SpatialInfo{X: 160, Z: 10},
+		}, nil)
+	})
+	for _, chId := range quadtreeTestChIds(0, 1, 2, 3, 4, 5, 6, 7) {
+		getEntityIds(chId)
+	}
+
+	// Hold the entity channel's goroutine, so the re-home is queued
+	release := make(chan struct{})
+	entityCh.Execute(func(ch *Channel) {
+		<-release
+	})
+
+	now := time.Now()
+	atomic.StoreInt32(&GetChannel(quadtreeTestChIds(1)[0]).entityCount, 20)
+	assert.True(t, ctl.restructure(now))
+	for _, chId := range quadtreeTestChIds(8, 9, 10, 11) {
+		getEntityIds(chId)
+	}
+
+	// The retired channel is kept until the entity is re-homed
+	ctl.removeRetiredChannels(now)
+	assert.NotNil(t, GetChannel(quadtreeTestChIds(1)[0]))
+	close(release)
+	assert.Eventually(t, func() bool {
+		_, exists := getEntityIds(quadtreeTestChIds(9)[0])[entityId]
+		return exists
+	}, time.Second, 10*time.Millisecond)
+	ctl.removeRetiredChannels(now)
+	assert.Nil(t, GetChannel(quadtreeTestChIds(1)[0]))
+	assert.Empty(t, ctl.retiredChannels)
+
+	// Merge 8, 9, 10, 11 into the cell that reuses the channel id of 1
+	release = make(chan struct{})
+	entityCh.Execute(func(ch *Channel) {
+		<-release
+	})
+	now = now.Add(time.Second)
+	assert.True(t, ctl.restructure(now))
+	mergedChId, err := ctl.GetChannelId(common.SpatialInfo{X: 160, Z: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, quadtreeTestChIds(1)[0], mergedChId)
+	getEntityIds(mergedChId)
+
+	ctl.removeRetiredChannels(now)
+	assert.NotNil(t, GetChannel(quadtreeTestChIds(9)[0]))
+	close(release)
+	assert.Eventually(t, func() bool {
+		_, exists := getEntityIds(mergedChId)[entityId]
+		return exists
+	}, time.Second, 10*time.Millisecond)
+	ctl.removeRetiredChannels(now)
+	for _, chId := range quadtreeTestChIds(8, 9, 10, 11) {
+		assert.Nil(t, GetChannel(chId))
+	}
+
+	// The retired channels are removed after the timeout, even if the re-home never finishes
+	ctl.retiredChannels = append(ctl.retiredChannels, &retiredCellChannels{
+		channels:       []*Channel{GetChannel(mergedChId)},
+		pendingRehomes: 1,
+		retiredTime:    now,
+	})
+	ctl.removeRetiredChannels(now)
+	assert.Len(t, ctl.retiredChannels, 1)
+	ctl.removeRetiredChannels(now.Add(rehomeEntitiesTimeout))
+	assert.Empty(t, ctl.retiredChannels)
+	assert.Nil(t, GetChannel(mergedChId))
+}
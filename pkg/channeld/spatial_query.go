@@ -0,0 +1,131 @@
+package channeld
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+)
+
+var ErrNoSpatialController = errors.New("spatial controller does not exist")
+
+// The entity channels indexed by the spatial channel that their positions are in.
+// Updated in the entity channels' goroutines when the entities move, so it's protected by the mutex.
+type spatialEntityIndex struct {
+	lock        sync.RWMutex
+	bySpatialCh map[common.ChannelId]map[EntityId]struct{}
+	spatialChOf map[EntityId]common.ChannelId
+}
+
+var spatialEntities = newSpatialEntityIndex()
+
+func newSpatialEntityIndex() *spatialEntityIndex {
+	return &spatialEntityIndex{
+		bySpatialCh: make(map[common.ChannelId]map[EntityId]struct{}),
+		spatialChOf: make(map[EntityId]common.ChannelId),
+	}
+}
+
+func (idx *spatialEntityIndex) set(entityId EntityId, spatialChId common.ChannelId) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	if oldChId, exists := idx.spatialChOf[entityId]; exists {
+		if oldChId == spatialChId {
+			return
+		}
+		idx.removeFrom(entityId, oldChId)
+	}
+	idx.spatialChOf[entityId] = spatialChId
+	entities, exists := idx.bySpatialCh[spatialChId]
+	if !exists {
+		entities = make(map[EntityId]struct{})
+		idx.bySpatialCh[spatialChId] = entities
+	}
+	entities[entityId] = struct{}{}
+}
+
+func (idx *spatialEntityIndex) remove(entityId EntityId) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	if spatialChId, exists := idx.spatialChOf[entityId]; exists {
+		idx.removeFrom(entityId, spatialChId)
+		delete(idx.spatialChOf, entityId)
+	}
+}
+
+// Should be called with the lock held.
+func (idx *spatialEntityIndex) removeFrom(entityId EntityId, spatialChId common.ChannelId) {
+	if entities, exists := idx.bySpatialCh[spatialChId]; exists {
+		delete(entities, entityId)
+		if len(entities) == 0 {
+			delete(idx.bySpatialCh, spatialChId)
+		}
+	}
+}
+
+// Returns a copy of the IDs of the entities in the spatial channel.
+func (idx *spatialEntityIndex) getEntities(spatialChId common.ChannelId) []EntityId {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	entities := make([]EntityId, 0, len(idx.bySpatialCh[spatialChId]))
+	for entityId := range idx.bySpatialCh[spatialChId] {
+		entities = append(entities, entityId)
+	}
+	return entities
+}
+
+type SpatialEntity struct {
+	EntityId    EntityId
+	SpatialInfo common.SpatialInfo
+}
+
+// Returns the entities of the default world whose positions are in the area of any AOI in the query, sorted by the entity ID.
+// See QuerySpatialEntitiesInWorld.
+func QuerySpatialEntities(query *channeldpb.SpatialInterestQuery) ([]SpatialEntity, error) {
+	return QuerySpatialEntitiesInWorld(DefaultSpatialWorldId, query)
+}
+
+// Returns the entities of the world whose positions are in the area of any AOI in the query, sorted by the entity ID.
+// The spatial channels that don't intersect with the query are pruned by SpatialController.QueryChannelIds first,
+// then the positions of the entities in the rest spatial channels (looked up in the spatial entity index) are checked
+// in 3D for the 3D spatial controller, or on the XZ plane otherwise (see isInSpatialInterest).
+// Only the entity channels whose data implements EntityChannelDataWithSpatialInfo can be found. The positions are as of the entity channels' last tick.
+func QuerySpatialEntitiesInWorld(worldId string, query *channeldpb.SpatialInterestQuery) ([]SpatialEntity, error) {
+	spatialCtl := GetSpatialControllerOfWorld(worldId)
+	if spatialCtl == nil {
+		return nil, ErrNoSpatialController
+	}
+
+	spatialChIds, err := spatialCtl.QueryChannelIds(query)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]SpatialEntity, 0)
+	if len(spatialChIds) == 0 {
+		return entities, nil
+	}
+
+	for spatialChId := range spatialChIds {
+		for _, entityId := range spatialEntities.getEntities(spatialChId) {
+			ch := GetChannel(common.ChannelId(entityId))
+			if ch == nil || ch.IsRemoving() || ch.spatialWorldId != worldId {
+				continue
+			}
+			info := ch.GetSpatialInfo()
+			if info == nil {
+				continue
+			}
+			if isInSpatialInterest(query, info, 0, spatialCtl) {
+				entities = append(entities, SpatialEntity{EntityId: entityId, SpatialInfo: *info})
+			}
+		}
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].EntityId < entities[j].EntityId
+	})
+	return entities, nil
+}
@@ -0,0 +1,130 @@
+package channeld
+
+import (
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerySpatialEntities(t *testing.T) {
+	InitChannels()
+
+	savedController := spatialController
+	spatialController = nil
+	defer func() {
+		spatialController = savedController
+	}()
+
+	query := &channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 100, Z: 100},
+			Radius: 30,
+		},
+	}
+	_, err := QuerySpatialEntities(query)
+	assert.ErrorIs(t, err, ErrNoSpatialController)
+
+	// 4-by-4-grid world of 50x50 grids
+	spatialController = &StaticGrid2DSpatialController{
+		GridWidth:  50,
+		GridHeight: 50,
+		GridCols:   4,
+		GridRows:   4,
+		ServerCols: 1,
+		ServerRows: 1,
+	}
+
+	positions := []common.SpatialInfo{
+		// In the sphere, across the grids
+		{X: 90, Z: 90},
+		{X: 110, Z: 120},
+		// In the grids of the sphere, but out of the sphere
+		{X: 74, Z: 126},
+		// Out of the grids of the sphere
+		{X: 190, Z: 10},
+	}
+	for i, pos := range positions {
+		pos := pos
+		entityCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+common.ChannelId(i), channeldpb.ChannelType_ENTITY, nil)
+		entityCh.setSpatialInfo(&pos)
+		defer func() {
+			entityCh.removing = 1
+		}()
+	}
+	// The entity without the spatial info
+	noInfoCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+common.ChannelId(len(positions)), channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		noInfoCh.removing = 1
+	}()
+
+	entities, err := QuerySpatialEntities(query)
+	assert.NoError(t, err)
+	assert.Equal(t, []SpatialEntity{
+		{EntityId: EntityId(GlobalSettings.EntityChannelIdStart), SpatialInfo: positions[0]},
+		{EntityId: EntityId(GlobalSettings.EntityChannelIdStart + 1), SpatialInfo: positions[1]},
+	}, entities)
+
+	query = &channeldpb.SpatialInterestQuery{
+		BoxAOI: &channeldpb.SpatialInterestQuery_BoxAOI{
+			Center: &channeldpb.SpatialInfo{X: 175, Z: 25},
+			Extent: &channeldpb.SpatialInfo{X: 25, Z: 25},
+		},
+	}
+	entities, err = QuerySpatialEntities(query)
+	assert.NoError(t, err)
+	assert.Len(t, entities, 1)
+	assert.EqualValues(t, GlobalSettings.EntityChannelIdStart+3, entities[0].EntityId)
+}
+
+func TestQuerySpatialEntities3D(t *testing.T) {
+	InitChannels()
+
+	savedController := spatialController
+	defer func() {
+		spatialController = savedController
+	}()
+	// 4x4x4-grid world of 10x10x10 grids
+	spatialController = createTestGrid3DController(t, 0)
+
+	positions := []common.SpatialInfo{
+		// In the sphere
+		{X: 20, Y: 20, Z: 26},
+		// In the sphere on the XZ plane, but below it
+		{X: 25, Y: 13, Z: 25},
+		// Out of the grids of the sphere
+		{X: 20, Y: 5, Z: 20},
+	}
+	for i, pos := range positions {
+		pos := pos
+		entityCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+common.ChannelId(i), channeldpb.ChannelType_ENTITY, nil)
+		entityCh.setSpatialInfo(&pos)
+		defer func() {
+			entityCh.removing = 1
+		}()
+	}
+
+	entities, err := QuerySpatialEntities(&channeldpb.SpatialInterestQuery{
+		SphereAOI: &channeldpb.SpatialInterestQuery_SphereAOI{
+			Center: &channeldpb.SpatialInfo{X: 20, Y: 20, Z: 20},
+			Radius: 8,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []SpatialEntity{
+		{EntityId: EntityId(GlobalSettings.EntityChannelIdStart), SpatialInfo: positions[0]},
+	}, entities)
+
+	entities, err = QuerySpatialEntities(&channeldpb.SpatialInterestQuery{
+		BoxAOI: &channeldpb.SpatialInterestQuery_BoxAOI{
+			Center: &channeldpb.SpatialInfo{X: 20, Y: 10, Z: 20},
+			Extent: &channeldpb.SpatialInfo{X: 10, Y: 5, Z: 10},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []SpatialEntity{
+		{EntityId: EntityId(GlobalSettings.EntityChannelIdStart + 1), SpatialInfo: positions[1]},
+		{EntityId: EntityId(GlobalSettings.EntityChannelIdStart + 2), SpatialInfo: positions[2]},
+	}, entities)
+}
@@ -3,6 +3,8 @@ package channeld
 import (
 	"math"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/metaworking/channeld/pkg/channeldpb"
@@ -842,3 +844,69 @@ func TestGetChannelId1(t *testing.T) {
 	_, err = ctl.GetChannelId(common.SpatialInfo{X: 450, Z: 200})
 	assert.Error(t, err)
 }
+
+type testSpatialController struct {
+	StaticGrid2DSpatialController
+	loaded bool
+}
+
+func (ctl *testSpatialController) LoadConfig(config []byte) error {
+	ctl.loaded = true
+	return ctl.StaticGrid2DSpatialController.LoadConfig(config)
+}
+
+func TestInitSpatialController(t *testing.T) {
+	oldConfig := GlobalSettings.SpatialControllerConfig
+	oldController := spatialController
+	defer func() {
+		GlobalSettings.SpatialControllerConfig = oldConfig
+		spatialController = oldController
+		SetSpatialDampingProfiles(nil)
+	}()
+
+	cfgPath := filepath.Join(t.TempDir(), "spatial.json")
+	GlobalSettings.SpatialControllerConfig.Set(cfgPath)
+	writeConfig := func(cfg string) {
+		assert.NoError(t, os.WriteFile(cfgPath, []byte(cfg), 0644))
+	}
+	gridConfig := `{"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1}`
+
+	// The config file doesn't exist
+	assert.Error(t, InitSpatialController())
+
+	// The default type
+	writeConfig(`{"Config": ` + gridConfig + `}`)
+	assert.NoError(t, InitSpatialController())
+	assert.IsType(t, &StaticGrid2DSpatialController{}, GetSpatialController())
+	// The server interest border size can be 0, but not negative
+	writeConfig(`{"Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1, "ServerInterestBorderSize": -1}}`)
+	assert.Error(t, InitSpatialController())
+
+	writeConfig(`{"SpatialControllerType": "StaticGrid3DSpatialController", "Config": {"GridWidth": 10, "GridHeight": 10, "GridLayerHeight": 10, "GridCols": 2, "GridRows": 2, "GridLayers": 2, "ServerCols": 1, "ServerRows": 1, "ServerLayers": 1}}`)
+	assert.NoError(t, InitSpatialController())
+	assert.IsType(t, &StaticGrid3DSpatialController{}, GetSpatialController())
+
+	// Unknown type
+	writeConfig(`{"SpatialControllerType": "TestSpatialController", "Config": ` + gridConfig + `}`)
+	assert.Error(t, InitSpatialController())
+	assert.IsType(t, &StaticGrid3DSpatialController{}, GetSpatialController())
+
+	// Custom type
+	RegisterSpatialControllerType("TestSpatialController", func() SpatialController {
+		return &testSpatialController{}
+	})
+	defer delete(spatialControllerTypeRegistry, "TestSpatialController")
+	assert.NoError(t, InitSpatialController())
+	ctl, ok := GetSpatialController().(*testSpatialController)
+	assert.True(t, ok)
+	assert.True(t, ctl.loaded)
+	assert.EqualValues(t, 2, ctl.GridCols)
+
+	// The error of LoadConfig is returned, and the controller is not replaced
+	writeConfig(`{"SpatialControllerType": "TestSpatialController", "Config": {"GridWidth": 0}}`)
+	assert.Error(t, InitSpatialController())
+	assert.Same(t, ctl, GetSpatialController())
+
+	writeConfig(`{"SpatialControllerType": "TestSpatialController"}`)
+	assert.Error(t, InitSpatialController())
+}
@@ -0,0 +1,266 @@
+package channeld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"go.uber.org/zap"
+)
+
+/* Spatial worlds
+ * By default, channeld has only one spatial world (the default world, with the empty ID), which is controlled by the SpatialController
+ * created from the spatial controller config. The spatial channel IDs of the default world start from SpatialChannelIdStart.
+ * More worlds (e.g. the instanced dungeons and arenas) can be defined in 'Worlds' of the spatial controller config. Each world has its own
+ * SpatialController, sub-range of the spatial channel IDs, and spatial servers.
+ * The messages with the worldId (CreateChannelMessage, QuerySpatialChannelMessage, QuerySpatialEntitiesMessage and UpdateSpatialInterestMessage)
+ * are handled by the SpatialController of the world. The entity channels created in the spatial channels of a world belong to that world.
+ * A SUBWORLD channel created with the worldId owns the world. When the SUBWORLD channel is removed, the spatial channels and the entity channels
+ * of the world are removed, and the world is reset, so it can be owned by another SUBWORLD channel.
+ */
+
+const DefaultSpatialWorldId = ""
+
+// The sub-range of the spatial channel IDs that a SpatialController uses. The zero value is the whole range of the spatial channel IDs.
+// Embedded by the built-in SpatialControllers. The custom SpatialControllers should embed it in order to be used in the named worlds.
+type SpatialChannelIdRange struct {
+	start common.ChannelId
+	end   common.ChannelId
+}
+
+// Returns the first spatial channel ID of the range.
+func (r *SpatialChannelIdRange) ChannelIdStart() common.ChannelId {
+	if r.start == 0 {
+		return GlobalSettings.SpatialChannelIdStart
+	}
+	return r.start
+}
+
+// Returns the last spatial channel ID of the range (inclusive).
+func (r *SpatialChannelIdRange) ChannelIdEnd() common.ChannelId {
+	if r.end == 0 {
+		return GlobalSettings.EntityChannelIdStart - 1
+	}
+	return r.end
+}
+
+func (r *SpatialChannelIdRange) SetChannelIdRange(start common.ChannelId, end common.ChannelId) {
+	r.start = start
+	r.end = end
+}
+
+type SpatialChannelIdRangeSetter interface {
+	SetChannelIdRange(start common.ChannelId, end common.ChannelId)
+}
+
+type SpatialWorldConfig struct {
+	Id string
+	// The type of the SpatialController. If not set, DefaultSpatialControllerType will be used.
+	SpatialControllerType string
+	// The parameters of the SpatialController.
+	Config json.RawMessage
+	// The first spatial channel ID of the world. Should be in [SpatialChannelIdStart, EntityChannelIdStart), after the spatial channels of the default world.
+	ChannelIdStart common.ChannelId
+	// How many spatial channel IDs the world can use.
+	ChannelIdCount uint32
+}
+
+type SpatialWorld struct {
+	config SpatialWorldConfig
+	// The SpatialController is replaced when the world is reset.
+	controller atomic.Value
+	// The id of the SUBWORLD channel that owns the world. 0 = the world is not owned.
+	ownerChannelId uint32
+}
+
+// The named worlds. Read-only after InitSpatialController().
+var spatialWorlds = make(map[string]*SpatialWorld)
+
+func (w *SpatialWorld) Id() string {
+	return w.config.Id
+}
+
+func (w *SpatialWorld) Controller() SpatialController {
+	ctl, _ := w.controller.Load().(SpatialController)
+	return ctl
+}
+
+// Returns the first and the last (inclusive) spatial channel ID of the world.
+func (w *SpatialWorld) ChannelIdRange() (common.ChannelId, common.ChannelId) {
+	return w.config.ChannelIdStart, w.config.ChannelIdStart + common.ChannelId(w.config.ChannelIdCount) - 1
+}
+
+func (w *SpatialWorld) containsChannelId(chId common.ChannelId) bool {
+	start, end := w.ChannelIdRange()
+	return chId >= start && chId <= end
+}
+
+// Returns the id of the SUBWORLD channel that owns the world, or 0 if the world is not owned.
+func (w *SpatialWorld) GetOwnerChannelId() common.ChannelId {
+	return common.ChannelId(atomic.LoadUint32(&w.ownerChannelId))
+}
+
+func (w *SpatialWorld) createController() (SpatialController, error) {
+	ctlType := w.config.SpatialControllerType
+	if ctlType == "" {
+		ctlType = DefaultSpatialControllerType
+	}
+	ctl, err := CreateSpatialController(ctlType, w.config.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the spatial controller of world '%s': %w", w.config.Id, err)
+	}
+	rangeSetter, ok := ctl.(SpatialChannelIdRangeSetter)
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't implement SpatialChannelIdRangeSetter, and can't be used in world '%s'", ctlType, w.config.Id)
+	}
+	rangeSetter.SetChannelIdRange(w.ChannelIdRange())
+
+	regions, err := ctl.GetRegions()
+	if err == nil && len(regions) > int(w.config.ChannelIdCount) {
+		return nil, fmt.Errorf("world '%s' has %d spatial channels, but only %d channel IDs", w.config.Id, len(regions), w.config.ChannelIdCount)
+	}
+	return ctl, nil
+}
+
+// Creates the named worlds from 'Worlds' in the spatial controller config. The default world should be created before.
+func loadSpatialWorlds(data json.RawMessage) (map[string]*SpatialWorld, error) {
+	var configs []SpatialWorldConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, err
+	}
+
+	// The spatial channels of the default world
+	minChannelId := GlobalSettings.SpatialChannelIdStart
+	if spatialController != nil {
+		if regions, err := spatialController.GetRegions(); err == nil {
+			minChannelId += common.ChannelId(len(regions))
+		}
+	}
+
+	worlds := make(map[string]*SpatialWorld, len(configs))
+	for _, config := range configs {
+		if config.Id == DefaultSpatialWorldId {
+			return nil, errors.New("the world ID should not be empty")
+		}
+		if _, exists := worlds[config.Id]; exists {
+			return nil, fmt.Errorf("duplicated world ID '%s'", config.Id)
+		}
+		if config.ChannelIdCount == 0 {
+			return nil, fmt.Errorf("ChannelIdCount of world '%s' should be positive", config.Id)
+		}
+
+		world := &SpatialWorld{config: config}
+		start, end := world.ChannelIdRange()
+		if start < minChannelId || end >= GlobalSettings.EntityChannelIdStart || end < start {
+			return nil, fmt.Errorf("the channel IDs [%d, %d] of world '%s' should be in [%d, %d)", start, end, config.Id, minChannelId, GlobalSettings.EntityChannelIdStart)
+		}
+		for _, other := range worlds {
+			if other.containsChannelId(start) || other.containsChannelId(end) || world.containsChannelId(other.config.ChannelIdStart) {
+				return nil, fmt.Errorf("the channel IDs of world '%s' overlap with world '%s'", config.Id, other.Id())
+			}
+		}
+
+		ctl, err := world.createController()
+		if err != nil {
+			return nil, err
+		}
+		world.controller.Store(ctl)
+		worlds[config.Id] = world
+	}
+	return worlds, nil
+}
+
+// Returns the named world, or nil if it doesn't exist.
+func GetSpatialWorld(worldId string) *SpatialWorld {
+	return spatialWorlds[worldId]
+}
+
+// Returns the SpatialController of the world, or nil if the world doesn't exist.
+func GetSpatialControllerOfWorld(worldId string) SpatialController {
+	if worldId == DefaultSpatialWorldId {
+		return spatialController
+	}
+	if world := GetSpatialWorld(worldId); world != nil {
+		return world.Controller()
+	}
+	return nil
+}
+
+// Returns the ID of the world that the spatial channel belongs to.
+func GetSpatialWorldIdByChannelId(chId common.ChannelId) string {
+	for worldId, world := range spatialWorlds {
+		if world.containsChannelId(chId) {
+			return worldId
+		}
+	}
+	return DefaultSpatialWorldId
+}
+
+// Returns the SpatialController of the world that the spatial channel belongs to.
+func GetSpatialControllerByChannelId(chId common.ChannelId) SpatialController {
+	return GetSpatialControllerOfWorld(GetSpatialWorldIdByChannelId(chId))
+}
+
+// Called in the GLOBAL channel
+func tickSpatialWorlds() {
+	for _, world := range spatialWorlds {
+		if ctl := world.Controller(); ctl != nil {
+			ctl.Tick()
+		}
+	}
+}
+
+// Makes the SUBWORLD channel the owner of the world. Called in the GLOBAL channel.
+func (w *SpatialWorld) setOwnerChannel(ch *Channel) error {
+	if ch.channelType != channeldpb.ChannelType_SUBWORLD {
+		return fmt.Errorf("only the SUBWORLD channel can own world '%s'", w.Id())
+	}
+	if !atomic.CompareAndSwapUint32(&w.ownerChannelId, 0, uint32(ch.id)) {
+		return fmt.Errorf("world '%s' is already owned by channel %d", w.Id(), w.GetOwnerChannelId())
+	}
+	return nil
+}
+
+// Removes the spatial channels and the entity channels of the world, and resets the world's SpatialController.
+// Called in the GLOBAL channel, when the owning SUBWORLD channel is being removed.
+func (w *SpatialWorld) reset() {
+	channelsToRemove := make([]common.ChannelId, 0)
+	allChannels.Range(func(chId common.ChannelId, ch *Channel) bool {
+		if (ch.channelType == channeldpb.ChannelType_SPATIAL && w.containsChannelId(chId)) ||
+			(ch.channelType == channeldpb.ChannelType_ENTITY && ch.spatialWorldId == w.Id()) {
+			channelsToRemove = append(channelsToRemove, chId)
+		}
+		return true
+	})
+	for _, chId := range channelsToRemove {
+		// Already in the GLOBAL channel, so the removal is handled directly.
+		handleRemoveChannel(MessageContext{
+			MsgType:   channeldpb.MessageType_REMOVE_CHANNEL,
+			Msg:       &channeldpb.RemoveChannelMessage{ChannelId: uint32(chId)},
+			Channel:   globalChannel,
+			ChannelId: uint32(GlobalChannelId),
+		})
+	}
+
+	ctl, err := w.createController()
+	if err != nil {
+		// Shouldn't happen as the config has been loaded successfully before.
+		rootLogger.Error("failed to reset the spatial controller of the world", zap.String("worldId", w.Id()), zap.Error(err))
+	} else {
+		w.controller.Store(ctl)
+	}
+	atomic.StoreUint32(&w.ownerChannelId, 0)
+	rootLogger.Info("reset the spatial world", zap.String("worldId", w.Id()), zap.Int("removedChannels", len(channelsToRemove)))
+}
+
+// Resets the worlds owned by the SUBWORLD channel. Called in the GLOBAL channel.
+func resetSpatialWorldsOwnedBy(ch *Channel) {
+	for _, world := range spatialWorlds {
+		if world.GetOwnerChannelId() == ch.id {
+			world.reset()
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package channeld
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/metaworking/channeld/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitSpatialWorlds(t *testing.T) {
+	InitLogs()
+	oldConfig := GlobalSettings.SpatialControllerConfig
+	oldController := spatialController
+	oldWorlds := spatialWorlds
+	defer func() {
+		GlobalSettings.SpatialControllerConfig = oldConfig
+		spatialController = oldController
+		spatialWorlds = oldWorlds
+		SetSpatialDampingProfiles(nil)
+	}()
+
+	cfgPath := filepath.Join(t.TempDir(), "spatial.json")
+	GlobalSettings.SpatialControllerConfig.Set(cfgPath)
+	writeConfig := func(worlds string) {
+		cfg := `{"Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1}, "Worlds": ` + worlds + `}`
+		assert.NoError(t, os.WriteFile(cfgPath, []byte(cfg), 0644))
+	}
+	start := GlobalSettings.SpatialChannelIdStart
+
+	writeConfig(`[
+		{"Id": "arena", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 4},
+		{"Id": "dungeon", "SpatialControllerType": "QuadtreeSpatialController", "Config": {"WorldWidth": 400, "WorldHeight": 200, "ServerCols": 2, "ServerRows": 1, "MinCellSize": 25, "MaxCellSize": 100}, "ChannelIdStart": 65796, "ChannelIdCount": 8}
+	]`)
+	assert.NoError(t, InitSpatialController())
+	defaultCtl := GetSpatialController()
+	assert.Same(t, defaultCtl, GetSpatialControllerOfWorld(DefaultSpatialWorldId))
+
+	arena := GetSpatialWorld("arena")
+	assert.NotNil(t, arena)
+	assert.NotNil(t, GetSpatialWorld("dungeon"))
+	assert.Nil(t, GetSpatialWorld("unknown"))
+	assert.Nil(t, GetSpatialControllerOfWorld("unknown"))
+
+	// The spatial channel IDs of the worlds don't overlap
+	chId, err := defaultCtl.GetChannelId(common.SpatialInfo{X: 15, Z: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, start+1, chId)
+	chId, err = arena.Controller().GetChannelId(common.SpatialInfo{X: 15, Z: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, common.ChannelId(65793), chId)
+
+	assert.Equal(t, "arena", GetSpatialWorldIdByChannelId(65795))
+	assert.Equal(t, "dungeon", GetSpatialWorldIdByChannelId(65796))
+	assert.Equal(t, DefaultSpatialWorldId, GetSpatialWorldIdByChannelId(start+1))
+	assert.Same(t, arena.Controller(), GetSpatialControllerByChannelId(65793))
+
+	// Reloading without 'Worlds' clears the named worlds
+	assert.NoError(t, os.WriteFile(cfgPath, []byte(`{"Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1}}`), 0644))
+	assert.NoError(t, InitSpatialController())
+	assert.Nil(t, GetSpatialWorld("arena"))
+	ctl := GetSpatialController()
+
+	invalidWorlds := []string{
+		// Empty ID
+		`[{"Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 1}]`,
+		// Duplicated ID
+		`[{"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 1},
+		  {"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65800, "ChannelIdCount": 1}]`,
+		// Overlapping with the default world
+		`[{"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65539, "ChannelIdCount": 1}]`,
+		// Overlapping with another world
+		`[{"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 4},
+		  {"Id": "b", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65795, "ChannelIdCount": 4}]`,
+		// Out of the spatial channel IDs
+		`[{"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 1, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 524287, "ChannelIdCount": 2}]`,
+		// Not enough channel IDs for the grids
+		`[{"Id": "a", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 2, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 3}]`,
+		// Invalid controller config
+		`[{"Id": "a", "Config": {"GridWidth": 0}, "ChannelIdStart": 65792, "ChannelIdCount": 4}]`,
+	}
+	for _, worlds := range invalidWorlds {
+		writeConfig(worlds)
+		assert.Error(t, InitSpatialController(), worlds)
+		// The previous controller is kept
+		assert.Same(t, ctl, GetSpatialController())
+		assert.Nil(t, GetSpatialWorld("a"))
+	}
+}
+
+func TestSpatialWorldOwnership(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	oldController := spatialController
+	oldWorlds := spatialWorlds
+	defer func() {
+		spatialController = oldController
+		spatialWorlds = oldWorlds
+	}()
+
+	spatialController = nil
+	worlds, err := loadSpatialWorlds([]byte(`[
+		{"Id": "arena", "Config": {"GridWidth": 10, "GridHeight": 10, "GridCols": 2, "GridRows": 1, "ServerCols": 1, "ServerRows": 1}, "ChannelIdStart": 65792, "ChannelIdCount": 2}
+	]`))
+	assert.NoError(t, err)
+	spatialWorlds = worlds
+	arena := GetSpatialWorld("arena")
+	arenaCtl := arena.Controller()
+
+	ownerCh := createChannelWithId(100, channeldpb.ChannelType_SUBWORLD, nil)
+	otherCh := createChannelWithId(101, channeldpb.ChannelType_SUBWORLD, nil)
+	defer func() {
+		otherCh.removing = 1
+	}()
+	globalCh := GetChannel(GlobalChannelId)
+
+	assert.Error(t, arena.setOwnerChannel(globalCh))
+	assert.NoError(t, arena.setOwnerChannel(ownerCh))
+	assert.Equal(t, ownerCh.id, arena.GetOwnerChannelId())
+	assert.Error(t, arena.setOwnerChannel(otherCh))
+
+	spatialCh := createChannelWithId(65793, channeldpb.ChannelType_SPATIAL, nil)
+	entityCh := createChannelInWorld(GlobalSettings.EntityChannelIdStart+100, channeldpb.ChannelType_ENTITY, nil, "arena")
+	defaultEntityCh := createChannelWithId(GlobalSettings.EntityChannelIdStart+101, channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		defaultEntityCh.removing = 1
+	}()
+	assert.Equal(t, "arena", entityCh.spatialWorldId)
+	assert.Equal(t, DefaultSpatialWorldId, defaultEntityCh.spatialWorldId)
+
+	// Removing the owning SUBWORLD channel resets the world
+	RemoveChannel(ownerCh)
+	assert.Nil(t, GetChannel(spatialCh.id))
+	assert.Nil(t, GetChannel(entityCh.id))
+	assert.NotNil(t, GetChannel(defaultEntityCh.id))
+	assert.Zero(t, arena.GetOwnerChannelId())
+	assert.NotSame(t, arenaCtl, arena.Controller())
+
+	// The world can be owned again
+	assert.NoError(t, arena.setOwnerChannel(otherCh))
+}
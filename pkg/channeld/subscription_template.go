@@ -0,0 +1,48 @@
+package channeld
+
+import "github.com/metaworking/channeld/pkg/channeldpb"
+
+// Returns the first template that matches the connection type and the auth tags, or nil if none matches.
+func matchSubscriptionTemplate(templates []*channeldpb.SubscriptionTemplate, connType channeldpb.ConnectionType, authTags map[string]string) *channeldpb.SubscriptionTemplate {
+	for _, template := range templates {
+		if template.ConnectionType != channeldpb.ConnectionType_NO_CONNECTION && template.ConnectionType != connType {
+			continue
+		}
+		matched := true
+		for key, value := range template.AuthTags {
+			if tag, exists := authTags[key]; !exists || tag != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return template
+		}
+	}
+	return nil
+}
+
+// Subscribes the connection to the well-known entity channel with the options of the matching template.
+// If no template matches, the client connection is subscribed with the default options, and the server connection is ignored.
+// Returns true if the connection is newly subscribed.
+func subscribeToWellKnownEntity(ch *Channel, conn ConnectionInChannel, authTags map[string]string,
+	templates []*channeldpb.SubscriptionTemplate, defaultOptions *channeldpb.ChannelSubscriptionOptions) bool {
+	subOptions := defaultOptions
+	if template := matchSubscriptionTemplate(templates, conn.GetConnectionType(), authTags); template != nil {
+		if template.Exclude {
+			return false
+		}
+		if template.SubOptions != nil {
+			subOptions = template.SubOptions
+		}
+	} else if conn.GetConnectionType() == channeldpb.ConnectionType_SERVER {
+		// Ignore the well-known entity for server
+		return false
+	}
+
+	cs, shouldSend := conn.SubscribeToChannel(ch, subOptions)
+	if shouldSend {
+		conn.sendSubscribed(MessageContext{}, ch, conn, 0, &cs.options)
+	}
+	return shouldSend
+}
@@ -0,0 +1,83 @@
+package channeld
+
+import (
+	"testing"
+
+	"github.com/metaworking/channeld/pkg/channeldpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type typedTestConnection struct {
+	*testConnection
+	connType channeldpb.ConnectionType
+}
+
+func (c *typedTestConnection) GetConnectionType() channeldpb.ConnectionType {
+	return c.connType
+}
+
+func TestMatchSubscriptionTemplate(t *testing.T) {
+	redTeam := &channeldpb.SubscriptionTemplate{
+		ConnectionType: channeldpb.ConnectionType_CLIENT,
+		AuthTags:       map[string]string{"team": "red"},
+		SubOptions:     &channeldpb.ChannelSubscriptionOptions{FanOutIntervalMs: Pointer(uint32(50))},
+	}
+	blueTeam := &channeldpb.SubscriptionTemplate{
+		ConnectionType: channeldpb.ConnectionType_CLIENT,
+		AuthTags:       map[string]string{"team": "blue"},
+		Exclude:        true,
+	}
+	anyServer := &channeldpb.SubscriptionTemplate{
+		ConnectionType: channeldpb.ConnectionType_SERVER,
+	}
+	templates := []*channeldpb.SubscriptionTemplate{redTeam, blueTeam, anyServer}
+
+	assert.Same(t, redTeam, matchSubscriptionTemplate(templates, channeldpb.ConnectionType_CLIENT, map[string]string{"team": "red", "role": "admin"}))
+	assert.Same(t, blueTeam, matchSubscriptionTemplate(templates, channeldpb.ConnectionType_CLIENT, map[string]string{"team": "blue"}))
+	assert.Same(t, anyServer, matchSubscriptionTemplate(templates, channeldpb.ConnectionType_SERVER, nil))
+	assert.Nil(t, matchSubscriptionTemplate(templates, channeldpb.ConnectionType_CLIENT, nil))
+	assert.Same(t, anyServer, matchSubscriptionTemplate(templates, channeldpb.ConnectionType_SERVER, map[string]string{"team": "green"}))
+
+	// The first match wins
+	anyConn := &channeldpb.SubscriptionTemplate{}
+	assert.Same(t, anyConn, matchSubscriptionTemplate([]*channeldpb.SubscriptionTemplate{anyConn, redTeam}, channeldpb.ConnectionType_CLIENT, map[string]string{"team": "red"}))
+	assert.Nil(t, matchSubscriptionTemplate(nil, channeldpb.ConnectionType_CLIENT, nil))
+}
+
+func TestSubscribeToWellKnownEntity(t *testing.T) {
+	InitLogs()
+	InitChannels()
+
+	ch := createChannelWithId(GlobalSettings.EntityChannelIdStart+501, channeldpb.ChannelType_ENTITY, nil)
+	defer func() {
+		ch.removing = 1
+		allChannels.Delete(ch.id)
+	}()
+
+	templates := []*channeldpb.SubscriptionTemplate{
+		{AuthTags: map[string]string{"team": "blue"}, Exclude: true},
+		{ConnectionType: channeldpb.ConnectionType_SERVER, AuthTags: map[string]string{"role": "observer"}},
+	}
+	newConn := func(connType channeldpb.ConnectionType) *typedTestConnection {
+		return &typedTestConnection{testConnection: createTestConnection(), connType: connType}
+	}
+
+	// The client connection is subscribed with the default options if no template matches
+	client := newConn(channeldpb.ConnectionType_CLIENT)
+	subscribeToWellKnownEntity(ch, client, map[string]string{"team": "red"}, templates, nil)
+	assert.Contains(t, client.subscribedChannels, ch.id)
+
+	// Excluded
+	client = newConn(channeldpb.ConnectionType_CLIENT)
+	subscribeToWellKnownEntity(ch, client, map[string]string{"team": "blue"}, templates, nil)
+	assert.NotContains(t, client.subscribedChannels, ch.id)
+
+	// The server connection is ignored if no template matches
+	server := newConn(channeldpb.ConnectionType_SERVER)
+	subscribeToWellKnownEntity(ch, server, nil, templates, nil)
+	assert.NotContains(t, server.subscribedChannels, ch.id)
+
+	server = newConn(channeldpb.ConnectionType_SERVER)
+	subscribeToWellKnownEntity(ch, server, map[string]string{"role": "observer"}, templates, nil)
+	assert.Contains(t, server.subscribedChannels, ch.id)
+}
@@ -119,3 +119,13 @@ func CopyArray[FROM UintId, TO UintId](arr []FROM) []TO {
 	}
 	return result
 }
+
+// Returns true if the array contains the value
+func Contains[T comparable](arr []T, val T) bool {
+	for _, v := range arr {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
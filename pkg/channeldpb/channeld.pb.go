@@ -40,7 +40,7 @@ const (
 	BroadcastType_ALL_BUT_CLIENT BroadcastType = 16
 	// Broadcast the message to all server connections in the channel, the owner excluded.
 	BroadcastType_ALL_BUT_SERVER BroadcastType = 32
-	// Broadcast the message to all the connections in all the adjacent(3x3) spatial channels. Ignored if the target channel is not a spatial channel.
+	// Broadcast the message to all the connections in all the adjacent(3x3 for the 2D grids, 3x3x3 for the 3D grids) spatial channels. Ignored if the target channel is not a spatial channel.
 	// To ignore the center spatial channel, use ADJACENT_CHANNELS | ALL_BUT_OWNER; to ignore the sender(spatial server), use ADJACENT_CHANNELS | ALL_BUT_SENDER.
 	BroadcastType_ADJACENT_CHANNELS BroadcastType = 64
 )
@@ -262,6 +262,22 @@ const (
 	MessageType_ENTITY_GROUP_REMOVE MessageType = 17
 	// Used by @SpatialChannelsReadyMessage
 	MessageType_SPATIAL_CHANNELS_READY MessageType = 18
+	// Used by both @GetChannelDataMessage and @GetChannelDataResultMessage
+	MessageType_GET_CHANNEL_DATA MessageType = 19
+	// Used by both @FetchChannelDataMessage and @FetchChannelDataResultMessage
+	MessageType_FETCH_CHANNEL_DATA MessageType = 20
+	// Used by @ChannelDataUpdateRejectedMessage
+	MessageType_CHANNEL_DATA_UPDATE_REJECTED MessageType = 21
+	// Used by @ChannelOwnerChangedMessage
+	MessageType_CHANNEL_OWNER_CHANGED MessageType = 22
+	// Used by both @QuerySpatialEntitiesMessage and @QuerySpatialEntitiesResultMessage
+	MessageType_QUERY_SPATIAL_ENTITIES MessageType = 23
+	// Used by both @AllocateEntityIdsMessage and @AllocateEntityIdsResultMessage
+	MessageType_ALLOCATE_ENTITY_IDS MessageType = 24
+	// Used by @SetEntityDormancyMessage
+	MessageType_SET_ENTITY_DORMANCY MessageType = 25
+	// Used by @AccessDeniedMessage
+	MessageType_ACCESS_DENIED MessageType = 26
 	// Used by @DebugGetSpatialRegionsMessage
 	MessageType_DEBUG_GET_SPATIAL_REGIONS MessageType = 99
 	// Start of any user-space defined message
@@ -289,30 +305,46 @@ var (
 		16:  "ENTITY_GROUP_ADD",
 		17:  "ENTITY_GROUP_REMOVE",
 		18:  "SPATIAL_CHANNELS_READY",
+		19:  "GET_CHANNEL_DATA",
+		20:  "FETCH_CHANNEL_DATA",
+		21:  "CHANNEL_DATA_UPDATE_REJECTED",
+		22:  "CHANNEL_OWNER_CHANGED",
+		23:  "QUERY_SPATIAL_ENTITIES",
+		24:  "ALLOCATE_ENTITY_IDS",
+		25:  "SET_ENTITY_DORMANCY",
+		26:  "ACCESS_DENIED",
 		99:  "DEBUG_GET_SPATIAL_REGIONS",
 		100: "USER_SPACE_START",
 	}
 	MessageType_value = map[string]int32{
-		"INVALID":                   0,
-		"AUTH":                      1,
-		"CREATE_CHANNEL":            3,
-		"REMOVE_CHANNEL":            4,
-		"LIST_CHANNEL":              5,
-		"SUB_TO_CHANNEL":            6,
-		"UNSUB_FROM_CHANNEL":        7,
-		"CHANNEL_DATA_UPDATE":       8,
-		"DISCONNECT":                9,
-		"CREATE_SPATIAL_CHANNEL":    10,
-		"QUERY_SPATIAL_CHANNEL":     11,
-		"CHANNEL_DATA_HANDOVER":     12,
-		"SPATIAL_REGIONS_UPDATE":    13,
-		"UPDATE_SPATIAL_INTEREST":   14,
-		"CREATE_ENTITY_CHANNEL":     15,
-		"ENTITY_GROUP_ADD":          16,
-		"ENTITY_GROUP_REMOVE":       17,
-		"SPATIAL_CHANNELS_READY":    18,
-		"DEBUG_GET_SPATIAL_REGIONS": 99,
-		"USER_SPACE_START":          100,
+		"INVALID":                      0,
+		"AUTH":                         1,
+		"CREATE_CHANNEL":               3,
+		"REMOVE_CHANNEL":               4,
+		"LIST_CHANNEL":                 5,
+		"SUB_TO_CHANNEL":               6,
+		"UNSUB_FROM_CHANNEL":           7,
+		"CHANNEL_DATA_UPDATE":          8,
+		"DISCONNECT":                   9,
+		"CREATE_SPATIAL_CHANNEL":       10,
+		"QUERY_SPATIAL_CHANNEL":        11,
+		"CHANNEL_DATA_HANDOVER":        12,
+		"SPATIAL_REGIONS_UPDATE":       13,
+		"UPDATE_SPATIAL_INTEREST":      14,
+		"CREATE_ENTITY_CHANNEL":        15,
+		"ENTITY_GROUP_ADD":             16,
+		"ENTITY_GROUP_REMOVE":          17,
+		"SPATIAL_CHANNELS_READY":       18,
+		"GET_CHANNEL_DATA":             19,
+		"FETCH_CHANNEL_DATA":           20,
+		"CHANNEL_DATA_UPDATE_REJECTED": 21,
+		"CHANNEL_OWNER_CHANGED":        22,
+		"QUERY_SPATIAL_ENTITIES":       23,
+		"ALLOCATE_ENTITY_IDS":          24,
+		"SET_ENTITY_DORMANCY":          25,
+		"ACCESS_DENIED":                26,
+		"DEBUG_GET_SPATIAL_REGIONS":    99,
+		"USER_SPACE_START":             100,
 	}
 )
 
@@ -390,6 +422,56 @@ func (CompressionType) EnumDescriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{4}
 }
 
+// The encoding of the packets, which is decided by the connection's first received packet. The following packets in both directions use the same encoding.
+type EncodingType int32
+
+const (
+	// The packets and messages are encoded in Protobuf's binary format. This is the default encoding.
+	EncodingType_PROTOBUF EncodingType = 0
+	// The packets and messages are encoded in Protobuf's JSON format. The packet body should start with '{'.
+	// The google.protobuf.Any fields (e.g. the channel data) are transcoded with the message types registered in channeld.
+	EncodingType_JSON EncodingType = 1
+)
+
+// Enum value maps for EncodingType.
+var (
+	EncodingType_name = map[int32]string{
+		0: "PROTOBUF",
+		1: "JSON",
+	}
+	EncodingType_value = map[string]int32{
+		"PROTOBUF": 0,
+		"JSON":     1,
+	}
+)
+
+func (x EncodingType) Enum() *EncodingType {
+	p := new(EncodingType)
+	*p = x
+	return p
+}
+
+func (x EncodingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EncodingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_channeld_proto_enumTypes[5].Descriptor()
+}
+
+func (EncodingType) Type() protoreflect.EnumType {
+	return &file_channeld_proto_enumTypes[5]
+}
+
+func (x EncodingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EncodingType.Descriptor instead.
+func (EncodingType) EnumDescriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{5}
+}
+
 type ChannelDataAccess int32
 
 const (
@@ -423,11 +505,11 @@ func (x ChannelDataAccess) String() string {
 }
 
 func (ChannelDataAccess) Descriptor() protoreflect.EnumDescriptor {
-	return file_channeld_proto_enumTypes[5].Descriptor()
+	return file_channeld_proto_enumTypes[6].Descriptor()
 }
 
 func (ChannelDataAccess) Type() protoreflect.EnumType {
-	return &file_channeld_proto_enumTypes[5]
+	return &file_channeld_proto_enumTypes[6]
 }
 
 func (x ChannelDataAccess) Number() protoreflect.EnumNumber {
@@ -436,7 +518,59 @@ func (x ChannelDataAccess) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ChannelDataAccess.Descriptor instead.
 func (ChannelDataAccess) EnumDescriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{5}
+	return file_channeld_proto_rawDescGZIP(), []int{6}
+}
+
+type ConflictResolutionPolicy int32
+
+const (
+	// The updates are merged in the arrival order. This is the default behavior.
+	ConflictResolutionPolicy_ARRIVAL_ORDER ConflictResolutionPolicy = 0
+	// The update of a field is rejected if its @ChannelDataUpdateMessage.timestamp is older than the last accepted update of the field.
+	ConflictResolutionPolicy_LAST_WRITER_WINS ConflictResolutionPolicy = 1
+	// Once a field is updated by the channel owner, the updates of the field from other connections are rejected.
+	ConflictResolutionPolicy_OWNER_WINS ConflictResolutionPolicy = 2
+)
+
+// Enum value maps for ConflictResolutionPolicy.
+var (
+	ConflictResolutionPolicy_name = map[int32]string{
+		0: "ARRIVAL_ORDER",
+		1: "LAST_WRITER_WINS",
+		2: "OWNER_WINS",
+	}
+	ConflictResolutionPolicy_value = map[string]int32{
+		"ARRIVAL_ORDER":    0,
+		"LAST_WRITER_WINS": 1,
+		"OWNER_WINS":       2,
+	}
+)
+
+func (x ConflictResolutionPolicy) Enum() *ConflictResolutionPolicy {
+	p := new(ConflictResolutionPolicy)
+	*p = x
+	return p
+}
+
+func (x ConflictResolutionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConflictResolutionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_channeld_proto_enumTypes[7].Descriptor()
+}
+
+func (ConflictResolutionPolicy) Type() protoreflect.EnumType {
+	return &file_channeld_proto_enumTypes[7]
+}
+
+func (x ConflictResolutionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConflictResolutionPolicy.Descriptor instead.
+func (ConflictResolutionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{7}
 }
 
 type EntityGroupType int32
@@ -469,11 +603,11 @@ func (x EntityGroupType) String() string {
 }
 
 func (EntityGroupType) Descriptor() protoreflect.EnumDescriptor {
-	return file_channeld_proto_enumTypes[6].Descriptor()
+	return file_channeld_proto_enumTypes[8].Descriptor()
 }
 
 func (EntityGroupType) Type() protoreflect.EnumType {
-	return &file_channeld_proto_enumTypes[6]
+	return &file_channeld_proto_enumTypes[8]
 }
 
 func (x EntityGroupType) Number() protoreflect.EnumNumber {
@@ -482,7 +616,7 @@ func (x EntityGroupType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EntityGroupType.Descriptor instead.
 func (EntityGroupType) EnumDescriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{6}
+	return file_channeld_proto_rawDescGZIP(), []int{8}
 }
 
 type AuthResultMessage_AuthResult int32
@@ -518,11 +652,11 @@ func (x AuthResultMessage_AuthResult) String() string {
 }
 
 func (AuthResultMessage_AuthResult) Descriptor() protoreflect.EnumDescriptor {
-	return file_channeld_proto_enumTypes[7].Descriptor()
+	return file_channeld_proto_enumTypes[9].Descriptor()
 }
 
 func (AuthResultMessage_AuthResult) Type() protoreflect.EnumType {
-	return &file_channeld_proto_enumTypes[7]
+	return &file_channeld_proto_enumTypes[9]
 }
 
 func (x AuthResultMessage_AuthResult) Number() protoreflect.EnumNumber {
@@ -534,6 +668,67 @@ func (AuthResultMessage_AuthResult) EnumDescriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type AccessDeniedMessage_Reason int32
+
+const (
+	AccessDeniedMessage_UNKNOWN AccessDeniedMessage_Reason = 0
+	// The access level of the channel type is None.
+	AccessDeniedMessage_NO_ACCESS                   AccessDeniedMessage_Reason = 1
+	AccessDeniedMessage_OWNER_ONLY                  AccessDeniedMessage_Reason = 2
+	AccessDeniedMessage_OWNER_AND_GLOBAL_OWNER_ONLY AccessDeniedMessage_Reason = 3
+	// Denied by a role-based ACL rule.
+	AccessDeniedMessage_ROLE_DENIED AccessDeniedMessage_Reason = 4
+	// The subscription doesn't have the write access to the channel data.
+	AccessDeniedMessage_NO_WRITE_ACCESS AccessDeniedMessage_Reason = 5
+)
+
+// Enum value maps for AccessDeniedMessage_Reason.
+var (
+	AccessDeniedMessage_Reason_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "NO_ACCESS",
+		2: "OWNER_ONLY",
+		3: "OWNER_AND_GLOBAL_OWNER_ONLY",
+		4: "ROLE_DENIED",
+		5: "NO_WRITE_ACCESS",
+	}
+	AccessDeniedMessage_Reason_value = map[string]int32{
+		"UNKNOWN":                     0,
+		"NO_ACCESS":                   1,
+		"OWNER_ONLY":                  2,
+		"OWNER_AND_GLOBAL_OWNER_ONLY": 3,
+		"ROLE_DENIED":                 4,
+		"NO_WRITE_ACCESS":             5,
+	}
+)
+
+func (x AccessDeniedMessage_Reason) Enum() *AccessDeniedMessage_Reason {
+	p := new(AccessDeniedMessage_Reason)
+	*p = x
+	return p
+}
+
+func (x AccessDeniedMessage_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessDeniedMessage_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_channeld_proto_enumTypes[10].Descriptor()
+}
+
+func (AccessDeniedMessage_Reason) Type() protoreflect.EnumType {
+	return &file_channeld_proto_enumTypes[10]
+}
+
+func (x AccessDeniedMessage_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessDeniedMessage_Reason.Descriptor instead.
+func (AccessDeniedMessage_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{46, 0}
+}
+
 // The data packet that is sent between the endpoints. A packet can have multiple messages in the payload in one trip to improve the efficiency.
 type Packet struct {
 	state         protoimpl.MessageState
@@ -969,6 +1164,15 @@ type ChannelDataMergeOptions struct {
 	TruncateTop bool `protobuf:"varint,3,opt,name=truncateTop,proto3" json:"truncateTop,omitempty"`
 	// If true, the merge method will remove any map entry that has removed=true in its value.
 	ShouldCheckRemovableMapField bool `protobuf:"varint,4,opt,name=shouldCheckRemovableMapField,proto3" json:"shouldCheckRemovableMapField,omitempty"`
+	// How the conflicts between the updates from different connections are resolved. The conflicts are checked per top-level field of the channel data.
+	ConflictPolicy ConflictResolutionPolicy `protobuf:"varint,5,opt,name=conflictPolicy,proto3,enum=channeldpb.ConflictResolutionPolicy" json:"conflictPolicy,omitempty"`
+	// The names of the top-level numeric fields that are merged as CRDT counters: the value in the update message is the delta to add, instead of the new value.
+	// The conflict policy doesn't apply to the counter fields.
+	// Declare the counter fields as optional, so the subscribers can receive the update when a counter reaches zero.
+	CounterFields []string `protobuf:"bytes,6,rep,name=counterFields,proto3" json:"counterFields,omitempty"`
+	// The names of the top-level list fields that are merged as CRDT sets (add-wins): only the elements that don't exist in the channel data are added.
+	// The conflict policy doesn't apply to the set fields.
+	SetFields []string `protobuf:"bytes,7,rep,name=setFields,proto3" json:"setFields,omitempty"`
 }
 
 func (x *ChannelDataMergeOptions) Reset() {
@@ -1031,6 +1235,27 @@ func (x *ChannelDataMergeOptions) GetShouldCheckRemovableMapField() bool {
 	return false
 }
 
+func (x *ChannelDataMergeOptions) GetConflictPolicy() ConflictResolutionPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return ConflictResolutionPolicy_ARRIVAL_ORDER
+}
+
+func (x *ChannelDataMergeOptions) GetCounterFields() []string {
+	if x != nil {
+		return x.CounterFields
+	}
+	return nil
+}
+
+func (x *ChannelDataMergeOptions) GetSetFields() []string {
+	if x != nil {
+		return x.SetFields
+	}
+	return nil
+}
+
 // The message should have channelId = 0 in order to be handled.
 // Response: @CreateChannelResultMessage, if the MessageType is CREATE_CHANNEL and the channelType is not SPATIAL. The GLOBAL channel owner will also receive this message.
 // Response: @CreateSpatialChannelsResultMessage, if the MessageType is CREATE_SPATIAL_CHANNEL and the channelType is SPATIAL. The GLOBAL channel owner will also receive this message.
@@ -1045,6 +1270,11 @@ type CreateChannelMessage struct {
 	SubOptions   *ChannelSubscriptionOptions `protobuf:"bytes,3,opt,name=subOptions,proto3" json:"subOptions,omitempty"`
 	Data         *anypb.Any                  `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	MergeOptions *ChannelDataMergeOptions    `protobuf:"bytes,5,opt,name=mergeOptions,proto3" json:"mergeOptions,omitempty"`
+	// Optional. For the SPATIAL channels, the spatial world that the channels are created in. Empty = the default world.
+	// For the SUBWORLD channel, the spatial world that the channel owns. When the SUBWORLD channel is removed, the spatial channels and the entity channels of the world are removed as well.
+	WorldId string `protobuf:"bytes,6,opt,name=worldId,proto3" json:"worldId,omitempty"`
+	// Optional. Overrides the role-based ACL rules of the channel type in the settings.
+	RoleACL *ChannelRoleACL `protobuf:"bytes,7,opt,name=roleACL,proto3" json:"roleACL,omitempty"`
 }
 
 func (x *CreateChannelMessage) Reset() {
@@ -1114,6 +1344,20 @@ func (x *CreateChannelMessage) GetMergeOptions() *ChannelDataMergeOptions {
 	return nil
 }
 
+func (x *CreateChannelMessage) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+func (x *CreateChannelMessage) GetRoleACL() *ChannelRoleACL {
+	if x != nil {
+		return x.RoleACL
+	}
+	return nil
+}
+
 type CreateChannelResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1595,6 +1839,9 @@ type ChannelDataUpdateMessage struct {
 	// In a server-authoratative system (which means the @ChannelDataUpdateMessage will only be sent by server), the servers need to send this field to channeld.
 	// If the sender is a client, this field will be ignored.
 	ContextConnId uint32 `protobuf:"varint,2,opt,name=contextConnId,proto3" json:"contextConnId,omitempty"`
+	// The time (Unix timestamp in milliseconds) when the sender made the update. Used by the LAST_WRITER_WINS conflict policy.
+	// If not set, the time when channeld received the message will be used. The time ahead of the receive time by more than 500ms (MaxUpdateTimestampSkewMs) is clamped.
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (x *ChannelDataUpdateMessage) Reset() {
@@ -1643,20 +1890,29 @@ func (x *ChannelDataUpdateMessage) GetContextConnId() uint32 {
 	return 0
 }
 
-// Disconnect another connection from channeld.
-// This message should only be sent by the server connection in a server-authoratative environment.
-// The message should have channelId = 0 in order to be handled.
+func (x *ChannelDataUpdateMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Sent to the connection if any field of its @ChannelDataUpdateMessage is rejected by the conflict policy (see @ChannelDataMergeOptions.conflictPolicy).
+// The accepted fields of the update message are still merged into the channel data.
 // Response: no.
-type DisconnectMessage struct {
+type ChannelDataUpdateRejectedMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConnId uint32 `protobuf:"varint,1,opt,name=connId,proto3" json:"connId,omitempty"`
+	// The names of the top-level fields of the channel data that are rejected.
+	RejectedFields []string `protobuf:"bytes,1,rep,name=rejectedFields,proto3" json:"rejectedFields,omitempty"`
+	// The current values of the rejected fields in the channel data, so the sender can correct its local state.
+	CurrentData *anypb.Any `protobuf:"bytes,2,opt,name=currentData,proto3" json:"currentData,omitempty"`
 }
 
-func (x *DisconnectMessage) Reset() {
-	*x = DisconnectMessage{}
+func (x *ChannelDataUpdateRejectedMessage) Reset() {
+	*x = ChannelDataUpdateRejectedMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1664,13 +1920,13 @@ func (x *DisconnectMessage) Reset() {
 	}
 }
 
-func (x *DisconnectMessage) String() string {
+func (x *ChannelDataUpdateRejectedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectMessage) ProtoMessage() {}
+func (*ChannelDataUpdateRejectedMessage) ProtoMessage() {}
 
-func (x *DisconnectMessage) ProtoReflect() protoreflect.Message {
+func (x *ChannelDataUpdateRejectedMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1682,31 +1938,39 @@ func (x *DisconnectMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectMessage.ProtoReflect.Descriptor instead.
-func (*DisconnectMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChannelDataUpdateRejectedMessage.ProtoReflect.Descriptor instead.
+func (*ChannelDataUpdateRejectedMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *DisconnectMessage) GetConnId() uint32 {
+func (x *ChannelDataUpdateRejectedMessage) GetRejectedFields() []string {
 	if x != nil {
-		return x.ConnId
+		return x.RejectedFields
 	}
-	return 0
+	return nil
 }
 
-// Left-handed coordinate system with Y-up rule.
-type SpatialInfo struct {
+func (x *ChannelDataUpdateRejectedMessage) GetCurrentData() *anypb.Any {
+	if x != nil {
+		return x.CurrentData
+	}
+	return nil
+}
+
+// Disconnect another connection from channeld.
+// This message should only be sent by the server connection in a server-authoratative environment.
+// The message should have channelId = 0 in order to be handled.
+// Response: no.
+type DisconnectMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
-	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
-	Z float64 `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
+	ConnId uint32 `protobuf:"varint,1,opt,name=connId,proto3" json:"connId,omitempty"`
 }
 
-func (x *SpatialInfo) Reset() {
-	*x = SpatialInfo{}
+func (x *DisconnectMessage) Reset() {
+	*x = DisconnectMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1714,13 +1978,13 @@ func (x *SpatialInfo) Reset() {
 	}
 }
 
-func (x *SpatialInfo) String() string {
+func (x *DisconnectMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpatialInfo) ProtoMessage() {}
+func (*DisconnectMessage) ProtoMessage() {}
 
-func (x *SpatialInfo) ProtoReflect() protoreflect.Message {
+func (x *DisconnectMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1732,44 +1996,37 @@ func (x *SpatialInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpatialInfo.ProtoReflect.Descriptor instead.
-func (*SpatialInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisconnectMessage.ProtoReflect.Descriptor instead.
+func (*DisconnectMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SpatialInfo) GetX() float64 {
-	if x != nil {
-		return x.X
-	}
-	return 0
-}
-
-func (x *SpatialInfo) GetY() float64 {
-	if x != nil {
-		return x.Y
-	}
-	return 0
-}
-
-func (x *SpatialInfo) GetZ() float64 {
+func (x *DisconnectMessage) GetConnId() uint32 {
 	if x != nil {
-		return x.Z
+		return x.ConnId
 	}
 	return 0
 }
 
-type CreateSpatialChannelsResultMessage struct {
+// Get the current or a historical state of the channel data, without subscribing to the channel.
+// The sender should have the access to sub to the channel (see ChannelSettings.ACLSettings.Sub), or be the channel owner or the GLOBAL channel owner - the same as @FetchChannelDataMessage.
+// The historical states are only available if ChannelSettings.DataHistorySize of the channel type is greater than 0.
+// Response: @GetChannelDataResultMessage
+type GetChannelDataMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SpatialChannelId []uint32 `protobuf:"varint,1,rep,packed,name=spatialChannelId,proto3" json:"spatialChannelId,omitempty"`
-	Metadata         string   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	OwnerConnId      uint32   `protobuf:"varint,3,opt,name=ownerConnId,proto3" json:"ownerConnId,omitempty"`
+	// The time (Unix timestamp in milliseconds) of the state to get.
+	// If not specified, the state at the time of the msgIndex, or the current state will be returned.
+	Timestamp *int64 `protobuf:"varint,1,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	// The index of the last update message that the state to get includes. The index starts from 0 (the initial state) and increases by 1 for every @ChannelDataUpdateMessage.
+	// Ignored if the timestamp is specified.
+	MsgIndex *uint64 `protobuf:"varint,2,opt,name=msgIndex,proto3,oneof" json:"msgIndex,omitempty"`
 }
 
-func (x *CreateSpatialChannelsResultMessage) Reset() {
-	*x = CreateSpatialChannelsResultMessage{}
+func (x *GetChannelDataMessage) Reset() {
+	*x = GetChannelDataMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1777,13 +2034,13 @@ func (x *CreateSpatialChannelsResultMessage) Reset() {
 	}
 }
 
-func (x *CreateSpatialChannelsResultMessage) String() string {
+func (x *GetChannelDataMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSpatialChannelsResultMessage) ProtoMessage() {}
+func (*GetChannelDataMessage) ProtoMessage() {}
 
-func (x *CreateSpatialChannelsResultMessage) ProtoReflect() protoreflect.Message {
+func (x *GetChannelDataMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1795,44 +2052,41 @@ func (x *CreateSpatialChannelsResultMessage) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSpatialChannelsResultMessage.ProtoReflect.Descriptor instead.
-func (*CreateSpatialChannelsResultMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetChannelDataMessage.ProtoReflect.Descriptor instead.
+func (*GetChannelDataMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CreateSpatialChannelsResultMessage) GetSpatialChannelId() []uint32 {
-	if x != nil {
-		return x.SpatialChannelId
-	}
-	return nil
-}
-
-func (x *CreateSpatialChannelsResultMessage) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
+func (x *GetChannelDataMessage) GetTimestamp() int64 {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateSpatialChannelsResultMessage) GetOwnerConnId() uint32 {
-	if x != nil {
-		return x.OwnerConnId
+func (x *GetChannelDataMessage) GetMsgIndex() uint64 {
+	if x != nil && x.MsgIndex != nil {
+		return *x.MsgIndex
 	}
 	return 0
 }
 
-// The message should have channelId = 0 in order to be handled.
-// Response: @QuerySpatialChannelResultMessage
-type QuerySpatialChannelMessage struct {
+type GetChannelDataResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SpatialInfo []*SpatialInfo `protobuf:"bytes,1,rep,name=spatialInfo,proto3" json:"spatialInfo,omitempty"`
+	ChannelId uint32 `protobuf:"varint,1,opt,name=channelId,proto3" json:"channelId,omitempty"`
+	// Not set if the data can't be got, e.g. the sender has no access, or the state is not in the history.
+	Data *anypb.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The index of the last update message that the data includes.
+	MsgIndex uint64 `protobuf:"varint,3,opt,name=msgIndex,proto3" json:"msgIndex,omitempty"`
+	// The time (Unix timestamp in milliseconds) of the state. For a historical state, it's the time when the last update message that the data includes arrived.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (x *QuerySpatialChannelMessage) Reset() {
-	*x = QuerySpatialChannelMessage{}
+func (x *GetChannelDataResultMessage) Reset() {
+	*x = GetChannelDataResultMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1840,13 +2094,13 @@ func (x *QuerySpatialChannelMessage) Reset() {
 	}
 }
 
-func (x *QuerySpatialChannelMessage) String() string {
+func (x *GetChannelDataResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuerySpatialChannelMessage) ProtoMessage() {}
+func (*GetChannelDataResultMessage) ProtoMessage() {}
 
-func (x *QuerySpatialChannelMessage) ProtoReflect() protoreflect.Message {
+func (x *GetChannelDataResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1858,28 +2112,55 @@ func (x *QuerySpatialChannelMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuerySpatialChannelMessage.ProtoReflect.Descriptor instead.
-func (*QuerySpatialChannelMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetChannelDataResultMessage.ProtoReflect.Descriptor instead.
+func (*GetChannelDataResultMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *QuerySpatialChannelMessage) GetSpatialInfo() []*SpatialInfo {
+func (x *GetChannelDataResultMessage) GetChannelId() uint32 {
 	if x != nil {
-		return x.SpatialInfo
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *GetChannelDataResultMessage) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type QuerySpatialChannelResultMessage struct {
+func (x *GetChannelDataResultMessage) GetMsgIndex() uint64 {
+	if x != nil {
+		return x.MsgIndex
+	}
+	return 0
+}
+
+func (x *GetChannelDataResultMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Get a one-shot snapshot of the data of one or more channels, without subscribing to the channels.
+// The sender should have the access to sub to the channel (see ChannelSettings.ACLSettings.Sub), or be the channel owner or the GLOBAL channel owner.
+// Response: @FetchChannelDataResultMessage, after all the channels have been fetched.
+type FetchChannelDataMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ChannelId []uint32 `protobuf:"varint,1,rep,packed,name=channelId,proto3" json:"channelId,omitempty"`
+	// The channels to fetch the data from. If empty, the channel of the message (the channelId in the MessagePack) will be used.
+	ChannelIds []uint32 `protobuf:"varint,1,rep,packed,name=channelIds,proto3" json:"channelIds,omitempty"`
+	// Only the fields in the masks will be included in the data. If empty, all the fields will be included.
+	DataFieldMasks []string `protobuf:"bytes,2,rep,name=dataFieldMasks,proto3" json:"dataFieldMasks,omitempty"`
 }
 
-func (x *QuerySpatialChannelResultMessage) Reset() {
-	*x = QuerySpatialChannelResultMessage{}
+func (x *FetchChannelDataMessage) Reset() {
+	*x = FetchChannelDataMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1887,13 +2168,13 @@ func (x *QuerySpatialChannelResultMessage) Reset() {
 	}
 }
 
-func (x *QuerySpatialChannelResultMessage) String() string {
+func (x *FetchChannelDataMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuerySpatialChannelResultMessage) ProtoMessage() {}
+func (*FetchChannelDataMessage) ProtoMessage() {}
 
-func (x *QuerySpatialChannelResultMessage) ProtoReflect() protoreflect.Message {
+func (x *FetchChannelDataMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1905,30 +2186,37 @@ func (x *QuerySpatialChannelResultMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuerySpatialChannelResultMessage.ProtoReflect.Descriptor instead.
-func (*QuerySpatialChannelResultMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use FetchChannelDataMessage.ProtoReflect.Descriptor instead.
+func (*FetchChannelDataMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *QuerySpatialChannelResultMessage) GetChannelId() []uint32 {
+func (x *FetchChannelDataMessage) GetChannelIds() []uint32 {
 	if x != nil {
-		return x.ChannelId
+		return x.ChannelIds
 	}
 	return nil
 }
 
-// Indicates that all the spatial channels are created by the spatial servers, so the servers can continue further initialization.
-type SpatialChannelsReadyMessage struct {
+func (x *FetchChannelDataMessage) GetDataFieldMasks() []string {
+	if x != nil {
+		return x.DataFieldMasks
+	}
+	return nil
+}
+
+type FetchChannelDataResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerIndex uint32 `protobuf:"varint,1,opt,name=serverIndex,proto3" json:"serverIndex,omitempty"`
-	ServerCount uint32 `protobuf:"varint,2,opt,name=serverCount,proto3" json:"serverCount,omitempty"`
+	Results []*FetchChannelDataResultMessage_ChannelDataResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// The channels that don't exist, have no data, or the sender doesn't have the access to.
+	FailedChannelIds []uint32 `protobuf:"varint,2,rep,packed,name=failedChannelIds,proto3" json:"failedChannelIds,omitempty"`
 }
 
-func (x *SpatialChannelsReadyMessage) Reset() {
-	*x = SpatialChannelsReadyMessage{}
+func (x *FetchChannelDataResultMessage) Reset() {
+	*x = FetchChannelDataResultMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1936,13 +2224,13 @@ func (x *SpatialChannelsReadyMessage) Reset() {
 	}
 }
 
-func (x *SpatialChannelsReadyMessage) String() string {
+func (x *FetchChannelDataResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpatialChannelsReadyMessage) ProtoMessage() {}
+func (*FetchChannelDataResultMessage) ProtoMessage() {}
 
-func (x *SpatialChannelsReadyMessage) ProtoReflect() protoreflect.Message {
+func (x *FetchChannelDataResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1954,42 +2242,38 @@ func (x *SpatialChannelsReadyMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpatialChannelsReadyMessage.ProtoReflect.Descriptor instead.
-func (*SpatialChannelsReadyMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use FetchChannelDataResultMessage.ProtoReflect.Descriptor instead.
+func (*FetchChannelDataResultMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *SpatialChannelsReadyMessage) GetServerIndex() uint32 {
+func (x *FetchChannelDataResultMessage) GetResults() []*FetchChannelDataResultMessage_ChannelDataResult {
 	if x != nil {
-		return x.ServerIndex
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-func (x *SpatialChannelsReadyMessage) GetServerCount() uint32 {
+func (x *FetchChannelDataResultMessage) GetFailedChannelIds() []uint32 {
 	if x != nil {
-		return x.ServerCount
+		return x.FailedChannelIds
 	}
-	return 0
+	return nil
 }
 
-// ALL connections in the source AND destination channels receive this messge when a handover happpned.
-// Handover means an object moves from a spatial channel to another. It doesn't necessarily mean the objece moves from a spatial server to another.
-type ChannelDataHandoverMessage struct {
+// Left-handed coordinate system with Y-up rule.
+type SpatialInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SrcChannelId uint32 `protobuf:"varint,1,opt,name=srcChannelId,proto3" json:"srcChannelId,omitempty"`
-	DstChannelId uint32 `protobuf:"varint,2,opt,name=dstChannelId,proto3" json:"dstChannelId,omitempty"`
-	// The ID of the client connection that triggered the handover. If the handover is triggered by server (e.g. NPC movement), the value will be 0.
-	ContextConnId uint32 `protobuf:"varint,3,opt,name=contextConnId,proto3" json:"contextConnId,omitempty"`
-	// The data that migrate from the source channel to the destination channel. It can be the spatial channel data or anything, as long as the spatial servers can use it to process the handover.
-	Data *anypb.Any `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z float64 `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
 }
 
-func (x *ChannelDataHandoverMessage) Reset() {
-	*x = ChannelDataHandoverMessage{}
+func (x *SpatialInfo) Reset() {
+	*x = SpatialInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1997,13 +2281,13 @@ func (x *ChannelDataHandoverMessage) Reset() {
 	}
 }
 
-func (x *ChannelDataHandoverMessage) String() string {
+func (x *SpatialInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelDataHandoverMessage) ProtoMessage() {}
+func (*SpatialInfo) ProtoMessage() {}
 
-func (x *ChannelDataHandoverMessage) ProtoReflect() protoreflect.Message {
+func (x *SpatialInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2015,52 +2299,44 @@ func (x *ChannelDataHandoverMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelDataHandoverMessage.ProtoReflect.Descriptor instead.
-func (*ChannelDataHandoverMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use SpatialInfo.ProtoReflect.Descriptor instead.
+func (*SpatialInfo) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ChannelDataHandoverMessage) GetSrcChannelId() uint32 {
+func (x *SpatialInfo) GetX() float64 {
 	if x != nil {
-		return x.SrcChannelId
+		return x.X
 	}
 	return 0
 }
 
-func (x *ChannelDataHandoverMessage) GetDstChannelId() uint32 {
+func (x *SpatialInfo) GetY() float64 {
 	if x != nil {
-		return x.DstChannelId
+		return x.Y
 	}
 	return 0
 }
 
-func (x *ChannelDataHandoverMessage) GetContextConnId() uint32 {
+func (x *SpatialInfo) GetZ() float64 {
 	if x != nil {
-		return x.ContextConnId
+		return x.Z
 	}
 	return 0
 }
 
-func (x *ChannelDataHandoverMessage) GetData() *anypb.Any {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-type SpatialRegion struct {
+type CreateSpatialChannelsResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Min         *SpatialInfo `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
-	Max         *SpatialInfo `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
-	ChannelId   uint32       `protobuf:"varint,3,opt,name=channelId,proto3" json:"channelId,omitempty"`
-	ServerIndex uint32       `protobuf:"varint,4,opt,name=serverIndex,proto3" json:"serverIndex,omitempty"`
+	SpatialChannelId []uint32 `protobuf:"varint,1,rep,packed,name=spatialChannelId,proto3" json:"spatialChannelId,omitempty"`
+	Metadata         string   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OwnerConnId      uint32   `protobuf:"varint,3,opt,name=ownerConnId,proto3" json:"ownerConnId,omitempty"`
 }
 
-func (x *SpatialRegion) Reset() {
-	*x = SpatialRegion{}
+func (x *CreateSpatialChannelsResultMessage) Reset() {
+	*x = CreateSpatialChannelsResultMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2068,13 +2344,13 @@ func (x *SpatialRegion) Reset() {
 	}
 }
 
-func (x *SpatialRegion) String() string {
+func (x *CreateSpatialChannelsResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpatialRegion) ProtoMessage() {}
+func (*CreateSpatialChannelsResultMessage) ProtoMessage() {}
 
-func (x *SpatialRegion) ProtoReflect() protoreflect.Message {
+func (x *CreateSpatialChannelsResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2086,52 +2362,46 @@ func (x *SpatialRegion) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpatialRegion.ProtoReflect.Descriptor instead.
-func (*SpatialRegion) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateSpatialChannelsResultMessage.ProtoReflect.Descriptor instead.
+func (*CreateSpatialChannelsResultMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SpatialRegion) GetMin() *SpatialInfo {
-	if x != nil {
-		return x.Min
-	}
-	return nil
-}
-
-func (x *SpatialRegion) GetMax() *SpatialInfo {
+func (x *CreateSpatialChannelsResultMessage) GetSpatialChannelId() []uint32 {
 	if x != nil {
-		return x.Max
+		return x.SpatialChannelId
 	}
 	return nil
 }
 
-func (x *SpatialRegion) GetChannelId() uint32 {
+func (x *CreateSpatialChannelsResultMessage) GetMetadata() string {
 	if x != nil {
-		return x.ChannelId
+		return x.Metadata
 	}
-	return 0
+	return ""
 }
 
-func (x *SpatialRegion) GetServerIndex() uint32 {
+func (x *CreateSpatialChannelsResultMessage) GetOwnerConnId() uint32 {
 	if x != nil {
-		return x.ServerIndex
+		return x.OwnerConnId
 	}
 	return 0
 }
 
-// channeld updates the information of spatial channels and regions to the spatial servers.
-// Spatial servers use this information mainly for mapping the position of a spawned object to a correct channelId at realtime (rather than querying it before sending the spawn message to the client).
-// Sent upon the creation of spatial channels (after @CreateSpatialChannelsResultMessage being sent), or any regional change (basiclally caused by the loadbalancer).
-type SpatialRegionsUpdateMessage struct {
+// The message should have channelId = 0 in order to be handled.
+// Response: @QuerySpatialChannelResultMessage
+type QuerySpatialChannelMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Regions []*SpatialRegion `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	SpatialInfo []*SpatialInfo `protobuf:"bytes,1,rep,name=spatialInfo,proto3" json:"spatialInfo,omitempty"`
+	// Optional. The spatial world to query. Empty = the default world.
+	WorldId string `protobuf:"bytes,2,opt,name=worldId,proto3" json:"worldId,omitempty"`
 }
 
-func (x *SpatialRegionsUpdateMessage) Reset() {
-	*x = SpatialRegionsUpdateMessage{}
+func (x *QuerySpatialChannelMessage) Reset() {
+	*x = QuerySpatialChannelMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2139,13 +2409,13 @@ func (x *SpatialRegionsUpdateMessage) Reset() {
 	}
 }
 
-func (x *SpatialRegionsUpdateMessage) String() string {
+func (x *QuerySpatialChannelMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpatialRegionsUpdateMessage) ProtoMessage() {}
+func (*QuerySpatialChannelMessage) ProtoMessage() {}
 
-func (x *SpatialRegionsUpdateMessage) ProtoReflect() protoreflect.Message {
+func (x *QuerySpatialChannelMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2157,31 +2427,35 @@ func (x *SpatialRegionsUpdateMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpatialRegionsUpdateMessage.ProtoReflect.Descriptor instead.
-func (*SpatialRegionsUpdateMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuerySpatialChannelMessage.ProtoReflect.Descriptor instead.
+func (*QuerySpatialChannelMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *SpatialRegionsUpdateMessage) GetRegions() []*SpatialRegion {
+func (x *QuerySpatialChannelMessage) GetSpatialInfo() []*SpatialInfo {
 	if x != nil {
-		return x.Regions
+		return x.SpatialInfo
 	}
 	return nil
 }
 
-type SpatialInterestQuery struct {
+func (x *QuerySpatialChannelMessage) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type QuerySpatialChannelResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SpotsAOI  *SpatialInterestQuery_SpotsAOI  `protobuf:"bytes,1,opt,name=spotsAOI,proto3,oneof" json:"spotsAOI,omitempty"`
-	BoxAOI    *SpatialInterestQuery_BoxAOI    `protobuf:"bytes,2,opt,name=boxAOI,proto3,oneof" json:"boxAOI,omitempty"`
-	SphereAOI *SpatialInterestQuery_SphereAOI `protobuf:"bytes,3,opt,name=sphereAOI,proto3,oneof" json:"sphereAOI,omitempty"`
-	ConeAOI   *SpatialInterestQuery_ConeAOI   `protobuf:"bytes,4,opt,name=coneAOI,proto3,oneof" json:"coneAOI,omitempty"`
+	ChannelId []uint32 `protobuf:"varint,1,rep,packed,name=channelId,proto3" json:"channelId,omitempty"`
 }
 
-func (x *SpatialInterestQuery) Reset() {
-	*x = SpatialInterestQuery{}
+func (x *QuerySpatialChannelResultMessage) Reset() {
+	*x = QuerySpatialChannelResultMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2189,13 +2463,13 @@ func (x *SpatialInterestQuery) Reset() {
 	}
 }
 
-func (x *SpatialInterestQuery) String() string {
+func (x *QuerySpatialChannelResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpatialInterestQuery) ProtoMessage() {}
+func (*QuerySpatialChannelResultMessage) ProtoMessage() {}
 
-func (x *SpatialInterestQuery) ProtoReflect() protoreflect.Message {
+func (x *QuerySpatialChannelResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2207,50 +2481,38 @@ func (x *SpatialInterestQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpatialInterestQuery.ProtoReflect.Descriptor instead.
-func (*SpatialInterestQuery) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuerySpatialChannelResultMessage.ProtoReflect.Descriptor instead.
+func (*QuerySpatialChannelResultMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *SpatialInterestQuery) GetSpotsAOI() *SpatialInterestQuery_SpotsAOI {
-	if x != nil {
-		return x.SpotsAOI
-	}
-	return nil
-}
-
-func (x *SpatialInterestQuery) GetBoxAOI() *SpatialInterestQuery_BoxAOI {
-	if x != nil {
-		return x.BoxAOI
-	}
-	return nil
-}
-
-func (x *SpatialInterestQuery) GetSphereAOI() *SpatialInterestQuery_SphereAOI {
-	if x != nil {
-		return x.SphereAOI
-	}
-	return nil
-}
-
-func (x *SpatialInterestQuery) GetConeAOI() *SpatialInterestQuery_ConeAOI {
+func (x *QuerySpatialChannelResultMessage) GetChannelId() []uint32 {
 	if x != nil {
-		return x.ConeAOI
+		return x.ChannelId
 	}
 	return nil
 }
 
-type UpdateSpatialInterestMessage struct {
+// Query the entities whose positions are in the area of the AOI, across the spatial servers.
+// Only the entity channels whose data implements the spatial info (e.g. the location of the actor) can be found.
+// The message should have channelId = 0 in order to be handled. Only the server connection can send this message.
+// Response: @QuerySpatialEntitiesResultMessage
+type QuerySpatialEntitiesMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConnId uint32                `protobuf:"varint,1,opt,name=connId,proto3" json:"connId,omitempty"`
-	Query  *SpatialInterestQuery `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Query *SpatialInterestQuery `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// If true, the data of the entity channels is included in the result.
+	WithData bool `protobuf:"varint,2,opt,name=withData,proto3" json:"withData,omitempty"`
+	// Only the fields in the masks will be included in the data. If empty, all the fields will be included.
+	DataFieldMasks []string `protobuf:"bytes,3,rep,name=dataFieldMasks,proto3" json:"dataFieldMasks,omitempty"`
+	// Optional. The spatial world to query. Empty = the default world.
+	WorldId string `protobuf:"bytes,4,opt,name=worldId,proto3" json:"worldId,omitempty"`
 }
 
-func (x *UpdateSpatialInterestMessage) Reset() {
-	*x = UpdateSpatialInterestMessage{}
+func (x *QuerySpatialEntitiesMessage) Reset() {
+	*x = QuerySpatialEntitiesMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2258,13 +2520,13 @@ func (x *UpdateSpatialInterestMessage) Reset() {
 	}
 }
 
-func (x *UpdateSpatialInterestMessage) String() string {
+func (x *QuerySpatialEntitiesMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSpatialInterestMessage) ProtoMessage() {}
+func (*QuerySpatialEntitiesMessage) ProtoMessage() {}
 
-func (x *UpdateSpatialInterestMessage) ProtoReflect() protoreflect.Message {
+func (x *QuerySpatialEntitiesMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2276,40 +2538,50 @@ func (x *UpdateSpatialInterestMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSpatialInterestMessage.ProtoReflect.Descriptor instead.
-func (*UpdateSpatialInterestMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuerySpatialEntitiesMessage.ProtoReflect.Descriptor instead.
+func (*QuerySpatialEntitiesMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UpdateSpatialInterestMessage) GetConnId() uint32 {
+func (x *QuerySpatialEntitiesMessage) GetQuery() *SpatialInterestQuery {
 	if x != nil {
-		return x.ConnId
+		return x.Query
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateSpatialInterestMessage) GetQuery() *SpatialInterestQuery {
+func (x *QuerySpatialEntitiesMessage) GetWithData() bool {
 	if x != nil {
-		return x.Query
+		return x.WithData
+	}
+	return false
+}
+
+func (x *QuerySpatialEntitiesMessage) GetDataFieldMasks() []string {
+	if x != nil {
+		return x.DataFieldMasks
 	}
 	return nil
 }
 
-type CreateEntityChannelMessage struct {
+func (x *QuerySpatialEntitiesMessage) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type QuerySpatialEntitiesResultMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EntityId     uint32                      `protobuf:"varint,1,opt,name=entityId,proto3" json:"entityId,omitempty"`
-	Metadata     string                      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	SubOptions   *ChannelSubscriptionOptions `protobuf:"bytes,3,opt,name=subOptions,proto3" json:"subOptions,omitempty"`
-	Data         *anypb.Any                  `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	MergeOptions *ChannelDataMergeOptions    `protobuf:"bytes,5,opt,name=mergeOptions,proto3" json:"mergeOptions,omitempty"`
-	IsWellKnown  bool                        `protobuf:"varint,6,opt,name=isWellKnown,proto3" json:"isWellKnown,omitempty"`
+	// Sorted by the entity ID.
+	Entities []*QuerySpatialEntitiesResultMessage_EntityResult `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
 }
 
-func (x *CreateEntityChannelMessage) Reset() {
-	*x = CreateEntityChannelMessage{}
+func (x *QuerySpatialEntitiesResultMessage) Reset() {
+	*x = QuerySpatialEntitiesResultMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_channeld_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2317,13 +2589,13 @@ func (x *CreateEntityChannelMessage) Reset() {
 	}
 }
 
-func (x *CreateEntityChannelMessage) String() string {
+func (x *QuerySpatialEntitiesResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateEntityChannelMessage) ProtoMessage() {}
+func (*QuerySpatialEntitiesResultMessage) ProtoMessage() {}
 
-func (x *CreateEntityChannelMessage) ProtoReflect() protoreflect.Message {
+func (x *QuerySpatialEntitiesResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_channeld_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2335,80 +2607,1030 @@ func (x *CreateEntityChannelMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateEntityChannelMessage.ProtoReflect.Descriptor instead.
-func (*CreateEntityChannelMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuerySpatialEntitiesResultMessage.ProtoReflect.Descriptor instead.
+func (*QuerySpatialEntitiesResultMessage) Descriptor() ([]byte, []int) {
 	return file_channeld_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *CreateEntityChannelMessage) GetEntityId() uint32 {
+func (x *QuerySpatialEntitiesResultMessage) GetEntities() []*QuerySpatialEntitiesResultMessage_EntityResult {
 	if x != nil {
-		return x.EntityId
+		return x.Entities
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateEntityChannelMessage) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
-	}
-	return ""
-}
+// Indicates that all the spatial channels are created by the spatial servers, so the servers can continue further initialization.
+type SpatialChannelsReadyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *CreateEntityChannelMessage) GetSubOptions() *ChannelSubscriptionOptions {
-	if x != nil {
-		return x.SubOptions
-	}
-	return nil
+	ServerIndex uint32 `protobuf:"varint,1,opt,name=serverIndex,proto3" json:"serverIndex,omitempty"`
+	ServerCount uint32 `protobuf:"varint,2,opt,name=serverCount,proto3" json:"serverCount,omitempty"`
 }
 
-func (x *CreateEntityChannelMessage) GetData() *anypb.Any {
+func (x *SpatialChannelsReadyMessage) Reset() {
+	*x = SpatialChannelsReadyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpatialChannelsReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpatialChannelsReadyMessage) ProtoMessage() {}
+
+func (x *SpatialChannelsReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpatialChannelsReadyMessage.ProtoReflect.Descriptor instead.
+func (*SpatialChannelsReadyMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SpatialChannelsReadyMessage) GetServerIndex() uint32 {
+	if x != nil {
+		return x.ServerIndex
+	}
+	return 0
+}
+
+func (x *SpatialChannelsReadyMessage) GetServerCount() uint32 {
+	if x != nil {
+		return x.ServerCount
+	}
+	return 0
+}
+
+// Sent when a spatial server takes over the spatial channels of a disconnected spatial server (the server failover), and the entity channels in them.
+// All connections in the spatial channels and the entity channels receive this message. The GLOBAL channel owner will also receive this message.
+type ChannelOwnerChangedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelIds []uint32 `protobuf:"varint,1,rep,packed,name=channelIds,proto3" json:"channelIds,omitempty"`
+	// The connection ID of the new owner.
+	OwnerConnId uint32 `protobuf:"varint,2,opt,name=ownerConnId,proto3" json:"ownerConnId,omitempty"`
+	// The index of the spatial server that is taken over.
+	ServerIndex uint32 `protobuf:"varint,3,opt,name=serverIndex,proto3" json:"serverIndex,omitempty"`
+}
+
+func (x *ChannelOwnerChangedMessage) Reset() {
+	*x = ChannelOwnerChangedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelOwnerChangedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelOwnerChangedMessage) ProtoMessage() {}
+
+func (x *ChannelOwnerChangedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelOwnerChangedMessage.ProtoReflect.Descriptor instead.
+func (*ChannelOwnerChangedMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ChannelOwnerChangedMessage) GetChannelIds() []uint32 {
+	if x != nil {
+		return x.ChannelIds
+	}
+	return nil
+}
+
+func (x *ChannelOwnerChangedMessage) GetOwnerConnId() uint32 {
+	if x != nil {
+		return x.OwnerConnId
+	}
+	return 0
+}
+
+func (x *ChannelOwnerChangedMessage) GetServerIndex() uint32 {
+	if x != nil {
+		return x.ServerIndex
+	}
+	return 0
+}
+
+// ALL connections in the source AND destination channels receive this messge when a handover happpned.
+// Handover means an object moves from a spatial channel to another. It doesn't necessarily mean the objece moves from a spatial server to another.
+type ChannelDataHandoverMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SrcChannelId uint32 `protobuf:"varint,1,opt,name=srcChannelId,proto3" json:"srcChannelId,omitempty"`
+	DstChannelId uint32 `protobuf:"varint,2,opt,name=dstChannelId,proto3" json:"dstChannelId,omitempty"`
+	// The ID of the client connection that triggered the handover. If the handover is triggered by server (e.g. NPC movement), the value will be 0.
+	ContextConnId uint32 `protobuf:"varint,3,opt,name=contextConnId,proto3" json:"contextConnId,omitempty"`
+	// The data that migrate from the source channel to the destination channel. It can be the spatial channel data or anything, as long as the spatial servers can use it to process the handover.
+	Data *anypb.Any `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ChannelDataHandoverMessage) Reset() {
+	*x = ChannelDataHandoverMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelDataHandoverMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelDataHandoverMessage) ProtoMessage() {}
+
+func (x *ChannelDataHandoverMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelDataHandoverMessage.ProtoReflect.Descriptor instead.
+func (*ChannelDataHandoverMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ChannelDataHandoverMessage) GetSrcChannelId() uint32 {
+	if x != nil {
+		return x.SrcChannelId
+	}
+	return 0
+}
+
+func (x *ChannelDataHandoverMessage) GetDstChannelId() uint32 {
+	if x != nil {
+		return x.DstChannelId
+	}
+	return 0
+}
+
+func (x *ChannelDataHandoverMessage) GetContextConnId() uint32 {
+	if x != nil {
+		return x.ContextConnId
+	}
+	return 0
+}
+
+func (x *ChannelDataHandoverMessage) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SpatialRegion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min         *SpatialInfo `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max         *SpatialInfo `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	ChannelId   uint32       `protobuf:"varint,3,opt,name=channelId,proto3" json:"channelId,omitempty"`
+	ServerIndex uint32       `protobuf:"varint,4,opt,name=serverIndex,proto3" json:"serverIndex,omitempty"`
+}
+
+func (x *SpatialRegion) Reset() {
+	*x = SpatialRegion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpatialRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpatialRegion) ProtoMessage() {}
+
+func (x *SpatialRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpatialRegion.ProtoReflect.Descriptor instead.
+func (*SpatialRegion) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SpatialRegion) GetMin() *SpatialInfo {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *SpatialRegion) GetMax() *SpatialInfo {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *SpatialRegion) GetChannelId() uint32 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *SpatialRegion) GetServerIndex() uint32 {
+	if x != nil {
+		return x.ServerIndex
+	}
+	return 0
+}
+
+// channeld updates the information of spatial channels and regions to the spatial servers.
+// Spatial servers use this information mainly for mapping the position of a spawned object to a correct channelId at realtime (rather than querying it before sending the spawn message to the client).
+// Sent upon the creation of spatial channels (after @CreateSpatialChannelsResultMessage being sent), or any regional change (basiclally caused by the loadbalancer).
+type SpatialRegionsUpdateMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions []*SpatialRegion `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+}
+
+func (x *SpatialRegionsUpdateMessage) Reset() {
+	*x = SpatialRegionsUpdateMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpatialRegionsUpdateMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpatialRegionsUpdateMessage) ProtoMessage() {}
+
+func (x *SpatialRegionsUpdateMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpatialRegionsUpdateMessage.ProtoReflect.Descriptor instead.
+func (*SpatialRegionsUpdateMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SpatialRegionsUpdateMessage) GetRegions() []*SpatialRegion {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type SpatialInterestQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpotsAOI  *SpatialInterestQuery_SpotsAOI  `protobuf:"bytes,1,opt,name=spotsAOI,proto3,oneof" json:"spotsAOI,omitempty"`
+	BoxAOI    *SpatialInterestQuery_BoxAOI    `protobuf:"bytes,2,opt,name=boxAOI,proto3,oneof" json:"boxAOI,omitempty"`
+	SphereAOI *SpatialInterestQuery_SphereAOI `protobuf:"bytes,3,opt,name=sphereAOI,proto3,oneof" json:"sphereAOI,omitempty"`
+	ConeAOI   *SpatialInterestQuery_ConeAOI   `protobuf:"bytes,4,opt,name=coneAOI,proto3,oneof" json:"coneAOI,omitempty"`
+}
+
+func (x *SpatialInterestQuery) Reset() {
+	*x = SpatialInterestQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpatialInterestQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpatialInterestQuery) ProtoMessage() {}
+
+func (x *SpatialInterestQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpatialInterestQuery.ProtoReflect.Descriptor instead.
+func (*SpatialInterestQuery) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SpatialInterestQuery) GetSpotsAOI() *SpatialInterestQuery_SpotsAOI {
+	if x != nil {
+		return x.SpotsAOI
+	}
+	return nil
+}
+
+func (x *SpatialInterestQuery) GetBoxAOI() *SpatialInterestQuery_BoxAOI {
+	if x != nil {
+		return x.BoxAOI
+	}
+	return nil
+}
+
+func (x *SpatialInterestQuery) GetSphereAOI() *SpatialInterestQuery_SphereAOI {
+	if x != nil {
+		return x.SphereAOI
+	}
+	return nil
+}
+
+func (x *SpatialInterestQuery) GetConeAOI() *SpatialInterestQuery_ConeAOI {
+	if x != nil {
+		return x.ConeAOI
+	}
+	return nil
+}
+
+type UpdateSpatialInterestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConnId uint32                `protobuf:"varint,1,opt,name=connId,proto3" json:"connId,omitempty"`
+	Query  *SpatialInterestQuery `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// Optional. The name of the spatial damping profile to use for the client connection, e.g. "player" or "spectator".
+	// Once set, the profile is kept for the connection's following updates.
+	DampingProfile string `protobuf:"bytes,3,opt,name=dampingProfile,proto3" json:"dampingProfile,omitempty"`
+	// Optional. If true, channeld also subscribes the client connection to the entity channels whose positions are in the query's area,
+	// and unsubscribes it as the entities leave the area. Should be set in each update to keep the entity-level interest.
+	EntityInterest bool `protobuf:"varint,4,opt,name=entityInterest,proto3" json:"entityInterest,omitempty"`
+	// Optional. The spatial world that the query is in. Empty = the default world.
+	WorldId string `protobuf:"bytes,5,opt,name=worldId,proto3" json:"worldId,omitempty"`
+}
+
+func (x *UpdateSpatialInterestMessage) Reset() {
+	*x = UpdateSpatialInterestMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSpatialInterestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSpatialInterestMessage) ProtoMessage() {}
+
+func (x *UpdateSpatialInterestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSpatialInterestMessage.ProtoReflect.Descriptor instead.
+func (*UpdateSpatialInterestMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateSpatialInterestMessage) GetConnId() uint32 {
+	if x != nil {
+		return x.ConnId
+	}
+	return 0
+}
+
+func (x *UpdateSpatialInterestMessage) GetQuery() *SpatialInterestQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *UpdateSpatialInterestMessage) GetDampingProfile() string {
+	if x != nil {
+		return x.DampingProfile
+	}
+	return ""
+}
+
+func (x *UpdateSpatialInterestMessage) GetEntityInterest() bool {
+	if x != nil {
+		return x.EntityInterest
+	}
+	return false
+}
+
+func (x *UpdateSpatialInterestMessage) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type CreateEntityChannelMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Should be in the entity channel ID range, and not leased to another connection (see @AllocateEntityIdsMessage).
+	// 0 = channeld assigns the entity ID, which is returned in @CreateChannelResultMessage.channelId.
+	EntityId     uint32                      `protobuf:"varint,1,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	Metadata     string                      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	SubOptions   *ChannelSubscriptionOptions `protobuf:"bytes,3,opt,name=subOptions,proto3" json:"subOptions,omitempty"`
+	Data         *anypb.Any                  `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	MergeOptions *ChannelDataMergeOptions    `protobuf:"bytes,5,opt,name=mergeOptions,proto3" json:"mergeOptions,omitempty"`
+	// Subscribe all the client connections to the entity channel, including the ones authenticated later.
+	IsWellKnown bool `protobuf:"varint,6,opt,name=isWellKnown,proto3" json:"isWellKnown,omitempty"`
+	// Optional. The subscription templates of the well-known entity. The first template that matches the connection is applied.
+	// If none matches, the client connections are subscribed with the default options, and the server connections are not subscribed.
+	WellKnownSubTemplates []*SubscriptionTemplate `protobuf:"bytes,7,rep,name=wellKnownSubTemplates,proto3" json:"wellKnownSubTemplates,omitempty"`
+	// Optional. Overrides the role-based ACL rules of the ENTITY channel type in the settings.
+	RoleACL *ChannelRoleACL `protobuf:"bytes,8,opt,name=roleACL,proto3" json:"roleACL,omitempty"`
+}
+
+func (x *CreateEntityChannelMessage) Reset() {
+	*x = CreateEntityChannelMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateEntityChannelMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEntityChannelMessage) ProtoMessage() {}
+
+func (x *CreateEntityChannelMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEntityChannelMessage.ProtoReflect.Descriptor instead.
+func (*CreateEntityChannelMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateEntityChannelMessage) GetEntityId() uint32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *CreateEntityChannelMessage) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CreateEntityChannelMessage) GetSubOptions() *ChannelSubscriptionOptions {
+	if x != nil {
+		return x.SubOptions
+	}
+	return nil
+}
+
+func (x *CreateEntityChannelMessage) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateEntityChannelMessage) GetMergeOptions() *ChannelDataMergeOptions {
+	if x != nil {
+		return x.MergeOptions
+	}
+	return nil
+}
+
+func (x *CreateEntityChannelMessage) GetIsWellKnown() bool {
+	if x != nil {
+		return x.IsWellKnown
+	}
+	return false
+}
+
+func (x *CreateEntityChannelMessage) GetWellKnownSubTemplates() []*SubscriptionTemplate {
+	if x != nil {
+		return x.WellKnownSubTemplates
+	}
+	return nil
+}
+
+func (x *CreateEntityChannelMessage) GetRoleACL() *ChannelRoleACL {
+	if x != nil {
+		return x.RoleACL
+	}
+	return nil
+}
+
+// Decides whether and how a connection is auto-subscribed to the well-known entity channel.
+type SubscriptionTemplate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional. Matches the connections of the type. NO_CONNECTION = any type.
+	ConnectionType ConnectionType `protobuf:"varint,1,opt,name=connectionType,proto3,enum=channeldpb.ConnectionType" json:"connectionType,omitempty"`
+	// Optional. Matches the connections that have all the auth tags (e.g. "team": "red"), see AuthTagsProvider.
+	AuthTags map[string]string `protobuf:"bytes,2,rep,name=authTags,proto3" json:"authTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Don't subscribe the matched connections, e.g. the ones of the opposing teams.
+	Exclude bool `protobuf:"varint,3,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	// Optional. The subscription options of the matched connections. Ignored if exclude is true.
+	SubOptions *ChannelSubscriptionOptions `protobuf:"bytes,4,opt,name=subOptions,proto3" json:"subOptions,omitempty"`
+}
+
+func (x *SubscriptionTemplate) Reset() {
+	*x = SubscriptionTemplate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionTemplate) ProtoMessage() {}
+
+func (x *SubscriptionTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionTemplate.ProtoReflect.Descriptor instead.
+func (*SubscriptionTemplate) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubscriptionTemplate) GetConnectionType() ConnectionType {
+	if x != nil {
+		return x.ConnectionType
+	}
+	return ConnectionType_NO_CONNECTION
+}
+
+func (x *SubscriptionTemplate) GetAuthTags() map[string]string {
+	if x != nil {
+		return x.AuthTags
+	}
+	return nil
+}
+
+func (x *SubscriptionTemplate) GetExclude() bool {
+	if x != nil {
+		return x.Exclude
+	}
+	return false
+}
+
+func (x *SubscriptionTemplate) GetSubOptions() *ChannelSubscriptionOptions {
+	if x != nil {
+		return x.SubOptions
+	}
+	return nil
+}
+
+// Add specified entities to the handover/lock group of the entity channel. Should sent by the entity channel owner.
+type AddEntityGroupMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type          EntityGroupType `protobuf:"varint,1,opt,name=type,proto3,enum=channeldpb.EntityGroupType" json:"type,omitempty"`
+	EntitiesToAdd []uint32        `protobuf:"varint,2,rep,packed,name=EntitiesToAdd,proto3" json:"EntitiesToAdd,omitempty"`
+}
+
+func (x *AddEntityGroupMessage) Reset() {
+	*x = AddEntityGroupMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddEntityGroupMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEntityGroupMessage) ProtoMessage() {}
+
+func (x *AddEntityGroupMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEntityGroupMessage.ProtoReflect.Descriptor instead.
+func (*AddEntityGroupMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AddEntityGroupMessage) GetType() EntityGroupType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityGroupType_HANDOVER
+}
+
+func (x *AddEntityGroupMessage) GetEntitiesToAdd() []uint32 {
+	if x != nil {
+		return x.EntitiesToAdd
+	}
+	return nil
+}
+
+// Remove specified entities from the handover/lock group of the entity channel. Should sent by the entity channel owner.
+type RemoveEntityGroupMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type             EntityGroupType `protobuf:"varint,1,opt,name=type,proto3,enum=channeldpb.EntityGroupType" json:"type,omitempty"`
+	EntitiesToRemove []uint32        `protobuf:"varint,2,rep,packed,name=EntitiesToRemove,proto3" json:"EntitiesToRemove,omitempty"`
+}
+
+func (x *RemoveEntityGroupMessage) Reset() {
+	*x = RemoveEntityGroupMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveEntityGroupMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveEntityGroupMessage) ProtoMessage() {}
+
+func (x *RemoveEntityGroupMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveEntityGroupMessage.ProtoReflect.Descriptor instead.
+func (*RemoveEntityGroupMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveEntityGroupMessage) GetType() EntityGroupType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityGroupType_HANDOVER
+}
+
+func (x *RemoveEntityGroupMessage) GetEntitiesToRemove() []uint32 {
+	if x != nil {
+		return x.EntitiesToRemove
+	}
+	return nil
+}
+
+// Lease a block of the entity IDs to the server connection, so the IDs it uses to create the entity channels won't collide with other servers'.
+// The IDs leased to a connection can't be used by other connections, until the connection is closed.
+// The message should have channelId = 0 in order to be handled. Only the server connection can send this message.
+// Response: @AllocateEntityIdsResultMessage
+type AllocateEntityIdsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How many entity IDs to lease.
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *AllocateEntityIdsMessage) Reset() {
+	*x = AllocateEntityIdsMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocateEntityIdsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateEntityIdsMessage) ProtoMessage() {}
+
+func (x *AllocateEntityIdsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateEntityIdsMessage.ProtoReflect.Descriptor instead.
+func (*AllocateEntityIdsMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AllocateEntityIdsMessage) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AllocateEntityIdsResultMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The leased entity IDs are [startEntityId, startEntityId + count).
+	StartEntityId uint32 `protobuf:"varint,1,opt,name=startEntityId,proto3" json:"startEntityId,omitempty"`
+	Count         uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *AllocateEntityIdsResultMessage) Reset() {
+	*x = AllocateEntityIdsResultMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocateEntityIdsResultMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateEntityIdsResultMessage) ProtoMessage() {}
+
+func (x *AllocateEntityIdsResultMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateEntityIdsResultMessage.ProtoReflect.Descriptor instead.
+func (*AllocateEntityIdsResultMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AllocateEntityIdsResultMessage) GetStartEntityId() uint32 {
+	if x != nil {
+		return x.StartEntityId
+	}
+	return 0
+}
+
+func (x *AllocateEntityIdsResultMessage) GetCount() uint32 {
 	if x != nil {
-		return x.Data
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateEntityChannelMessage) GetMergeOptions() *ChannelDataMergeOptions {
+// Puts the entity channel into or out of the dormancy. A dormant entity channel doesn't fan out until the next data update
+// or being woken up by this message, except the first fan-out to the new subscribers.
+// The message should be sent to the entity channel by its owner connection.
+type SetEntityDormancyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// false = wake up the entity channel.
+	Dormant bool `protobuf:"varint,1,opt,name=dormant,proto3" json:"dormant,omitempty"`
+}
+
+func (x *SetEntityDormancyMessage) Reset() {
+	*x = SetEntityDormancyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetEntityDormancyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEntityDormancyMessage) ProtoMessage() {}
+
+func (x *SetEntityDormancyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEntityDormancyMessage.ProtoReflect.Descriptor instead.
+func (*SetEntityDormancyMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetEntityDormancyMessage) GetDormant() bool {
 	if x != nil {
-		return x.MergeOptions
+		return x.Dormant
+	}
+	return false
+}
+
+// Allows or denies the access of the connections that have any of the roles. The roles are assigned at auth time (see AuthTagsProvider).
+type RoleACLRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "*" = any role.
+	Roles []string `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	Allow bool     `protobuf:"varint,2,opt,name=allow,proto3" json:"allow,omitempty"`
+}
+
+func (x *RoleACLRule) Reset() {
+	*x = RoleACLRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoleACLRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleACLRule) ProtoMessage() {}
+
+func (x *RoleACLRule) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleACLRule.ProtoReflect.Descriptor instead.
+func (*RoleACLRule) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RoleACLRule) GetRoles() []string {
+	if x != nil {
+		return x.Roles
 	}
 	return nil
 }
 
-func (x *CreateEntityChannelMessage) GetIsWellKnown() bool {
+func (x *RoleACLRule) GetAllow() bool {
 	if x != nil {
-		return x.IsWellKnown
+		return x.Allow
 	}
 	return false
 }
 
-// Add specified entities to the handover/lock group of the entity channel. Should sent by the entity channel owner.
-type AddEntityGroupMessage struct {
+type RoleACLRuleList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type          EntityGroupType `protobuf:"varint,1,opt,name=type,proto3,enum=channeldpb.EntityGroupType" json:"type,omitempty"`
-	EntitiesToAdd []uint32        `protobuf:"varint,2,rep,packed,name=EntitiesToAdd,proto3" json:"EntitiesToAdd,omitempty"`
+	Rules []*RoleACLRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
 }
 
-func (x *AddEntityGroupMessage) Reset() {
-	*x = AddEntityGroupMessage{}
+func (x *RoleACLRuleList) Reset() {
+	*x = RoleACLRuleList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[29]
+		mi := &file_channeld_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddEntityGroupMessage) String() string {
+func (x *RoleACLRuleList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddEntityGroupMessage) ProtoMessage() {}
+func (*RoleACLRuleList) ProtoMessage() {}
 
-func (x *AddEntityGroupMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[29]
+func (x *RoleACLRuleList) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2419,52 +3641,130 @@ func (x *AddEntityGroupMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddEntityGroupMessage.ProtoReflect.Descriptor instead.
-func (*AddEntityGroupMessage) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use RoleACLRuleList.ProtoReflect.Descriptor instead.
+func (*RoleACLRuleList) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *AddEntityGroupMessage) GetType() EntityGroupType {
+func (x *RoleACLRuleList) GetRules() []*RoleACLRule {
 	if x != nil {
-		return x.Type
+		return x.Rules
 	}
-	return EntityGroupType_HANDOVER
+	return nil
 }
 
-func (x *AddEntityGroupMessage) GetEntitiesToAdd() []uint32 {
+// The role-based ACL rules of a channel. For each access, the first rule that matches the connection's roles decides.
+// If none matches, the access level of the channel type applies. An empty rule list means the rules of the channel type apply.
+type ChannelRoleACL struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sub    []*RoleACLRule `protobuf:"bytes,1,rep,name=sub,proto3" json:"sub,omitempty"`
+	Unsub  []*RoleACLRule `protobuf:"bytes,2,rep,name=unsub,proto3" json:"unsub,omitempty"`
+	Remove []*RoleACLRule `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	// Updating the channel data. Only the denying rules take effect, as the owner or the WRITE_ACCESS subscription is still required.
+	DataWrite []*RoleACLRule `protobuf:"bytes,4,rep,name=dataWrite,proto3" json:"dataWrite,omitempty"`
+	// Sending the user-space messages to the channel, by the message type.
+	UserMessages map[uint32]*RoleACLRuleList `protobuf:"bytes,5,rep,name=userMessages,proto3" json:"userMessages,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ChannelRoleACL) Reset() {
+	*x = ChannelRoleACL{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelRoleACL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelRoleACL) ProtoMessage() {}
+
+func (x *ChannelRoleACL) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelRoleACL.ProtoReflect.Descriptor instead.
+func (*ChannelRoleACL) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ChannelRoleACL) GetSub() []*RoleACLRule {
 	if x != nil {
-		return x.EntitiesToAdd
+		return x.Sub
 	}
 	return nil
 }
 
-// Remove specified entities from the handover/lock group of the entity channel. Should sent by the entity channel owner.
-type RemoveEntityGroupMessage struct {
+func (x *ChannelRoleACL) GetUnsub() []*RoleACLRule {
+	if x != nil {
+		return x.Unsub
+	}
+	return nil
+}
+
+func (x *ChannelRoleACL) GetRemove() []*RoleACLRule {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *ChannelRoleACL) GetDataWrite() []*RoleACLRule {
+	if x != nil {
+		return x.DataWrite
+	}
+	return nil
+}
+
+func (x *ChannelRoleACL) GetUserMessages() map[uint32]*RoleACLRuleList {
+	if x != nil {
+		return x.UserMessages
+	}
+	return nil
+}
+
+// Sent to the connection whose message is denied by the ACL of the channel.
+type AccessDeniedMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type             EntityGroupType `protobuf:"varint,1,opt,name=type,proto3,enum=channeldpb.EntityGroupType" json:"type,omitempty"`
-	EntitiesToRemove []uint32        `protobuf:"varint,2,rep,packed,name=EntitiesToRemove,proto3" json:"EntitiesToRemove,omitempty"`
+	// The type of the denied message.
+	MsgType   uint32                     `protobuf:"varint,1,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	ChannelId uint32                     `protobuf:"varint,2,opt,name=channelId,proto3" json:"channelId,omitempty"`
+	Reason    AccessDeniedMessage_Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=channeldpb.AccessDeniedMessage_Reason" json:"reason,omitempty"`
 }
 
-func (x *RemoveEntityGroupMessage) Reset() {
-	*x = RemoveEntityGroupMessage{}
+func (x *AccessDeniedMessage) Reset() {
+	*x = AccessDeniedMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[30]
+		mi := &file_channeld_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveEntityGroupMessage) String() string {
+func (x *AccessDeniedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveEntityGroupMessage) ProtoMessage() {}
+func (*AccessDeniedMessage) ProtoMessage() {}
 
-func (x *RemoveEntityGroupMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[30]
+func (x *AccessDeniedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2475,23 +3775,30 @@ func (x *RemoveEntityGroupMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveEntityGroupMessage.ProtoReflect.Descriptor instead.
-func (*RemoveEntityGroupMessage) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use AccessDeniedMessage.ProtoReflect.Descriptor instead.
+func (*AccessDeniedMessage) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *RemoveEntityGroupMessage) GetType() EntityGroupType {
+func (x *AccessDeniedMessage) GetMsgType() uint32 {
 	if x != nil {
-		return x.Type
+		return x.MsgType
 	}
-	return EntityGroupType_HANDOVER
+	return 0
 }
 
-func (x *RemoveEntityGroupMessage) GetEntitiesToRemove() []uint32 {
+func (x *AccessDeniedMessage) GetChannelId() uint32 {
 	if x != nil {
-		return x.EntitiesToRemove
+		return x.ChannelId
 	}
-	return nil
+	return 0
+}
+
+func (x *AccessDeniedMessage) GetReason() AccessDeniedMessage_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return AccessDeniedMessage_UNKNOWN
 }
 
 // Client requests the spatail regions information. Only valid in Development mode (with "-dev" launch argument).
@@ -2505,7 +3812,7 @@ type DebugGetSpatialRegionsMessage struct {
 func (x *DebugGetSpatialRegionsMessage) Reset() {
 	*x = DebugGetSpatialRegionsMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[31]
+		mi := &file_channeld_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2518,7 +3825,7 @@ func (x *DebugGetSpatialRegionsMessage) String() string {
 func (*DebugGetSpatialRegionsMessage) ProtoMessage() {}
 
 func (x *DebugGetSpatialRegionsMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[31]
+	mi := &file_channeld_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2531,7 +3838,7 @@ func (x *DebugGetSpatialRegionsMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugGetSpatialRegionsMessage.ProtoReflect.Descriptor instead.
 func (*DebugGetSpatialRegionsMessage) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{31}
+	return file_channeld_proto_rawDescGZIP(), []int{47}
 }
 
 type ListChannelResultMessage_ChannelInfo struct {
@@ -2547,7 +3854,7 @@ type ListChannelResultMessage_ChannelInfo struct {
 func (x *ListChannelResultMessage_ChannelInfo) Reset() {
 	*x = ListChannelResultMessage_ChannelInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[32]
+		mi := &file_channeld_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2560,7 +3867,7 @@ func (x *ListChannelResultMessage_ChannelInfo) String() string {
 func (*ListChannelResultMessage_ChannelInfo) ProtoMessage() {}
 
 func (x *ListChannelResultMessage_ChannelInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[32]
+	mi := &file_channeld_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2587,14 +3894,133 @@ func (x *ListChannelResultMessage_ChannelInfo) GetChannelType() ChannelType {
 	if x != nil {
 		return x.ChannelType
 	}
-	return ChannelType_UNKNOWN
+	return ChannelType_UNKNOWN
+}
+
+func (x *ListChannelResultMessage_ChannelInfo) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type FetchChannelDataResultMessage_ChannelDataResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId uint32     `protobuf:"varint,1,opt,name=channelId,proto3" json:"channelId,omitempty"`
+	Data      *anypb.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *FetchChannelDataResultMessage_ChannelDataResult) Reset() {
+	*x = FetchChannelDataResultMessage_ChannelDataResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchChannelDataResultMessage_ChannelDataResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchChannelDataResultMessage_ChannelDataResult) ProtoMessage() {}
+
+func (x *FetchChannelDataResultMessage_ChannelDataResult) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchChannelDataResultMessage_ChannelDataResult.ProtoReflect.Descriptor instead.
+func (*FetchChannelDataResultMessage_ChannelDataResult) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{22, 0}
+}
+
+func (x *FetchChannelDataResultMessage_ChannelDataResult) GetChannelId() uint32 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *FetchChannelDataResultMessage_ChannelDataResult) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type QuerySpatialEntitiesResultMessage_EntityResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntityId    uint32       `protobuf:"varint,1,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	SpatialInfo *SpatialInfo `protobuf:"bytes,2,opt,name=spatialInfo,proto3" json:"spatialInfo,omitempty"`
+	// Only set if QuerySpatialEntitiesMessage.withData is true and the data of the entity channel is available.
+	Data *anypb.Any `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) Reset() {
+	*x = QuerySpatialEntitiesResultMessage_EntityResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_channeld_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpatialEntitiesResultMessage_EntityResult) ProtoMessage() {}
+
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) ProtoReflect() protoreflect.Message {
+	mi := &file_channeld_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpatialEntitiesResultMessage_EntityResult.ProtoReflect.Descriptor instead.
+func (*QuerySpatialEntitiesResultMessage_EntityResult) Descriptor() ([]byte, []int) {
+	return file_channeld_proto_rawDescGZIP(), []int{28, 0}
+}
+
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) GetEntityId() uint32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) GetSpatialInfo() *SpatialInfo {
+	if x != nil {
+		return x.SpatialInfo
+	}
+	return nil
 }
 
-func (x *ListChannelResultMessage_ChannelInfo) GetMetadata() string {
+func (x *QuerySpatialEntitiesResultMessage_EntityResult) GetData() *anypb.Any {
 	if x != nil {
-		return x.Metadata
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
 type SpatialInterestQuery_SpotsAOI struct {
@@ -2610,7 +4036,7 @@ type SpatialInterestQuery_SpotsAOI struct {
 func (x *SpatialInterestQuery_SpotsAOI) Reset() {
 	*x = SpatialInterestQuery_SpotsAOI{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[33]
+		mi := &file_channeld_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2623,7 +4049,7 @@ func (x *SpatialInterestQuery_SpotsAOI) String() string {
 func (*SpatialInterestQuery_SpotsAOI) ProtoMessage() {}
 
 func (x *SpatialInterestQuery_SpotsAOI) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[33]
+	mi := &file_channeld_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2636,7 +4062,7 @@ func (x *SpatialInterestQuery_SpotsAOI) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpatialInterestQuery_SpotsAOI.ProtoReflect.Descriptor instead.
 func (*SpatialInterestQuery_SpotsAOI) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{26, 0}
+	return file_channeld_proto_rawDescGZIP(), []int{34, 0}
 }
 
 func (x *SpatialInterestQuery_SpotsAOI) GetSpots() []*SpatialInfo {
@@ -2665,7 +4091,7 @@ type SpatialInterestQuery_BoxAOI struct {
 func (x *SpatialInterestQuery_BoxAOI) Reset() {
 	*x = SpatialInterestQuery_BoxAOI{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[34]
+		mi := &file_channeld_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2678,7 +4104,7 @@ func (x *SpatialInterestQuery_BoxAOI) String() string {
 func (*SpatialInterestQuery_BoxAOI) ProtoMessage() {}
 
 func (x *SpatialInterestQuery_BoxAOI) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[34]
+	mi := &file_channeld_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2691,7 +4117,7 @@ func (x *SpatialInterestQuery_BoxAOI) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpatialInterestQuery_BoxAOI.ProtoReflect.Descriptor instead.
 func (*SpatialInterestQuery_BoxAOI) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{26, 1}
+	return file_channeld_proto_rawDescGZIP(), []int{34, 1}
 }
 
 func (x *SpatialInterestQuery_BoxAOI) GetCenter() *SpatialInfo {
@@ -2720,7 +4146,7 @@ type SpatialInterestQuery_SphereAOI struct {
 func (x *SpatialInterestQuery_SphereAOI) Reset() {
 	*x = SpatialInterestQuery_SphereAOI{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[35]
+		mi := &file_channeld_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2733,7 +4159,7 @@ func (x *SpatialInterestQuery_SphereAOI) String() string {
 func (*SpatialInterestQuery_SphereAOI) ProtoMessage() {}
 
 func (x *SpatialInterestQuery_SphereAOI) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[35]
+	mi := &file_channeld_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2746,7 +4172,7 @@ func (x *SpatialInterestQuery_SphereAOI) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpatialInterestQuery_SphereAOI.ProtoReflect.Descriptor instead.
 func (*SpatialInterestQuery_SphereAOI) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{26, 2}
+	return file_channeld_proto_rawDescGZIP(), []int{34, 2}
 }
 
 func (x *SpatialInterestQuery_SphereAOI) GetCenter() *SpatialInfo {
@@ -2778,7 +4204,7 @@ type SpatialInterestQuery_ConeAOI struct {
 func (x *SpatialInterestQuery_ConeAOI) Reset() {
 	*x = SpatialInterestQuery_ConeAOI{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_channeld_proto_msgTypes[36]
+		mi := &file_channeld_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2791,7 +4217,7 @@ func (x *SpatialInterestQuery_ConeAOI) String() string {
 func (*SpatialInterestQuery_ConeAOI) ProtoMessage() {}
 
 func (x *SpatialInterestQuery_ConeAOI) ProtoReflect() protoreflect.Message {
-	mi := &file_channeld_proto_msgTypes[36]
+	mi := &file_channeld_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2804,7 +4230,7 @@ func (x *SpatialInterestQuery_ConeAOI) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpatialInterestQuery_ConeAOI.ProtoReflect.Descriptor instead.
 func (*SpatialInterestQuery_ConeAOI) Descriptor() ([]byte, []int) {
-	return file_channeld_proto_rawDescGZIP(), []int{26, 3}
+	return file_channeld_proto_rawDescGZIP(), []int{34, 3}
 }
 
 func (x *SpatialInterestQuery_ConeAOI) GetCenter() *SpatialInfo {
@@ -2909,7 +4335,7 @@ var file_channeld_proto_rawDesc = []byte{
 	0x61, 0x79, 0x4d, 0x73, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x65, 0x6c,
 	0x66, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x42, 0x12, 0x0a,
 	0x10, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x69, 0x72, 0x73, 0x74, 0x46, 0x61, 0x6e, 0x4f, 0x75,
-	0x74, 0x22, 0xd3, 0x01, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74,
+	0x74, 0x22, 0xe5, 0x02, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74,
 	0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a,
 	0x11, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69,
 	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64,
@@ -2922,327 +4348,548 @@ var file_channeld_proto_rawDesc = []byte{
 	0x63, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x46, 0x69,
 	0x65, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x73, 0x68, 0x6f, 0x75, 0x6c,
 	0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x62, 0x6c, 0x65, 0x4d,
-	0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x22, 0xa8, 0x02, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
-	0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0c, 0x6d, 0x65, 0x72,
-	0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x23, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6f,
-	0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x77,
-	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x4c, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x24, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xf8, 0x02, 0x0a, 0x14, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0c, 0x6d,
+	0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x34,
+	0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x07, 0x72, 0x6f, 0x6c,
+	0x65, 0x41, 0x43, 0x4c, 0x22, 0xb3, 0x01, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
 	0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a,
-	0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0xed, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
-	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x64, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x73, 0x1a, 0x82, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
+	0x65, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x14, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
-	0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
-	0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7c, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x46, 0x0a,
-	0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf5, 0x01, 0x0a, 0x20, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x64, 0x54, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f,
-	0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e,
-	0x49, 0x64, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a,
-	0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x63, 0x6f,
-	0x6e, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63,
+	0x22, 0x77, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x28, 0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0xed, 0x01, 0x0a, 0x18, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x1a, 0x82, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7c, 0x0a, 0x1a, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12,
+	0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73, 0x75, 0x62,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf5, 0x01, 0x0a, 0x20, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x6e, 0x49, 0x64, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x08,
+	0x63, 0x6f, 0x6e, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22,
+	0x38, 0x0a, 0x1e, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x46,
+	0x72, 0x6f, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0xb1, 0x01, 0x0a, 0x24, 0x55, 0x6e,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x63, 0x6f,
+	0x6e, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63,
 	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
 	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x6e, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
 	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x38, 0x0a,
-	0x1e, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x46, 0x72, 0x6f,
-	0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0xb1, 0x01, 0x0a, 0x24, 0x55, 0x6e, 0x73, 0x75,
-	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x39, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
-	0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x6a, 0x0a, 0x18, 0x43,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x6e,
-	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f,
-	0x6e, 0x6e, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x0b, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
-	0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12,
-	0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x7a, 0x22, 0x8e, 0x01,
-	0x0a, 0x22, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10,
-	0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b,
-	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0x57,
-	0x0a, 0x1a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0b,
-	0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53,
-	0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x73, 0x70, 0x61, 0x74,
-	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x40, 0x0a, 0x20, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x1b, 0x53, 0x70, 0x61,
-	0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x61, 0x64,
-	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb4, 0x01, 0x0a,
-	0x1a, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x48, 0x61, 0x6e, 0x64,
-	0x6f, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73,
-	0x72, 0x63, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0c, 0x73, 0x72, 0x63, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
-	0x22, 0x0a, 0x0c, 0x64, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f,
-	0x6e, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0xa5, 0x01, 0x0a, 0x0d, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52,
-	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e,
-	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x6d, 0x69, 0x6e,
-	0x12, 0x29, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69,
-	0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x52, 0x0a, 0x1b, 0x53,
-	0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65,
-	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c,
-	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0xa7, 0x06, 0x0a, 0x14, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x4a, 0x0a, 0x08, 0x73, 0x70, 0x6f, 0x74,
-	0x73, 0x41, 0x4f, 0x49, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x70, 0x6f,
-	0x74, 0x73, 0x41, 0x4f, 0x49, 0x48, 0x00, 0x52, 0x08, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f,
-	0x49, 0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x06, 0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70,
-	0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73,
-	0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x42, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x48, 0x01, 0x52,
-	0x06, 0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x88, 0x01, 0x01, 0x12, 0x4d, 0x0a, 0x09, 0x73, 0x70,
-	0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69,
-	0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e,
-	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x48, 0x02, 0x52, 0x09, 0x73, 0x70, 0x68,
-	0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x65, 0x41, 0x4f, 0x49, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6e,
-	0x65, 0x41, 0x4f, 0x49, 0x48, 0x03, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x88,
-	0x01, 0x01, 0x1a, 0x4f, 0x0a, 0x08, 0x53, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x12, 0x2d,
-	0x0a, 0x05, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69,
-	0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x64, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x69,
-	0x73, 0x74, 0x73, 0x1a, 0x6a, 0x0a, 0x06, 0x42, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x12, 0x2f, 0x0a,
-	0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69,
-	0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x2f,
-	0x0a, 0x06, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x88, 0x01,
+	0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x82, 0x01, 0x0a, 0x20, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
+	0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x2b, 0x0a,
+	0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0x76, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x08, 0x6d, 0x73, 0x67, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x73, 0x67, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0x9f, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x73,
+	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x73,
+	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x61, 0x0a, 0x17, 0x46, 0x65, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x22, 0xff, 0x01, 0x0a, 0x1d, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x55, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x2a, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x1a, 0x5b, 0x0a, 0x11,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x37, 0x0a, 0x0b, 0x53, 0x70, 0x61,
+	0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x01, 0x7a, 0x22, 0x8e, 0x01, 0x0a, 0x22, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61,
+	0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x70, 0x61,
+	0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x10, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x6e, 0x49, 0x64, 0x22, 0x71, 0x0a, 0x1a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x70, 0x61, 0x74,
+	0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x39, 0x0a, 0x0b, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0b, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x40, 0x0a, 0x20, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53,
+	0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0xb3, 0x01, 0x0a, 0x1b, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x77, 0x69, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0e,
+	0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x61, 0x73, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x8d,
+	0x02, 0x0a, 0x21, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x56, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x64, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x8f, 0x01, 0x0a,
+	0x0c, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0b, 0x73, 0x70, 0x61,
+	0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
 	0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74,
-	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x1a,
-	0x54, 0x0a, 0x09, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x12, 0x2f, 0x0a, 0x06,
-	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61,
-	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a,
-	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72,
-	0x61, 0x64, 0x69, 0x75, 0x73, 0x1a, 0x9f, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x65, 0x41, 0x4f,
-	0x49, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53,
-	0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74,
-	0x65, 0x72, 0x12, 0x35, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x61,
+	0x0a, 0x1b, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x73, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x80, 0x01, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e,
+	0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0xb4, 0x01, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x44, 0x61, 0x74, 0x61, 0x48, 0x61, 0x6e, 0x64, 0x6f, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x72, 0x63, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x72, 0x63, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x73, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64,
+	0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa5, 0x01, 0x0a, 0x0d,
+	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
-	0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09,
-	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6e, 0x67,
-	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x73, 0x70, 0x6f, 0x74,
-	0x73, 0x41, 0x4f, 0x49, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x42,
-	0x0c, 0x0a, 0x0a, 0x5f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x42, 0x0a, 0x0a,
-	0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x22, 0x6e, 0x0a, 0x1c, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65,
-	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
-	0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49,
-	0x64, 0x12, 0x36, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70,
-	0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0xb1, 0x02, 0x0a, 0x1a, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70,
-	0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73, 0x75,
-	0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x47, 0x0a, 0x0c, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74,
-	0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0c, 0x6d,
-	0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69,
-	0x73, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0b, 0x69, 0x73, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x22, 0x6e, 0x0a,
-	0x15, 0x41, 0x64, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70,
-	0x62, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x54, 0x6f, 0x41, 0x64, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0d,
-	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x41, 0x64, 0x64, 0x22, 0x77, 0x0a,
-	0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x6f,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x1f, 0x0a, 0x1d, 0x44, 0x65, 0x62, 0x75, 0x67, 0x47,
-	0x65, 0x74, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0xa7, 0x01, 0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x5f,
-	0x42, 0x52, 0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53,
-	0x49, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
-	0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x41,
-	0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x04, 0x12,
-	0x11, 0x0a, 0x0d, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x52,
-	0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f, 0x43, 0x4c,
-	0x49, 0x45, 0x4e, 0x54, 0x10, 0x10, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55,
-	0x54, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x20, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x44,
-	0x4a, 0x41, 0x43, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53, 0x10,
-	0x40, 0x2a, 0x3b, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
-	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
-	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x2a, 0x90,
-	0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b,
-	0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47,
-	0x4c, 0x4f, 0x42, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41,
-	0x54, 0x45, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x55, 0x42, 0x57, 0x4f, 0x52, 0x4c, 0x44,
-	0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x04, 0x12,
-	0x0a, 0x0a, 0x06, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x54,
-	0x45, 0x53, 0x54, 0x10, 0x64, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x31, 0x10, 0x65,
-	0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x32, 0x10, 0x66, 0x12, 0x09, 0x0a, 0x05, 0x54,
-	0x45, 0x53, 0x54, 0x33, 0x10, 0x67, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x34, 0x10,
-	0x68, 0x2a, 0xd9, 0x03, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x08,
-	0x0a, 0x04, 0x41, 0x55, 0x54, 0x48, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x52, 0x45, 0x41,
-	0x54, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e,
-	0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x04,
-	0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c,
-	0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x55, 0x42, 0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x48, 0x41,
-	0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x53, 0x55, 0x42, 0x5f,
-	0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x07, 0x12, 0x17,
-	0x0a, 0x13, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x55,
-	0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x43, 0x4f,
-	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x10, 0x09, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x52, 0x45, 0x41, 0x54,
-	0x45, 0x5f, 0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45,
-	0x4c, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x53, 0x50, 0x41,
-	0x54, 0x49, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x0b, 0x12, 0x19,
-	0x0a, 0x15, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x48,
-	0x41, 0x4e, 0x44, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x0c, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x50, 0x41,
-	0x54, 0x49, 0x41, 0x4c, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x53, 0x5f, 0x55, 0x50, 0x44,
-	0x41, 0x54, 0x45, 0x10, 0x0d, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f,
-	0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x45, 0x53, 0x54,
-	0x10, 0x0e, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x4e, 0x54,
-	0x49, 0x54, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x0f, 0x12, 0x14, 0x0a,
-	0x10, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x41, 0x44,
-	0x44, 0x10, 0x10, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x47, 0x52,
-	0x4f, 0x55, 0x50, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x11, 0x12, 0x1a, 0x0a, 0x16,
-	0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53,
-	0x5f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x12, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x45, 0x42, 0x55,
-	0x47, 0x5f, 0x47, 0x45, 0x54, 0x5f, 0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x52, 0x45,
-	0x47, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x63, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x53, 0x45, 0x52, 0x5f,
-	0x53, 0x50, 0x41, 0x43, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x64, 0x2a, 0x31, 0x0a,
-	0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49,
-	0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4e, 0x41, 0x50, 0x50, 0x59, 0x10, 0x01,
-	0x2a, 0x45, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x41,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x5f, 0x41, 0x43, 0x43, 0x45,
-	0x53, 0x53, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x41, 0x43, 0x43,
-	0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x41,
-	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x02, 0x2a, 0x29, 0x0a, 0x0f, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x48, 0x41,
-	0x4e, 0x44, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x43, 0x4b,
-	0x10, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x65, 0x74, 0x61, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x52, 0x0a, 0x1b, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62,
+	0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa7, 0x06, 0x0a, 0x14, 0x53, 0x70, 0x61, 0x74,
+	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x4a, 0x0a, 0x08, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e,
+	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x48, 0x00, 0x52,
+	0x08, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x06,
+	0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61,
+	0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x42,
+	0x6f, 0x78, 0x41, 0x4f, 0x49, 0x48, 0x01, 0x52, 0x06, 0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x88,
+	0x01, 0x01, 0x12, 0x4d, 0x0a, 0x09, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
+	0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65,
+	0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f,
+	0x49, 0x48, 0x02, 0x52, 0x09, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x4f, 0x49, 0x88, 0x01,
+	0x01, 0x12, 0x47, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e,
+	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x48, 0x03, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x88, 0x01, 0x01, 0x1a, 0x4f, 0x0a, 0x08, 0x53, 0x70,
+	0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
+	0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05,
+	0x73, 0x70, 0x6f, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x69, 0x73, 0x74, 0x73, 0x1a, 0x6a, 0x0a, 0x06, 0x42,
+	0x6f, 0x78, 0x41, 0x4f, 0x49, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
+	0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06,
+	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x06, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x54, 0x0a, 0x09, 0x53, 0x70, 0x68, 0x65, 0x72,
+	0x65, 0x41, 0x4f, 0x49, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70,
+	0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x1a, 0x9f, 0x01,
+	0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x65, 0x41, 0x4f, 0x49, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69,
+	0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x73, 0x70, 0x6f, 0x74, 0x73, 0x41, 0x4f, 0x49, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x62, 0x6f, 0x78, 0x41, 0x4f, 0x49, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x73, 0x70, 0x68, 0x65,
+	0x72, 0x65, 0x41, 0x4f, 0x49, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x65, 0x41, 0x4f,
+	0x49, 0x22, 0xd8, 0x01, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x74,
+	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x61, 0x6d, 0x70, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x61, 0x6d, 0x70,
+	0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0xbf, 0x03, 0x0a,
+	0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x0c, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0c, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x69, 0x73, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x12, 0x56, 0x0a, 0x15, 0x77, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x53, 0x75, 0x62,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x52, 0x15, 0x77, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x53, 0x75, 0x62, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65,
+	0x41, 0x43, 0x4c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x22, 0xc5,
+	0x02, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4a, 0x0a, 0x08, 0x61,
+	0x75, 0x74, 0x68, 0x54, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x61,
+	0x75, 0x74, 0x68, 0x54, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x12, 0x46, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64,
+	0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x73,
+	0x75, 0x62, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x41, 0x75, 0x74,
+	0x68, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6e, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x41, 0x64,
+	0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x54, 0x6f, 0x41, 0x64, 0x64, 0x22, 0x77, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54,
+	0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22,
+	0x30, 0x0a, 0x18, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x49, 0x64, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x5c, 0x0a, 0x1e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x34, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x44, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x74, 0x22, 0x39, 0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x22, 0x40, 0x0a, 0x0f, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e,
+	0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x22, 0x82, 0x03, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x43, 0x4c, 0x12, 0x29, 0x0a, 0x03, 0x73, 0x75, 0x62, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e,
+	0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x03, 0x73, 0x75, 0x62,
+	0x12, 0x2d, 0x0a, 0x05, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x6c,
+	0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x12,
+	0x2f, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x6c,
+	0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x12, 0x35, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x57, 0x72, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62,
+	0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x64, 0x61,
+	0x74, 0x61, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x50, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x43, 0x4c, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x75, 0x73, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x5c, 0x0a, 0x11, 0x55, 0x73, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x6c,
+	0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8a, 0x02, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x64, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x44, 0x65, 0x6e, 0x69, 0x65,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7b, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x4e, 0x4f, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a,
+	0x0a, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x1f, 0x0a,
+	0x1b, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x47, 0x4c, 0x4f, 0x42, 0x41,
+	0x4c, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x0f,
+	0x0a, 0x0b, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x44, 0x45, 0x4e, 0x49, 0x45, 0x44, 0x10, 0x04, 0x12,
+	0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x5f, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x45,
+	0x53, 0x53, 0x10, 0x05, 0x22, 0x1f, 0x0a, 0x1d, 0x44, 0x65, 0x62, 0x75, 0x67, 0x47, 0x65, 0x74,
+	0x53, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0xa7, 0x01, 0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x5f, 0x42, 0x52,
+	0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x49, 0x4e,
+	0x47, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01,
+	0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c,
+	0x5f, 0x42, 0x55, 0x54, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x10, 0x04, 0x12, 0x11, 0x0a,
+	0x0d, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x10, 0x08,
+	0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f, 0x43, 0x4c, 0x49, 0x45,
+	0x4e, 0x54, 0x10, 0x10, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x42, 0x55, 0x54, 0x5f,
+	0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x20, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x44, 0x4a, 0x41,
+	0x43, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53, 0x10, 0x40, 0x2a,
+	0x3b, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x2a, 0x90, 0x01, 0x0a,
+	0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4c, 0x4f,
+	0x42, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45,
+	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x55, 0x42, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x10, 0x03,
+	0x12, 0x0b, 0x0a, 0x07, 0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x04, 0x12, 0x0a, 0x0a,
+	0x06, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x53,
+	0x54, 0x10, 0x64, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x31, 0x10, 0x65, 0x12, 0x09,
+	0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x32, 0x10, 0x66, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53,
+	0x54, 0x33, 0x10, 0x67, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x53, 0x54, 0x34, 0x10, 0x68, 0x2a,
+	0xa5, 0x05, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x41, 0x55, 0x54, 0x48, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45,
+	0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x04, 0x12, 0x10,
+	0x0a, 0x0c, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x05,
+	0x12, 0x12, 0x0a, 0x0e, 0x53, 0x55, 0x42, 0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e,
+	0x45, 0x4c, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x53, 0x55, 0x42, 0x5f, 0x46, 0x52,
+	0x4f, 0x4d, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13,
+	0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x55, 0x50, 0x44,
+	0x41, 0x54, 0x45, 0x10, 0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x10, 0x09, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f,
+	0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10,
+	0x0a, 0x12, 0x19, 0x0a, 0x15, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x53, 0x50, 0x41, 0x54, 0x49,
+	0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x0b, 0x12, 0x19, 0x0a, 0x15,
+	0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x48, 0x41, 0x4e,
+	0x44, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x0c, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x50, 0x41, 0x54, 0x49,
+	0x41, 0x4c, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x53, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x10, 0x0d, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x50,
+	0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x45, 0x53, 0x54, 0x10, 0x0e,
+	0x12, 0x19, 0x0a, 0x15, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x4e, 0x54, 0x49, 0x54,
+	0x59, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x0f, 0x12, 0x14, 0x0a, 0x10, 0x45,
+	0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x41, 0x44, 0x44, 0x10,
+	0x10, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x47, 0x52, 0x4f, 0x55,
+	0x50, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x11, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x50,
+	0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53, 0x5f, 0x52,
+	0x45, 0x41, 0x44, 0x59, 0x10, 0x12, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x45, 0x54, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x13, 0x12, 0x16, 0x0a, 0x12,
+	0x46, 0x45, 0x54, 0x43, 0x48, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x44, 0x41,
+	0x54, 0x41, 0x10, 0x14, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f,
+	0x44, 0x41, 0x54, 0x41, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x4a, 0x45,
+	0x43, 0x54, 0x45, 0x44, 0x10, 0x15, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45,
+	0x4c, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x16, 0x12, 0x1a, 0x0a, 0x16, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x53, 0x50, 0x41, 0x54, 0x49,
+	0x41, 0x4c, 0x5f, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x49, 0x45, 0x53, 0x10, 0x17, 0x12, 0x17, 0x0a,
+	0x13, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59,
+	0x5f, 0x49, 0x44, 0x53, 0x10, 0x18, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x45, 0x54, 0x5f, 0x45, 0x4e,
+	0x54, 0x49, 0x54, 0x59, 0x5f, 0x44, 0x4f, 0x52, 0x4d, 0x41, 0x4e, 0x43, 0x59, 0x10, 0x19, 0x12,
+	0x11, 0x0a, 0x0d, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x44, 0x45, 0x4e, 0x49, 0x45, 0x44,
+	0x10, 0x1a, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x45, 0x42, 0x55, 0x47, 0x5f, 0x47, 0x45, 0x54, 0x5f,
+	0x53, 0x50, 0x41, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x53, 0x10,
+	0x63, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x53, 0x50, 0x41, 0x43, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x64, 0x2a, 0x31, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f,
+	0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x53, 0x4e, 0x41, 0x50, 0x50, 0x59, 0x10, 0x01, 0x2a, 0x26, 0x0a, 0x0c, 0x45, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52,
+	0x4f, 0x54, 0x4f, 0x42, 0x55, 0x46, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e,
+	0x10, 0x01, 0x2a, 0x45, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74,
+	0x61, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x5f, 0x41, 0x43,
+	0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x41,
+	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x52, 0x49, 0x54, 0x45,
+	0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x02, 0x2a, 0x53, 0x0a, 0x18, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x52, 0x52, 0x49, 0x56, 0x41, 0x4c,
+	0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x41, 0x53, 0x54,
+	0x5f, 0x57, 0x52, 0x49, 0x54, 0x45, 0x52, 0x5f, 0x57, 0x49, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x0e,
+	0x0a, 0x0a, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x57, 0x49, 0x4e, 0x53, 0x10, 0x02, 0x2a, 0x29,
+	0x0a, 0x0f, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0c, 0x0a, 0x08, 0x48, 0x41, 0x4e, 0x44, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x77, 0x6f, 0x72, 0x6b,
+	0x69, 0x6e, 0x67, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3257,102 +4904,148 @@ func file_channeld_proto_rawDescGZIP() []byte {
 	return file_channeld_proto_rawDescData
 }
 
-var file_channeld_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
-var file_channeld_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_channeld_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_channeld_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_channeld_proto_goTypes = []interface{}{
-	(BroadcastType)(0),                           // 0: channeldpb.BroadcastType
-	(ConnectionType)(0),                          // 1: channeldpb.ConnectionType
-	(ChannelType)(0),                             // 2: channeldpb.ChannelType
-	(MessageType)(0),                             // 3: channeldpb.MessageType
-	(CompressionType)(0),                         // 4: channeldpb.CompressionType
-	(ChannelDataAccess)(0),                       // 5: channeldpb.ChannelDataAccess
-	(EntityGroupType)(0),                         // 6: channeldpb.EntityGroupType
-	(AuthResultMessage_AuthResult)(0),            // 7: channeldpb.AuthResultMessage.AuthResult
-	(*Packet)(nil),                               // 8: channeldpb.Packet
-	(*MessagePack)(nil),                          // 9: channeldpb.MessagePack
-	(*ServerForwardMessage)(nil),                 // 10: channeldpb.ServerForwardMessage
-	(*AuthMessage)(nil),                          // 11: channeldpb.AuthMessage
-	(*AuthResultMessage)(nil),                    // 12: channeldpb.AuthResultMessage
-	(*ChannelSubscriptionOptions)(nil),           // 13: channeldpb.ChannelSubscriptionOptions
-	(*ChannelDataMergeOptions)(nil),              // 14: channeldpb.ChannelDataMergeOptions
-	(*CreateChannelMessage)(nil),                 // 15: channeldpb.CreateChannelMessage
-	(*CreateChannelResultMessage)(nil),           // 16: channeldpb.CreateChannelResultMessage
-	(*RemoveChannelMessage)(nil),                 // 17: channeldpb.RemoveChannelMessage
-	(*ListChannelMessage)(nil),                   // 18: channeldpb.ListChannelMessage
-	(*ListChannelResultMessage)(nil),             // 19: channeldpb.ListChannelResultMessage
-	(*SubscribedToChannelMessage)(nil),           // 20: channeldpb.SubscribedToChannelMessage
-	(*SubscribedToChannelResultMessage)(nil),     // 21: channeldpb.SubscribedToChannelResultMessage
-	(*UnsubscribedFromChannelMessage)(nil),       // 22: channeldpb.UnsubscribedFromChannelMessage
-	(*UnsubscribedFromChannelResultMessage)(nil), // 23: channeldpb.UnsubscribedFromChannelResultMessage
-	(*ChannelDataUpdateMessage)(nil),             // 24: channeldpb.ChannelDataUpdateMessage
-	(*DisconnectMessage)(nil),                    // 25: channeldpb.DisconnectMessage
-	(*SpatialInfo)(nil),                          // 26: channeldpb.SpatialInfo
-	(*CreateSpatialChannelsResultMessage)(nil),   // 27: channeldpb.CreateSpatialChannelsResultMessage
-	(*QuerySpatialChannelMessage)(nil),           // 28: channeldpb.QuerySpatialChannelMessage
-	(*QuerySpatialChannelResultMessage)(nil),     // 29: channeldpb.QuerySpatialChannelResultMessage
-	(*SpatialChannelsReadyMessage)(nil),          // 30: channeldpb.SpatialChannelsReadyMessage
-	(*ChannelDataHandoverMessage)(nil),           // 31: channeldpb.ChannelDataHandoverMessage
-	(*SpatialRegion)(nil),                        // 32: channeldpb.SpatialRegion
-	(*SpatialRegionsUpdateMessage)(nil),          // 33: channeldpb.SpatialRegionsUpdateMessage
-	(*SpatialInterestQuery)(nil),                 // 34: channeldpb.SpatialInterestQuery
-	(*UpdateSpatialInterestMessage)(nil),         // 35: channeldpb.UpdateSpatialInterestMessage
-	(*CreateEntityChannelMessage)(nil),           // 36: channeldpb.CreateEntityChannelMessage
-	(*AddEntityGroupMessage)(nil),                // 37: channeldpb.AddEntityGroupMessage
-	(*RemoveEntityGroupMessage)(nil),             // 38: channeldpb.RemoveEntityGroupMessage
-	(*DebugGetSpatialRegionsMessage)(nil),        // 39: channeldpb.DebugGetSpatialRegionsMessage
-	(*ListChannelResultMessage_ChannelInfo)(nil), // 40: channeldpb.ListChannelResultMessage.ChannelInfo
-	(*SpatialInterestQuery_SpotsAOI)(nil),        // 41: channeldpb.SpatialInterestQuery.SpotsAOI
-	(*SpatialInterestQuery_BoxAOI)(nil),          // 42: channeldpb.SpatialInterestQuery.BoxAOI
-	(*SpatialInterestQuery_SphereAOI)(nil),       // 43: channeldpb.SpatialInterestQuery.SphereAOI
-	(*SpatialInterestQuery_ConeAOI)(nil),         // 44: channeldpb.SpatialInterestQuery.ConeAOI
-	(*anypb.Any)(nil),                            // 45: google.protobuf.Any
+	(BroadcastType)(0),                                      // 0: channeldpb.BroadcastType
+	(ConnectionType)(0),                                     // 1: channeldpb.ConnectionType
+	(ChannelType)(0),                                        // 2: channeldpb.ChannelType
+	(MessageType)(0),                                        // 3: channeldpb.MessageType
+	(CompressionType)(0),                                    // 4: channeldpb.CompressionType
+	(EncodingType)(0),                                       // 5: channeldpb.EncodingType
+	(ChannelDataAccess)(0),                                  // 6: channeldpb.ChannelDataAccess
+	(ConflictResolutionPolicy)(0),                           // 7: channeldpb.ConflictResolutionPolicy
+	(EntityGroupType)(0),                                    // 8: channeldpb.EntityGroupType
+	(AuthResultMessage_AuthResult)(0),                       // 9: channeldpb.AuthResultMessage.AuthResult
+	(AccessDeniedMessage_Reason)(0),                         // 10: channeldpb.AccessDeniedMessage.Reason
+	(*Packet)(nil),                                          // 11: channeldpb.Packet
+	(*MessagePack)(nil),                                     // 12: channeldpb.MessagePack
+	(*ServerForwardMessage)(nil),                            // 13: channeldpb.ServerForwardMessage
+	(*AuthMessage)(nil),                                     // 14: channeldpb.AuthMessage
+	(*AuthResultMessage)(nil),                               // 15: channeldpb.AuthResultMessage
+	(*ChannelSubscriptionOptions)(nil),                      // 16: channeldpb.ChannelSubscriptionOptions
+	(*ChannelDataMergeOptions)(nil),                         // 17: channeldpb.ChannelDataMergeOptions
+	(*CreateChannelMessage)(nil),                            // 18: channeldpb.CreateChannelMessage
+	(*CreateChannelResultMessage)(nil),                      // 19: channeldpb.CreateChannelResultMessage
+	(*RemoveChannelMessage)(nil),                            // 20: channeldpb.RemoveChannelMessage
+	(*ListChannelMessage)(nil),                              // 21: channeldpb.ListChannelMessage
+	(*ListChannelResultMessage)(nil),                        // 22: channeldpb.ListChannelResultMessage
+	(*SubscribedToChannelMessage)(nil),                      // 23: channeldpb.SubscribedToChannelMessage
+	(*SubscribedToChannelResultMessage)(nil),                // 24: channeldpb.SubscribedToChannelResultMessage
+	(*UnsubscribedFromChannelMessage)(nil),                  // 25: channeldpb.UnsubscribedFromChannelMessage
+	(*UnsubscribedFromChannelResultMessage)(nil),            // 26: channeldpb.UnsubscribedFromChannelResultMessage
+	(*ChannelDataUpdateMessage)(nil),                        // 27: channeldpb.ChannelDataUpdateMessage
+	(*ChannelDataUpdateRejectedMessage)(nil),                // 28: channeldpb.ChannelDataUpdateRejectedMessage
+	(*DisconnectMessage)(nil),                               // 29: channeldpb.DisconnectMessage
+	(*GetChannelDataMessage)(nil),                           // 30: channeldpb.GetChannelDataMessage
+	(*GetChannelDataResultMessage)(nil),                     // 31: channeldpb.GetChannelDataResultMessage
+	(*FetchChannelDataMessage)(nil),                         // 32: channeldpb.FetchChannelDataMessage
+	(*FetchChannelDataResultMessage)(nil),                   // 33: channeldpb.FetchChannelDataResultMessage
+	(*SpatialInfo)(nil),                                     // 34: channeldpb.SpatialInfo
+	(*CreateSpatialChannelsResultMessage)(nil),              // 35: channeldpb.CreateSpatialChannelsResultMessage
+	(*QuerySpatialChannelMessage)(nil),                      // 36: channeldpb.QuerySpatialChannelMessage
+	(*QuerySpatialChannelResultMessage)(nil),                // 37: channeldpb.QuerySpatialChannelResultMessage
+	(*QuerySpatialEntitiesMessage)(nil),                     // 38: channeldpb.QuerySpatialEntitiesMessage
+	(*QuerySpatialEntitiesResultMessage)(nil),               // 39: channeldpb.QuerySpatialEntitiesResultMessage
+	(*SpatialChannelsReadyMessage)(nil),                     // 40: channeldpb.SpatialChannelsReadyMessage
+	(*ChannelOwnerChangedMessage)(nil),                      // 41: channeldpb.ChannelOwnerChangedMessage
+	(*ChannelDataHandoverMessage)(nil),                      // 42: channeldpb.ChannelDataHandoverMessage
+	(*SpatialRegion)(nil),                                   // 43: channeldpb.SpatialRegion
+	(*SpatialRegionsUpdateMessage)(nil),                     // 44: channeldpb.SpatialRegionsUpdateMessage
+	(*SpatialInterestQuery)(nil),                            // 45: channeldpb.SpatialInterestQuery
+	(*UpdateSpatialInterestMessage)(nil),                    // 46: channeldpb.UpdateSpatialInterestMessage
+	(*CreateEntityChannelMessage)(nil),                      // 47: channeldpb.CreateEntityChannelMessage
+	(*SubscriptionTemplate)(nil),                            // 48: channeldpb.SubscriptionTemplate
+	(*AddEntityGroupMessage)(nil),                           // 49: channeldpb.AddEntityGroupMessage
+	(*RemoveEntityGroupMessage)(nil),                        // 50: channeldpb.RemoveEntityGroupMessage
+	(*AllocateEntityIdsMessage)(nil),                        // 51: channeldpb.AllocateEntityIdsMessage
+	(*AllocateEntityIdsResultMessage)(nil),                  // 52: channeldpb.AllocateEntityIdsResultMessage
+	(*SetEntityDormancyMessage)(nil),                        // 53: channeldpb.SetEntityDormancyMessage
+	(*RoleACLRule)(nil),                                     // 54: channeldpb.RoleACLRule
+	(*RoleACLRuleList)(nil),                                 // 55: channeldpb.RoleACLRuleList
+	(*ChannelRoleACL)(nil),                                  // 56: channeldpb.ChannelRoleACL
+	(*AccessDeniedMessage)(nil),                             // 57: channeldpb.AccessDeniedMessage
+	(*DebugGetSpatialRegionsMessage)(nil),                   // 58: channeldpb.DebugGetSpatialRegionsMessage
+	(*ListChannelResultMessage_ChannelInfo)(nil),            // 59: channeldpb.ListChannelResultMessage.ChannelInfo
+	(*FetchChannelDataResultMessage_ChannelDataResult)(nil), // 60: channeldpb.FetchChannelDataResultMessage.ChannelDataResult
+	(*QuerySpatialEntitiesResultMessage_EntityResult)(nil),  // 61: channeldpb.QuerySpatialEntitiesResultMessage.EntityResult
+	(*SpatialInterestQuery_SpotsAOI)(nil),                   // 62: channeldpb.SpatialInterestQuery.SpotsAOI
+	(*SpatialInterestQuery_BoxAOI)(nil),                     // 63: channeldpb.SpatialInterestQuery.BoxAOI
+	(*SpatialInterestQuery_SphereAOI)(nil),                  // 64: channeldpb.SpatialInterestQuery.SphereAOI
+	(*SpatialInterestQuery_ConeAOI)(nil),                    // 65: channeldpb.SpatialInterestQuery.ConeAOI
+	nil,                                                     // 66: channeldpb.SubscriptionTemplate.AuthTagsEntry
+	nil,                                                     // 67: channeldpb.ChannelRoleACL.UserMessagesEntry
+	(*anypb.Any)(nil),                                       // 68: google.protobuf.Any
 }
 var file_channeld_proto_depIdxs = []int32{
-	9,  // 0: channeldpb.Packet.messages:type_name -> channeldpb.MessagePack
-	7,  // 1: channeldpb.AuthResultMessage.result:type_name -> channeldpb.AuthResultMessage.AuthResult
+	12, // 0: channeldpb.Packet.messages:type_name -> channeldpb.MessagePack
+	9,  // 1: channeldpb.AuthResultMessage.result:type_name -> channeldpb.AuthResultMessage.AuthResult
 	4,  // 2: channeldpb.AuthResultMessage.compressionType:type_name -> channeldpb.CompressionType
-	5,  // 3: channeldpb.ChannelSubscriptionOptions.dataAccess:type_name -> channeldpb.ChannelDataAccess
-	2,  // 4: channeldpb.CreateChannelMessage.channelType:type_name -> channeldpb.ChannelType
-	13, // 5: channeldpb.CreateChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
-	45, // 6: channeldpb.CreateChannelMessage.data:type_name -> google.protobuf.Any
-	14, // 7: channeldpb.CreateChannelMessage.mergeOptions:type_name -> channeldpb.ChannelDataMergeOptions
-	2,  // 8: channeldpb.CreateChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
-	2,  // 9: channeldpb.ListChannelMessage.typeFilter:type_name -> channeldpb.ChannelType
-	40, // 10: channeldpb.ListChannelResultMessage.channels:type_name -> channeldpb.ListChannelResultMessage.ChannelInfo
-	13, // 11: channeldpb.SubscribedToChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
-	13, // 12: channeldpb.SubscribedToChannelResultMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
-	1,  // 13: channeldpb.SubscribedToChannelResultMessage.connType:type_name -> channeldpb.ConnectionType
-	2,  // 14: channeldpb.SubscribedToChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
-	1,  // 15: channeldpb.UnsubscribedFromChannelResultMessage.connType:type_name -> channeldpb.ConnectionType
-	2,  // 16: channeldpb.UnsubscribedFromChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
-	45, // 17: channeldpb.ChannelDataUpdateMessage.data:type_name -> google.protobuf.Any
-	26, // 18: channeldpb.QuerySpatialChannelMessage.spatialInfo:type_name -> channeldpb.SpatialInfo
-	45, // 19: channeldpb.ChannelDataHandoverMessage.data:type_name -> google.protobuf.Any
-	26, // 20: channeldpb.SpatialRegion.min:type_name -> channeldpb.SpatialInfo
-	26, // 21: channeldpb.SpatialRegion.max:type_name -> channeldpb.SpatialInfo
-	32, // 22: channeldpb.SpatialRegionsUpdateMessage.regions:type_name -> channeldpb.SpatialRegion
-	41, // 23: channeldpb.SpatialInterestQuery.spotsAOI:type_name -> channeldpb.SpatialInterestQuery.SpotsAOI
-	42, // 24: channeldpb.SpatialInterestQuery.boxAOI:type_name -> channeldpb.SpatialInterestQuery.BoxAOI
-	43, // 25: channeldpb.SpatialInterestQuery.sphereAOI:type_name -> channeldpb.SpatialInterestQuery.SphereAOI
-	44, // 26: channeldpb.SpatialInterestQuery.coneAOI:type_name -> channeldpb.SpatialInterestQuery.ConeAOI
-	34, // 27: channeldpb.UpdateSpatialInterestMessage.query:type_name -> channeldpb.SpatialInterestQuery
-	13, // 28: channeldpb.CreateEntityChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
-	45, // 29: channeldpb.CreateEntityChannelMessage.data:type_name -> google.protobuf.Any
-	14, // 30: channeldpb.CreateEntityChannelMessage.mergeOptions:type_name -> channeldpb.ChannelDataMergeOptions
-	6,  // 31: channeldpb.AddEntityGroupMessage.type:type_name -> channeldpb.EntityGroupType
-	6,  // 32: channeldpb.RemoveEntityGroupMessage.type:type_name -> channeldpb.EntityGroupType
-	2,  // 33: channeldpb.ListChannelResultMessage.ChannelInfo.channelType:type_name -> channeldpb.ChannelType
-	26, // 34: channeldpb.SpatialInterestQuery.SpotsAOI.spots:type_name -> channeldpb.SpatialInfo
-	26, // 35: channeldpb.SpatialInterestQuery.BoxAOI.center:type_name -> channeldpb.SpatialInfo
-	26, // 36: channeldpb.SpatialInterestQuery.BoxAOI.extent:type_name -> channeldpb.SpatialInfo
-	26, // 37: channeldpb.SpatialInterestQuery.SphereAOI.center:type_name -> channeldpb.SpatialInfo
-	26, // 38: channeldpb.SpatialInterestQuery.ConeAOI.center:type_name -> channeldpb.SpatialInfo
-	26, // 39: channeldpb.SpatialInterestQuery.ConeAOI.direction:type_name -> channeldpb.SpatialInfo
-	40, // [40:40] is the sub-list for method output_type
-	40, // [40:40] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	6,  // 3: channeldpb.ChannelSubscriptionOptions.dataAccess:type_name -> channeldpb.ChannelDataAccess
+	7,  // 4: channeldpb.ChannelDataMergeOptions.conflictPolicy:type_name -> channeldpb.ConflictResolutionPolicy
+	2,  // 5: channeldpb.CreateChannelMessage.channelType:type_name -> channeldpb.ChannelType
+	16, // 6: channeldpb.CreateChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
+	68, // 7: channeldpb.CreateChannelMessage.data:type_name -> google.protobuf.Any
+	17, // 8: channeldpb.CreateChannelMessage.mergeOptions:type_name -> channeldpb.ChannelDataMergeOptions
+	56, // 9: channeldpb.CreateChannelMessage.roleACL:type_name -> channeldpb.ChannelRoleACL
+	2,  // 10: channeldpb.CreateChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
+	2,  // 11: channeldpb.ListChannelMessage.typeFilter:type_name -> channeldpb.ChannelType
+	59, // 12: channeldpb.ListChannelResultMessage.channels:type_name -> channeldpb.ListChannelResultMessage.ChannelInfo
+	16, // 13: channeldpb.SubscribedToChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
+	16, // 14: channeldpb.SubscribedToChannelResultMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
+	1,  // 15: channeldpb.SubscribedToChannelResultMessage.connType:type_name -> channeldpb.ConnectionType
+	2,  // 16: channeldpb.SubscribedToChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
+	1,  // 17: channeldpb.UnsubscribedFromChannelResultMessage.connType:type_name -> channeldpb.ConnectionType
+	2,  // 18: channeldpb.UnsubscribedFromChannelResultMessage.channelType:type_name -> channeldpb.ChannelType
+	68, // 19: channeldpb.ChannelDataUpdateMessage.data:type_name -> google.protobuf.Any
+	68, // 20: channeldpb.ChannelDataUpdateRejectedMessage.currentData:type_name -> google.protobuf.Any
+	68, // 21: channeldpb.GetChannelDataResultMessage.data:type_name -> google.protobuf.Any
+	60, // 22: channeldpb.FetchChannelDataResultMessage.results:type_name -> channeldpb.FetchChannelDataResultMessage.ChannelDataResult
+	34, // 23: channeldpb.QuerySpatialChannelMessage.spatialInfo:type_name -> channeldpb.SpatialInfo
+	45, // 24: channeldpb.QuerySpatialEntitiesMessage.query:type_name -> channeldpb.SpatialInterestQuery
+	61, // 25: channeldpb.QuerySpatialEntitiesResultMessage.entities:type_name -> channeldpb.QuerySpatialEntitiesResultMessage.EntityResult
+	68, // 26: channeldpb.ChannelDataHandoverMessage.data:type_name -> google.protobuf.Any
+	34, // 27: channeldpb.SpatialRegion.min:type_name -> channeldpb.SpatialInfo
+	34, // 28: channeldpb.SpatialRegion.max:type_name -> channeldpb.SpatialInfo
+	43, // 29: channeldpb.SpatialRegionsUpdateMessage.regions:type_name -> channeldpb.SpatialRegion
+	62, // 30: channeldpb.SpatialInterestQuery.spotsAOI:type_name -> channeldpb.SpatialInterestQuery.SpotsAOI
+	63, // 31: channeldpb.SpatialInterestQuery.boxAOI:type_name -> channeldpb.SpatialInterestQuery.BoxAOI
+	64, // 32: channeldpb.SpatialInterestQuery.sphereAOI:type_name -> channeldpb.SpatialInterestQuery.SphereAOI
+	65, // 33: channeldpb.SpatialInterestQuery.coneAOI:type_name -> channeldpb.SpatialInterestQuery.ConeAOI
+	45, // 34: channeldpb.UpdateSpatialInterestMessage.query:type_name -> channeldpb.SpatialInterestQuery
+	16, // 35: channeldpb.CreateEntityChannelMessage.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
+	68, // 36: channeldpb.CreateEntityChannelMessage.data:type_name -> google.protobuf.Any
+	17, // 37: channeldpb.CreateEntityChannelMessage.mergeOptions:type_name -> channeldpb.ChannelDataMergeOptions
+	48, // 38: channeldpb.CreateEntityChannelMessage.wellKnownSubTemplates:type_name -> channeldpb.SubscriptionTemplate
+	56, // 39: channeldpb.CreateEntityChannelMessage.roleACL:type_name -> channeldpb.ChannelRoleACL
+	1,  // 40: channeldpb.SubscriptionTemplate.connectionType:type_name -> channeldpb.ConnectionType
+	66, // 41: channeldpb.SubscriptionTemplate.authTags:type_name -> channeldpb.SubscriptionTemplate.AuthTagsEntry
+	16, // 42: channeldpb.SubscriptionTemplate.subOptions:type_name -> channeldpb.ChannelSubscriptionOptions
+	8,  // 43: channeldpb.AddEntityGroupMessage.type:type_name -> channeldpb.EntityGroupType
+	8,  // 44: channeldpb.RemoveEntityGroupMessage.type:type_name -> channeldpb.EntityGroupType
+	54, // 45: channeldpb.RoleACLRuleList.rules:type_name -> channeldpb.RoleACLRule
+	54, // 46: channeldpb.ChannelRoleACL.sub:type_name -> channeldpb.RoleACLRule
+	54, // 47: channeldpb.ChannelRoleACL.unsub:type_name -> channeldpb.RoleACLRule
+	54, // 48: channeldpb.ChannelRoleACL.remove:type_name -> channeldpb.RoleACLRule
+	54, // 49: channeldpb.ChannelRoleACL.dataWrite:type_name -> channeldpb.RoleACLRule
+	67, // 50: channeldpb.ChannelRoleACL.userMessages:type_name -> channeldpb.ChannelRoleACL.UserMessagesEntry
+	10, // 51: channeldpb.AccessDeniedMessage.reason:type_name -> channeldpb.AccessDeniedMessage.Reason
+	2,  // 52: channeldpb.ListChannelResultMessage.ChannelInfo.channelType:type_name -> channeldpb.ChannelType
+	68, // 53: channeldpb.FetchChannelDataResultMessage.ChannelDataResult.data:type_name -> google.protobuf.Any
+	34, // 54: channeldpb.QuerySpatialEntitiesResultMessage.EntityResult.spatialInfo:type_name -> channeldpb.SpatialInfo
+	68, // 55: channeldpb.QuerySpatialEntitiesResultMessage.EntityResult.data:type_name -> google.protobuf.Any
+	34, // 56: channeldpb.SpatialInterestQuery.SpotsAOI.spots:type_name -> channeldpb.SpatialInfo
+	34, // 57: channeldpb.SpatialInterestQuery.BoxAOI.center:type_name -> channeldpb.SpatialInfo
+	34, // 58: channeldpb.SpatialInterestQuery.BoxAOI.extent:type_name -> channeldpb.SpatialInfo
+	34, // 59: channeldpb.SpatialInterestQuery.SphereAOI.center:type_name -> channeldpb.SpatialInfo
+	34, // 60: channeldpb.SpatialInterestQuery.ConeAOI.center:type_name -> channeldpb.SpatialInfo
+	34, // 61: channeldpb.SpatialInterestQuery.ConeAOI.direction:type_name -> channeldpb.SpatialInfo
+	55, // 62: channeldpb.ChannelRoleACL.UserMessagesEntry.value:type_name -> channeldpb.RoleACLRuleList
+	63, // [63:63] is the sub-list for method output_type
+	63, // [63:63] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_channeld_proto_init() }
@@ -3566,7 +5259,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisconnectMessage); i {
+			switch v := v.(*ChannelDataUpdateRejectedMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3578,7 +5271,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialInfo); i {
+			switch v := v.(*DisconnectMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3590,7 +5283,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSpatialChannelsResultMessage); i {
+			switch v := v.(*GetChannelDataMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3602,7 +5295,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuerySpatialChannelMessage); i {
+			switch v := v.(*GetChannelDataResultMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3614,7 +5307,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuerySpatialChannelResultMessage); i {
+			switch v := v.(*FetchChannelDataMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3626,7 +5319,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialChannelsReadyMessage); i {
+			switch v := v.(*FetchChannelDataResultMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3638,7 +5331,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChannelDataHandoverMessage); i {
+			switch v := v.(*SpatialInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3650,7 +5343,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialRegion); i {
+			switch v := v.(*CreateSpatialChannelsResultMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3662,7 +5355,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialRegionsUpdateMessage); i {
+			switch v := v.(*QuerySpatialChannelMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3674,7 +5367,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialInterestQuery); i {
+			switch v := v.(*QuerySpatialChannelResultMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3686,7 +5379,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateSpatialInterestMessage); i {
+			switch v := v.(*QuerySpatialEntitiesMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3698,7 +5391,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateEntityChannelMessage); i {
+			switch v := v.(*QuerySpatialEntitiesResultMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3710,7 +5403,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddEntityGroupMessage); i {
+			switch v := v.(*SpatialChannelsReadyMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3722,7 +5415,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveEntityGroupMessage); i {
+			switch v := v.(*ChannelOwnerChangedMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3734,7 +5427,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugGetSpatialRegionsMessage); i {
+			switch v := v.(*ChannelDataHandoverMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3746,7 +5439,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListChannelResultMessage_ChannelInfo); i {
+			switch v := v.(*SpatialRegion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3758,7 +5451,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialInterestQuery_SpotsAOI); i {
+			switch v := v.(*SpatialRegionsUpdateMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3770,7 +5463,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialInterestQuery_BoxAOI); i {
+			switch v := v.(*SpatialInterestQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3782,7 +5475,7 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpatialInterestQuery_SphereAOI); i {
+			switch v := v.(*UpdateSpatialInterestMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3794,6 +5487,222 @@ func file_channeld_proto_init() {
 			}
 		}
 		file_channeld_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateEntityChannelMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionTemplate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddEntityGroupMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveEntityGroupMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocateEntityIdsMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocateEntityIdsResultMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetEntityDormancyMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleACLRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleACLRuleList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelRoleACL); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccessDeniedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugGetSpatialRegionsMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListChannelResultMessage_ChannelInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchChannelDataResultMessage_ChannelDataResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuerySpatialEntitiesResultMessage_EntityResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpatialInterestQuery_SpotsAOI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpatialInterestQuery_BoxAOI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpatialInterestQuery_SphereAOI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_channeld_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SpatialInterestQuery_ConeAOI); i {
 			case 0:
 				return &v.state
@@ -3807,14 +5716,15 @@ func file_channeld_proto_init() {
 		}
 	}
 	file_channeld_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_channeld_proto_msgTypes[26].OneofWrappers = []interface{}{}
+	file_channeld_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_channeld_proto_msgTypes[34].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_channeld_proto_rawDesc,
-			NumEnums:      8,
-			NumMessages:   37,
+			NumEnums:      11,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
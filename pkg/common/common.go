@@ -63,3 +63,22 @@ func (info *SpatialInfo) Unit2D() SpatialInfo {
 	mag := info.Magnitude2D()
 	return SpatialInfo{X: info.X / mag, Y: info.Y, Z: info.Z / mag}
 }
+
+func (info1 *SpatialInfo) Dist3D(info2 *SpatialInfo) float64 {
+	return math.Sqrt((info1.X-info2.X)*(info1.X-info2.X) + (info1.Y-info2.Y)*(info1.Y-info2.Y) + (info1.Z-info2.Z)*(info1.Z-info2.Z))
+}
+
+func (info1 *SpatialInfo) Dot3D(info2 *SpatialInfo) float64 {
+	return info1.X*info2.X + info1.Y*info2.Y + info1.Z*info2.Z
+}
+
+func (info1 *SpatialInfo) Magnitude3D() float64 {
+	return math.Sqrt(info1.X*info1.X + info1.Y*info1.Y + info1.Z*info1.Z)
+}
+
+func (info *SpatialInfo) Normalize3D() {
+	mag := info.Magnitude3D()
+	info.X /= mag
+	info.Y /= mag
+	info.Z /= mag
+}
@@ -51,7 +51,7 @@ func handleUnrealSpawnObject(ctx channeld.MessageContext) {
 	// Update the message's spatial channelId based on the actor's location
 	oldChId := *spawnMsg.ChannelId
 	if spawnMsg.Location != nil {
-		spatialChId, err := channeld.GetSpatialController().GetChannelId(*spawnMsg.Location.ToSpatialInfo())
+		spatialChId, err := channeld.GetSpatialControllerByChannelId(ctx.Channel.Id()).GetChannelId(*spawnMsg.Location.ToSpatialInfo())
 		if err != nil {
 			ctx.Connection.Logger().Warn("failed to GetChannelId", zap.Error(err),
 				zap.Float32("x", *spawnMsg.Location.X),
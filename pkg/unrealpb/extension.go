@@ -84,3 +84,8 @@ func (dst *SpatialChannelData) RemoveEntity(entityId channeld.EntityId) error {
 	delete(dst.Entities, uint32(entityId))
 	return nil
 }
+
+// Implement [channeld.SpatialChannelEntityCounter]
+func (data *SpatialChannelData) GetEntityCount() int {
+	return len(data.Entities)
+}